@@ -252,6 +252,21 @@ func (c *LocalActionsCache) FindMetadata(spec string) (*ActionMetadata, bool, er
 	return &meta, false, nil
 }
 
+// checkLocalActionPath checks the path of the local action whose metadata was not found. The path
+// is wrong when it is a file such as "./path/to/action/action.yml". A directory without action
+// metadata is not reported since the action may be put there at running the workflow (#25, #40).
+// This method does not use the cache so that the error is reported at every "uses:" of the path.
+func (c *LocalActionsCache) checkLocalActionPath(spec string) error {
+	if c.proj == nil || !strings.HasPrefix(spec, "./") {
+		return nil
+	}
+	p := filepath.Join(c.proj.RootDir(), filepath.FromSlash(spec))
+	if s, err := os.Stat(p); err != nil || s.IsDir() {
+		return nil
+	}
+	return fmt.Errorf("local action %q must be a directory which contains \"action.yml\" or \"action.yaml\" but it is a file. specify the directory of the action like %q", spec, spec[:strings.LastIndexByte(spec, '/')])
+}
+
 func (c *LocalActionsCache) readLocalActionMetadataFile(dir string) ([]byte, string, bool) {
 	for _, f := range []string{"action.yaml", "action.yml"} {
 		p := filepath.Join(dir, f)
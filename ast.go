@@ -211,6 +211,8 @@ type DispatchInput struct {
 	Type WorkflowDispatchEventInputType
 	// Options is list of options of choice type
 	Options []*String
+	// Comment is a line comment at the line where the input is declared. Empty when no comment exists.
+	Comment string
 }
 
 // WorkflowDispatchEvent is event on dispatching workflow manually.
@@ -375,6 +377,8 @@ type Concurrency struct {
 	Group *String
 	// CancelInProgress is a flag that shows if canceling this workflow cancels other jobs in progress.
 	CancelInProgress *Bool
+	// Comment is a line comment at the line of 'concurrency' section. Empty when no comment exists.
+	Comment string
 	// Pos is a position in source.
 	Pos *Pos
 }
@@ -417,6 +421,8 @@ type ExecRun struct {
 	WorkingDirectory *String
 	// RunPos is position of 'run' section
 	RunPos *Pos
+	// RunComment is a line comment at the line of 'run' section. Empty when no comment exists.
+	RunComment string
 }
 
 // Kind returns kind of the step execution.
@@ -438,6 +444,8 @@ type Input struct {
 type ExecAction struct {
 	// https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#jobsjob_idstepsuses
 	Uses *String
+	// UsesComment is a line comment at the line of 'uses' section. Empty when no comment exists.
+	UsesComment string
 	// Inputs represents inputs to the action to execute in 'with' section. Keys are in lower case since they are case-insensitive.
 	Inputs map[string]*Input
 	// Entrypoint represents optional 'entrypoint' field in 'with' section. Nil field means nothing specified
@@ -929,3 +937,96 @@ func (w *Workflow) FindWorkflowCallEvent() (*WorkflowCallEvent, bool) {
 	}
 	return nil, false
 }
+
+// ActionInput is an input of action metadata.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#inputs
+type ActionInput struct {
+	// Name is a name of the input.
+	Name *String
+	// Description is a description of the input.
+	Description *String
+	// Required is true when the input is required.
+	Required *Bool
+	// Default is a default value of the input.
+	Default *String
+	// DeprecationMessage is a message shown when the deprecated input is used.
+	DeprecationMessage *String
+}
+
+// ActionOutput is an output of action metadata.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#outputs-for-docker-container-and-javascript-actions
+type ActionOutput struct {
+	// Name is a name of the output.
+	Name *String
+	// Description is a description of the output.
+	Description *String
+	// Value is a value of the output. This is only available for composite actions.
+	// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#outputsoutput_idvalue
+	Value *String
+}
+
+// ActionRuns is "runs" section of action metadata. Which fields are available depends on the kind
+// of action. JavaScript action, Docker action, or composite action.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs
+type ActionRuns struct {
+	// Using is a runner name of the action such as "node20", "docker", or "composite".
+	Using *String
+	// Main is a file path to the entrypoint of JavaScript action.
+	Main *String
+	// Pre is a file path to the script run before the main script of JavaScript action.
+	Pre *String
+	// PreIf is a condition to run "pre" script.
+	PreIf *String
+	// Post is a file path to the script run after the main script of JavaScript action.
+	Post *String
+	// PostIf is a condition to run "post" script.
+	PostIf *String
+	// Steps is steps run by composite action.
+	Steps []*Step
+	// Image is a Docker image or a path to Dockerfile of Docker action.
+	Image *String
+	// PreEntrypoint is an entrypoint run before the main entrypoint of Docker action.
+	PreEntrypoint *String
+	// Entrypoint overrides the ENTRYPOINT of Dockerfile of Docker action.
+	Entrypoint *String
+	// PostEntrypoint is an entrypoint run after the main entrypoint of Docker action.
+	PostEntrypoint *String
+	// Args is arguments passed to the entrypoint of Docker action.
+	Args []*String
+	// Env is environment variables set in the container of Docker action.
+	Env *Env
+	// Pos is a position in source.
+	Pos *Pos
+}
+
+// ActionBranding is "branding" section of action metadata.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#branding
+type ActionBranding struct {
+	// Icon is a name of Feather icon.
+	Icon *String
+	// Color is a background color of the badge.
+	Color *String
+	// Pos is a position in source.
+	Pos *Pos
+}
+
+// Action is root of action metadata syntax tree, which represents one action.yml file.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions
+type Action struct {
+	// Name is a name of the action.
+	Name *String
+	// Author is a name of the author of the action.
+	Author *String
+	// Description is a description of the action.
+	Description *String
+	// Inputs is mappings from input ID to the input object. Keys are in lower case since they are
+	// case-insensitive.
+	Inputs map[string]*ActionInput
+	// Outputs is mappings from output ID to the output object. Keys are in lower case since they
+	// are case-insensitive.
+	Outputs map[string]*ActionOutput
+	// Runs is configuration of how the action is run.
+	Runs *ActionRuns
+	// Branding is configuration of the badge of the action in GitHub Marketplace.
+	Branding *ActionBranding
+}
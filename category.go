@@ -0,0 +1,75 @@
+package actionlint
+
+const (
+	// CategorySyntax is a category of errors in syntax and configuration of workflows and actions
+	// such as unexpected keys, undefined job IDs, and unknown runner labels.
+	CategorySyntax = "syntax"
+	// CategoryExpression is a category of errors in expressions embedded with ${{ }} syntax and in
+	// "if:" conditions.
+	CategoryExpression = "expression"
+	// CategoryScript is a category of errors in scripts at "run:" such as errors reported by
+	// shellcheck and pyflakes.
+	CategoryScript = "script"
+	// CategorySecurity is a category of errors which may cause security issues such as script
+	// injection, leaked secrets, and insufficient or excessive permissions.
+	CategorySecurity = "security"
+	// CategoryStyle is a category of errors which don't break workflows but are considered as bad
+	// practices such as unused inputs, missing timeouts, and deprecated runtimes.
+	CategoryStyle = "style"
+	// CategoryOther is a category of errors reported by rules which don't belong to any category
+	// such as your own rules.
+	CategoryOther = "other"
+)
+
+// RuleCategories is a mapping from names of the built-in rules to their categories. Errors reported
+// by the rules have the categories in their Category field. It is useful to group or suppress
+// errors by category without matching rule names.
+var RuleCategories = map[string]string{
+	"action":              CategorySyntax,
+	"action-permissions":  CategorySecurity,
+	"artifact":            CategorySyntax,
+	"broad-permissions":   CategorySecurity,
+	"cache-key":           CategoryStyle,
+	"concurrency":         CategoryStyle,
+	"credentials":         CategorySecurity,
+	"deprecated-commands": CategoryScript,
+	"env-var":             CategorySyntax,
+	"environment":         CategorySyntax,
+	"events":              CategorySyntax,
+	"expression":          CategoryExpression,
+	"gating-jobs":         CategoryStyle,
+	"glob":                CategorySyntax,
+	"id":                  CategorySyntax,
+	"if-cond":             CategoryExpression,
+	"job-needs":           CategorySyntax,
+	"job-outputs":         CategoryExpression,
+	"job-timeout":         CategoryStyle,
+	"matrix":              CategorySyntax,
+	"multiline-env":       CategoryScript,
+	"node-runtime":        CategoryStyle,
+	"permissions":         CategorySyntax,
+	"pyflakes":            CategoryScript,
+	"reserved-env":        CategorySyntax,
+	"runner-label":        CategorySyntax,
+	"secret-print":        CategorySecurity,
+	"services":            CategorySyntax,
+	"shallow-checkout":    CategoryScript,
+	"shell-name":          CategoryScript,
+	"shellcheck":          CategoryScript,
+	"syntax-check":        CategorySyntax,
+	"unreachable-steps":   CategoryScript,
+	"untrusted-checkout":  CategorySecurity,
+	"unused-inputs":       CategoryStyle,
+	"unused-step-outputs": CategoryStyle,
+	"workflow-call":       CategorySyntax,
+	"working-directory":   CategorySyntax,
+}
+
+// RuleCategory returns the category of errors reported by the rule. The argument is a rule name,
+// which is the same as Kind field of Error. CategoryOther is returned for unknown rules.
+func RuleCategory(rule string) string {
+	if c, ok := RuleCategories[rule]; ok {
+		return c
+	}
+	return CategoryOther
+}
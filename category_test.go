@@ -0,0 +1,73 @@
+package actionlint
+
+import (
+	"io"
+	"testing"
+)
+
+func TestCategoryAllRulesHaveCategory(t *testing.T) {
+	names := map[string]struct{}{}
+	for _, r := range builtinRuleFields() {
+		names[r.Name] = struct{}{}
+		if _, ok := RuleCategories[r.Name]; !ok {
+			t.Errorf("category of rule %q is missing", r.Name)
+		}
+	}
+	for n := range RuleCategories {
+		if _, ok := names[n]; !ok {
+			t.Errorf("category of rule %q exists but the rule is not registered", n)
+		}
+	}
+}
+
+func TestCategoryUnknownRule(t *testing.T) {
+	if c := RuleCategory("my-own-rule"); c != CategoryOther {
+		t.Fatalf("wanted %q but got %q", CategoryOther, c)
+	}
+	if c := RuleCategory("expression"); c != CategoryExpression {
+		t.Fatalf("wanted %q but got %q", CategoryExpression, c)
+	}
+}
+
+func TestCategoryPopulatedByLinter(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	src := `on: push
+jobs:
+  test:
+    runs-on: linux-latest
+    steps:
+      - run: echo ${{ unknown.foo }}
+    foo: bar
+`
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"syntax-check": CategorySyntax,
+		"runner-label": CategorySyntax,
+		"expression":   CategoryExpression,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %v", len(want), errs)
+	}
+	for _, e := range errs {
+		c, ok := want[e.Kind]
+		if !ok {
+			t.Errorf("unexpected error: %s", e)
+			continue
+		}
+		if e.Category != c {
+			t.Errorf("wanted category %q for %q error but got %q", c, e.Kind, e.Category)
+		}
+		if f := e.GetTemplateFields(nil); f.Category != c {
+			t.Errorf("wanted category %q in template fields of %q error but got %q", c, e.Kind, f.Category)
+		}
+	}
+}
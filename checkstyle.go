@@ -0,0 +1,71 @@
+package actionlint
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Types for Checkstyle XML format. Only the elements and attributes used by actionlint are defined.
+// https://checkstyle.sourceforge.io/
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+type checkstyleFile struct {
+	Name   string             `xml:"name,attr"`
+	Errors []*checkstyleError `xml:"error"`
+}
+
+type checkstyleResult struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+// printErrorsInCheckstyle prints the errors in Checkstyle XML format. The "source" attribute of
+// each error is the rule name. Checked files with no error are also output as empty <file>
+// elements.
+func printErrorsInCheckstyle(out io.Writer, errs []*ErrorTemplateFields, rules []*ruleTemplateFields, files []string) error {
+	fs := make([]*checkstyleFile, 0, len(files))
+	idx := make(map[string]*checkstyleFile, len(files))
+	for _, p := range files {
+		f := &checkstyleFile{Name: p}
+		fs = append(fs, f)
+		idx[p] = f
+	}
+
+	for _, e := range errs {
+		f, ok := idx[e.Filepath]
+		if !ok {
+			f = &checkstyleFile{Name: e.Filepath}
+			fs = append(fs, f)
+			idx[e.Filepath] = f
+		}
+		f.Errors = append(f.Errors, &checkstyleError{
+			Line:     e.Line,
+			Column:   e.Column,
+			Severity: severityOrDefault(e.Severity),
+			Message:  e.Message,
+			Source:   e.Kind,
+		})
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return fmt.Errorf("could not write Checkstyle XML: %w", err)
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&checkstyleResult{Version: "4.3", Files: fs}); err != nil {
+		return fmt.Errorf("could not encode errors into Checkstyle XML: %w", err)
+	}
+	if _, err := io.WriteString(out, "\n"); err != nil {
+		return fmt.Errorf("could not write Checkstyle XML: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package actionlint
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckstylePrintErrors(t *testing.T) {
+	errs := []*ErrorTemplateFields{
+		{
+			Message:  `"foo" & <bar>`,
+			Filepath: "b.yaml",
+			Line:     1,
+			Column:   2,
+			Kind:     "expression",
+		},
+		{
+			Message:  "error in file not registered",
+			Filepath: "c.yaml",
+			Line:     3,
+			Column:   4,
+			Kind:     "syntax-check",
+			Severity: "warning",
+		},
+	}
+
+	var b strings.Builder
+	if err := printErrorsInCheckstyle(&b, errs, nil, []string{"a.yaml", "b.yaml"}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	if !strings.HasPrefix(out, xml.Header) {
+		t.Fatalf("XML header is missing: %q", out)
+	}
+	if !strings.Contains(out, `message="&#34;foo&#34; &amp; &lt;bar&gt;"`) {
+		t.Fatalf("special characters in message are not escaped: %q", out)
+	}
+
+	var have checkstyleResult
+	if err := xml.Unmarshal([]byte(out), &have); err != nil {
+		t.Fatalf("output is not valid XML: %v: %q", err, out)
+	}
+
+	want := checkstyleResult{
+		XMLName: xml.Name{Local: "checkstyle"},
+		Version: "4.3",
+		Files: []*checkstyleFile{
+			{Name: "a.yaml"},
+			{
+				Name:   "b.yaml",
+				Errors: []*checkstyleError{{1, 2, "error", `"foo" & <bar>`, "expression"}},
+			},
+			{
+				Name:   "c.yaml",
+				Errors: []*checkstyleError{{3, 4, "warning", "error in file not registered", "syntax-check"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
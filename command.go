@@ -4,9 +4,13 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
 	"runtime"
 	"runtime/debug"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 // These variables might be modified by ldflags on building release binaries by GoReleaser. Do not modify manually
@@ -46,6 +50,12 @@ func printUsageHeader(out io.Writer) {
 
     $ actionlint file1.yaml file2.yaml
 
+  To check all workflow files in '.github/workflows' directories under some
+  directory recursively (e.g. subprojects in a monorepo), pass the directory
+  paths as arguments:
+
+    $ actionlint services/
+
   To check content which is not saved in file yet (e.g. output from some
   command), pass - argument. It reads stdin and checks it as workflow file:
 
@@ -56,6 +66,12 @@ func printUsageHeader(out io.Writer) {
 
     $ actionlint -format '{{json .}}'
 
+  To show the list of rules or the details of the rule, use explain
+  subcommand:
+
+    $ actionlint explain
+    $ actionlint explain runner-label
+
 Documents:
 
   - List of checks: https://github.com/rhysd/actionlint/tree/%s/docs/checks.md
@@ -89,7 +105,7 @@ type Command struct {
 	Stderr io.Writer
 }
 
-func (cmd *Command) runLinter(args []string, opts *LinterOptions, initConfig bool) ([]*Error, error) {
+func (cmd *Command) runLinter(args []string, opts *LinterOptions, initConfig bool, gitRev string) ([]*Error, error) {
 	l, err := NewLinter(cmd.Stdout, opts)
 	if err != nil {
 		return nil, err
@@ -99,6 +115,10 @@ func (cmd *Command) runLinter(args []string, opts *LinterOptions, initConfig boo
 		return nil, l.GenerateDefaultConfig("")
 	}
 
+	if gitRev != "" {
+		return l.LintGitRevision(gitRev)
+	}
+
 	if len(args) == 0 {
 		return l.LintRepository("")
 	}
@@ -107,7 +127,62 @@ func (cmd *Command) runLinter(args []string, opts *LinterOptions, initConfig boo
 		return l.LintStdin(cmd.Stdin)
 	}
 
-	return l.LintFiles(args, nil)
+	// Directories are expanded to workflow files in ".github/workflows" directories under them. Glob
+	// patterns such as "**/*.yaml" are expanded to the matched files so that they are handled in the
+	// same way regardless of shells.
+	files := make([]string, 0, len(args))
+	for _, a := range args {
+		s, err := os.Stat(a)
+		if err != nil && strings.ContainsAny(a, "*?[{") {
+			found, err := doublestar.FilepathGlob(a, doublestar.WithFilesOnly())
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob pattern %q in arguments: %w", a, err)
+			}
+			if len(found) == 0 {
+				return nil, fmt.Errorf("no file matches to the glob pattern %q in arguments", a)
+			}
+			found, err = l.filterIgnoredFiles(found, nil)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, found...)
+			continue
+		}
+		if err == nil && s.IsDir() {
+			found, err := findWorkflowFiles(a)
+			if err != nil {
+				return nil, err
+			}
+			found, err = l.filterIgnoredFiles(found, nil)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, found...)
+			continue
+		}
+		files = append(files, a)
+	}
+
+	return l.LintFiles(files, nil)
+}
+
+// explain runs `explain` subcommand. It lists all rules when no rule name is given. Otherwise it
+// shows the details of the given rule.
+func (cmd *Command) explain(args []string) int {
+	switch len(args) {
+	case 0:
+		printRuleList(cmd.Stdout)
+		return ExitStatusSuccessNoProblem
+	case 1:
+		if err := printRuleExplanation(cmd.Stdout, args[0]); err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusInvalidCommandOption
+		}
+		return ExitStatusSuccessNoProblem
+	default:
+		fmt.Fprintf(cmd.Stderr, "\"explain\" subcommand takes at most one rule name but got %d arguments\n", len(args))
+		return ExitStatusInvalidCommandOption
+	}
 }
 
 type ignorePatternFlags []string
@@ -120,16 +195,64 @@ func (i *ignorePatternFlags) Set(v string) error {
 	return nil
 }
 
+type configFileFlags []string
+
+func (c *configFileFlags) String() string {
+	return "option for config files"
+}
+func (c *configFileFlags) Set(v string) error {
+	*c = append(*c, v)
+	return nil
+}
+
+// colorFlag is a value of -color option. It is "auto", "always", or "never". It can also be used
+// as a boolean flag like -color, which means "always", for backward compatibility.
+type colorFlag ColorOptionKind
+
+func (c *colorFlag) String() string {
+	switch ColorOptionKind(*c) {
+	case ColorOptionKindAlways:
+		return "always"
+	case ColorOptionKindNever:
+		return "never"
+	default:
+		return "auto"
+	}
+}
+func (c *colorFlag) Set(v string) error {
+	switch strings.ToLower(v) {
+	case "auto":
+		*c = colorFlag(ColorOptionKindAuto)
+	case "always", "true":
+		*c = colorFlag(ColorOptionKindAlways)
+	case "never", "false":
+		*c = colorFlag(ColorOptionKindNever)
+	default:
+		return fmt.Errorf("invalid value %q. it must be \"auto\", \"always\", or \"never\"", v)
+	}
+	return nil
+}
+func (c *colorFlag) IsBoolFlag() bool {
+	return true
+}
+
 // Main is main function of actionlint. It takes command line arguments as string slice and returns
 // exit status. The args should be entire arguments including the program name, usually given via
 // os.Args.
 func (cmd *Command) Main(args []string) int {
 	var ver bool
+	var listRules bool
 	var opts LinterOptions
 	var ignorePats ignorePatternFlags
+	var configFiles configFileFlags
 	var initConfig bool
 	var noColor bool
-	var color bool
+	var color colorFlag
+	var failOn string
+	var exitZero bool
+	var formatFile string
+	var gitRev string
+	var noCache bool
 
 	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
 	flags.SetOutput(cmd.Stderr)
@@ -137,15 +260,30 @@ func (cmd *Command) Main(args []string) int {
 	flags.StringVar(&opts.Shellcheck, "shellcheck", "shellcheck", "Command name or file path of \"shellcheck\" external command. If empty, shellcheck integration will be disabled")
 	flags.StringVar(&opts.Pyflakes, "pyflakes", "pyflakes", "Command name or file path of \"pyflakes\" external command. If empty, pyflakes integration will be disabled")
 	flags.BoolVar(&opts.Oneline, "oneline", false, "Use one line per one error. Useful for reading error messages from programs")
-	flags.StringVar(&opts.Format, "format", "", "Custom template to format error messages in Go template syntax. See the usage documentation for more details")
-	flags.StringVar(&opts.ConfigFile, "config-file", "", "File path to config file")
+	flags.StringVar(&opts.Format, "format", "", "Custom template to format error messages in Go template syntax, \"json\" to output errors in JSON, \"sarif\" to output errors in SARIF format, \"checkstyle\" to output errors in Checkstyle XML format, \"gcc\" to output one error per line in GCC-style format, \"github\" to output errors as annotations of GitHub Actions, or \"rdjsonl\" to output errors in reviewdog's RDJSONL format. See the usage documentation for more details")
+	flags.StringVar(&formatFile, "format-file", "", "File path to the template to format error messages. The template is treated in the same way as the value of -format option. This option cannot be used with -format option")
+	flags.Var(&configFiles, "config-file", "File path to config file. This flag is repeatable. Later config files are merged into earlier ones")
 	flags.BoolVar(&initConfig, "init-config", false, "Generate default config file at .github/actionlint.yaml in current project")
-	flags.BoolVar(&noColor, "no-color", false, "Disable colorful output")
-	flags.BoolVar(&color, "color", false, "Always enable colorful output. This is useful to force colorful outputs")
+	flags.BoolVar(&noColor, "no-color", false, "Disable colorful output. This is a shorthand of -color=never")
+	flags.Var(&color, "color", "When to enable colorful output. \"auto\", \"always\", or \"never\". With \"auto\", colors are enabled only when the output is a terminal and $NO_COLOR is not set. -color without value means \"always\", which is useful to force colorful outputs")
 	flags.BoolVar(&opts.Verbose, "verbose", false, "Enable verbose output")
 	flags.BoolVar(&opts.Debug, "debug", false, "Enable debug output (for development)")
+	flags.BoolVar(&opts.Stats, "stats", false, "Print the summary of found errors with the numbers of errors per rule to stderr after linting")
+	flags.BoolVar(&opts.Timing, "timing", false, "Print time spent in each rule and each external command such as shellcheck to stderr after linting")
 	flags.BoolVar(&ver, "version", false, "Show version and how this binary was installed")
+	flags.BoolVar(&listRules, "list-rules", false, "Show all built-in rules with their default severities and whether they are opt-in. Use -format json to output them in JSON")
 	flags.StringVar(&opts.StdinFileName, "stdin-filename", "<stdin>", "File name when reading input from stdin")
+	flags.BoolVar(&noCache, "no-cache", false, "Disable the on-disk cache of shellcheck results")
+	flags.StringVar(&gitRev, "git-rev", "", "Lint workflow files in .github/workflows at the Git revision such as commit SHA. The files are read from the Git object database so a working tree is not necessary. This is useful for server-side hooks such as pre-receive hook. File path arguments cannot be given with this option")
+	flags.StringVar(&opts.Since, "since", "", "Lint only workflow files changed since the Git ref such as \"origin/main\". This option is effective only when no file path is given")
+	flags.BoolVar(&opts.Template, "template", false, "Lint the workflow files as workflow templates where placeholders such as $default-branch are available. Files in \"workflow-templates\" directory are always linted as workflow templates")
+	flags.BoolVar(&opts.Action, "action", false, "Lint the input files as action metadata files. Files named \"action.yml\" or \"action.yaml\" outside \"workflows\" directory are always linted as action metadata files")
+	flags.StringVar(&failOn, "fail-on", SeverityWarning, "Minimum severity of errors which make the exit status non-zero. \"error\" or \"warning\". With \"error\", errors of rules configured as \"warning\" in \"severity\" configuration don't fail")
+	flags.BoolVar(&exitZero, "exit-zero", false, "Exit with status 0 even if some problem is found. Errors are reported as usual. Fatal errors such as an invalid config file still make the exit status non-zero")
+	flags.IntVar(&opts.Jobs, "jobs", 0, "Number of workflow files linted in parallel and external processes run in parallel. When 0, the number of available CPUs is used")
+	flags.StringVar(&opts.Target, "target", "", "Platform where the workflows run. \"github\" for GitHub.com or \"ghes\" for GitHub Enterprise Server. This option overrides \"platform\" in the config file")
+	flags.BoolVar(&opts.CheckDependabot, "check-dependabot", false, "Also check schedules in Dependabot configuration file .github/dependabot.yml. The file is linted with workflow files when no file path is given")
+	flags.BoolVar(&opts.Fix, "fix", false, "Fix deprecated \"set-output\" and \"save-state\" workflow commands in the workflow files in place")
 	flags.Usage = func() {
 		printUsageHeader(cmd.Stderr)
 		flags.PrintDefaults()
@@ -158,6 +296,31 @@ func (cmd *Command) Main(args []string) int {
 		return ExitStatusInvalidCommandOption
 	}
 
+	opts.ShellcheckCache = !noCache
+
+	if failOn != SeverityError && failOn != SeverityWarning {
+		fmt.Fprintf(cmd.Stderr, "invalid value %q for -fail-on option. it must be %q or %q\n", failOn, SeverityError, SeverityWarning)
+		return ExitStatusInvalidCommandOption
+	}
+
+	if gitRev != "" && flags.NArg() > 0 && flags.Arg(0) != "explain" {
+		fmt.Fprintln(cmd.Stderr, "file path arguments cannot be given with -git-rev option")
+		return ExitStatusInvalidCommandOption
+	}
+
+	if formatFile != "" {
+		if opts.Format != "" {
+			fmt.Fprintln(cmd.Stderr, "-format and -format-file options cannot be specified at the same time")
+			return ExitStatusInvalidCommandOption
+		}
+		b, err := os.ReadFile(formatFile)
+		if err != nil {
+			fmt.Fprintf(cmd.Stderr, "could not read template file for -format-file option: %s\n", err)
+			return ExitStatusInvalidCommandOption
+		}
+		opts.Format = string(b)
+	}
+
 	if ver {
 		fmt.Fprintf(
 			cmd.Stdout,
@@ -171,23 +334,39 @@ func (cmd *Command) Main(args []string) int {
 		return ExitStatusSuccessNoProblem
 	}
 
+	if listRules {
+		if err := printRuleListWithDetails(cmd.Stdout, opts.Format); err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusInvalidCommandOption
+		}
+		return ExitStatusSuccessNoProblem
+	}
+
+	if as := flags.Args(); len(as) > 0 && as[0] == "explain" {
+		return cmd.explain(as[1:])
+	}
+
 	opts.IgnorePatterns = ignorePats
+	opts.ConfigFiles = configFiles
 	opts.LogWriter = cmd.Stderr
 
-	if color {
-		opts.Color = ColorOptionKindAlways
-	}
+	opts.Color = ColorOptionKind(color)
 	if noColor {
 		opts.Color = ColorOptionKindNever
 	}
 
-	errs, err := cmd.runLinter(flags.Args(), &opts, initConfig)
+	errs, err := cmd.runLinter(flags.Args(), &opts, initConfig, gitRev)
 	if err != nil {
 		fmt.Fprintln(cmd.Stderr, err.Error())
 		return ExitStatusFailure
 	}
-	if len(errs) > 0 {
-		return ExitStatusSuccessProblemFound // Linter found some issues, yay!
+	if exitZero {
+		return ExitStatusSuccessNoProblem
+	}
+	for _, e := range errs {
+		if failOn == SeverityWarning || !e.IsWarning() {
+			return ExitStatusSuccessProblemFound // Linter found some issues, yay!
+		}
 	}
 
 	return ExitStatusSuccessNoProblem
@@ -2,10 +2,14 @@ package actionlint
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/fatih/color"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestCommandMain(t *testing.T) {
@@ -42,3 +46,409 @@ func TestCommandMain(t *testing.T) {
 		t.Errorf("runner-label rule should be ignored by -ignore but it is included in output: %q", out)
 	}
 }
+
+func TestCommandFailOn(t *testing.T) {
+	dir := filepath.Join("testdata", "projects", "severity")
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	warnOnly := filepath.Join(dir, "workflows", "warning_only.yaml")
+	withError := filepath.Join(dir, "workflows", "test.yaml")
+
+	testCases := []struct {
+		what     string
+		failOn   string
+		exitZero bool
+		file     string
+		want     int
+	}{
+		{"only warnings with default", "", false, warnOnly, 1},
+		{"only warnings with -fail-on warning", "warning", false, warnOnly, 1},
+		{"only warnings with -fail-on error", "error", false, warnOnly, 0},
+		{"errors with -fail-on error", "error", false, withError, 1},
+		{"errors with -exit-zero", "", true, withError, 0},
+		{"errors with -fail-on warning and -exit-zero", "warning", true, withError, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			var output bytes.Buffer
+			cmd := Command{
+				Stdin:  os.Stdin,
+				Stdout: &output,
+				Stderr: &output,
+			}
+
+			args := []string{"actionlint", "-shellcheck=", "-pyflakes=", "-no-color", "-config-file", cfg}
+			if tc.failOn != "" {
+				args = append(args, "-fail-on", tc.failOn)
+			}
+			if tc.exitZero {
+				args = append(args, "-exit-zero")
+			}
+			args = append(args, tc.file)
+
+			if status := cmd.Main(args); status != tc.want {
+				t.Fatalf("exit status should be %d but got %d: %q", tc.want, status, output.String())
+			}
+			if out := output.String(); !strings.Contains(out, "warning: label \"linux-latest\" is unknown") {
+				t.Fatalf("warning is not output: %q", out)
+			}
+		})
+	}
+}
+
+func TestCommandMultipleConfigFiles(t *testing.T) {
+	dir := filepath.Join("testdata", "config", "merge")
+	base := filepath.Join(dir, "base.yml")
+	repo := filepath.Join(dir, "repo.yml")
+	file := filepath.Join("testdata", "projects", "severity", "workflows", "warning_only.yaml")
+
+	testCases := []struct {
+		what  string
+		files []string
+		want  int
+	}{
+		{"base config only", []string{base}, 0},
+		{"severity overridden by later config", []string{base, repo}, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			var output bytes.Buffer
+			cmd := Command{
+				Stdin:  os.Stdin,
+				Stdout: &output,
+				Stderr: &output,
+			}
+
+			args := []string{"actionlint", "-shellcheck=", "-pyflakes=", "-no-color", "-fail-on", "error"}
+			for _, f := range tc.files {
+				args = append(args, "-config-file", f)
+			}
+			args = append(args, file)
+
+			if status := cmd.Main(args); status != tc.want {
+				t.Fatalf("exit status should be %d but got %d: %q", tc.want, status, output.String())
+			}
+		})
+	}
+}
+
+func TestCommandLintWorkflowDirectories(t *testing.T) {
+	d := filepath.Join("testdata", "monorepo")
+	testEnsureDotGitDir(d)
+
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &output,
+		Stderr: &output,
+	}
+
+	status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-no-color", "-oneline", d})
+	if status != 1 {
+		t.Fatalf("exit status should be 1 but got %d: %q", status, output.String())
+	}
+
+	out := output.String()
+	if n := strings.Count(out, "\n"); n != 1 {
+		t.Fatalf("only one error should be reported but got %d errors: %q", n, out)
+	}
+	want := filepath.Join(d, "services", "web", ".github", "workflows", "test.yaml") + ":10:28: label \"api-runner\" is unknown"
+	if !strings.HasPrefix(out, want) {
+		t.Fatalf("output should start with %q but got %q", want, out)
+	}
+}
+
+func TestCommandLintGlobPatterns(t *testing.T) {
+	d := filepath.Join("testdata", "monorepo")
+	testEnsureDotGitDir(d)
+
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &output,
+		Stderr: &output,
+	}
+
+	pat := filepath.Join(d, "**", "workflows", "*.yaml")
+	status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-no-color", "-oneline", pat})
+	if status != 1 {
+		t.Fatalf("exit status should be 1 but got %d: %q", status, output.String())
+	}
+
+	out := output.String()
+	if n := strings.Count(out, "\n"); n != 1 {
+		t.Fatalf("only one error should be reported but got %d errors: %q", n, out)
+	}
+	want := filepath.Join(d, "services", "web", ".github", "workflows", "test.yaml") + ":10:28: label \"api-runner\" is unknown"
+	if !strings.HasPrefix(out, want) {
+		t.Fatalf("output should start with %q but got %q", want, out)
+	}
+
+	output.Reset()
+	pat = filepath.Join(d, "**", "unknown", "*.yaml")
+	status = cmd.Main([]string{"actionlint", pat})
+	if status != ExitStatusFailure {
+		t.Fatalf("exit status should be %d but got %d: %q", ExitStatusFailure, status, output.String())
+	}
+	if out := output.String(); !strings.Contains(out, "no file matches to the glob pattern") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCommandLintDirectoryWithoutWorkflows(t *testing.T) {
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &output,
+		Stderr: &output,
+	}
+
+	d := filepath.Join("testdata", "ok")
+	status := cmd.Main([]string{"actionlint", d})
+	if status != ExitStatusFailure {
+		t.Fatalf("exit status should be %d but got %d: %q", ExitStatusFailure, status, output.String())
+	}
+	if out := output.String(); !strings.Contains(out, "no YAML file was found in \".github/workflows\" directories") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCommandFailOnInvalidValue(t *testing.T) {
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &output,
+		Stderr: &output,
+	}
+
+	status := cmd.Main([]string{"actionlint", "-fail-on", "info", filepath.Join("testdata", "ok", "minimal.yaml")})
+	if status != ExitStatusInvalidCommandOption {
+		t.Fatalf("exit status should be %d but got %d", ExitStatusInvalidCommandOption, status)
+	}
+	if out := output.String(); !strings.Contains(out, `invalid value "info" for -fail-on option`) {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCommandGitRevWithFileArguments(t *testing.T) {
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &output,
+		Stderr: &output,
+	}
+
+	status := cmd.Main([]string{"actionlint", "-git-rev", "HEAD", filepath.Join("testdata", "ok", "minimal.yaml")})
+	if status != ExitStatusInvalidCommandOption {
+		t.Fatalf("exit status should be %d but got %d", ExitStatusInvalidCommandOption, status)
+	}
+	if out := output.String(); !strings.Contains(out, "file path arguments cannot be given with -git-rev option") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCommandColorOption(t *testing.T) {
+	saved := color.NoColor
+	defer func() { color.NoColor = saved }()
+
+	file := filepath.Join("testdata", "err", "invalid_runner_labels.yaml")
+	testCases := []struct {
+		what    string
+		args    []string
+		colored bool
+	}{
+		{"always", []string{"-color=always"}, true},
+		{"no value", []string{"-color"}, true},
+		{"never", []string{"-color=never"}, false},
+		{"no-color", []string{"-no-color"}, false},
+		{"no-color takes precedence", []string{"-color=always", "-no-color"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			var output bytes.Buffer
+			cmd := Command{
+				Stdin:  os.Stdin,
+				Stdout: &output,
+				Stderr: &output,
+			}
+
+			args := append([]string{"actionlint", "-shellcheck=", "-pyflakes="}, tc.args...)
+			args = append(args, file)
+			if status := cmd.Main(args); status != ExitStatusSuccessProblemFound {
+				t.Fatalf("exit status should be %d but got %d: %q", ExitStatusSuccessProblemFound, status, output.String())
+			}
+			if have := strings.Contains(output.String(), "\x1b["); have != tc.colored {
+				t.Fatalf("colored output should be %v but got %v: %q", tc.colored, have, output.String())
+			}
+		})
+	}
+}
+
+func TestCommandColorOptionInvalidValue(t *testing.T) {
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &output,
+		Stderr: &output,
+	}
+
+	status := cmd.Main([]string{"actionlint", "-color=sometimes", filepath.Join("testdata", "ok", "minimal.yaml")})
+	if status != ExitStatusInvalidCommandOption {
+		t.Fatalf("exit status should be %d but got %d", ExitStatusInvalidCommandOption, status)
+	}
+	if out := output.String(); !strings.Contains(out, `invalid value "sometimes". it must be "auto", "always", or "never"`) {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCommandFormatFile(t *testing.T) {
+	tmpl := filepath.Join("testdata", "format", "sarif_template.txt")
+	b, err := os.ReadFile(tmpl)
+	if err != nil {
+		panic(err)
+	}
+	workflow := filepath.Join("testdata", "format", "test.yaml")
+
+	run := func(args ...string) string {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  os.Stdin,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		args = append([]string{"actionlint", "-shellcheck=", "-pyflakes="}, args...)
+		if status := cmd.Main(append(args, workflow)); status != ExitStatusSuccessProblemFound {
+			t.Fatalf("exit status should be %d but got %d: %q", ExitStatusSuccessProblemFound, status, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	want := run("-format", string(b))
+	have := run("-format-file", tmpl)
+	if want != have {
+		t.Fatalf("output with -format-file is different from output with -format:\n%s", cmp.Diff(want, have))
+	}
+}
+
+func TestCommandFormatFileError(t *testing.T) {
+	tests := []struct {
+		what string
+		args []string
+		want string
+	}{
+		{"with -format", []string{"-format", "{{json .}}", "-format-file", filepath.Join("testdata", "format", "sarif_template.txt")}, "cannot be specified at the same time"},
+		{"file not found", []string{"-format-file", filepath.Join("testdata", "format", "this-file-does-not-exist.txt")}, "could not read template file for -format-file option"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			var output bytes.Buffer
+			cmd := Command{
+				Stdin:  os.Stdin,
+				Stdout: &output,
+				Stderr: &output,
+			}
+			args := append([]string{"actionlint"}, tc.args...)
+			status := cmd.Main(append(args, filepath.Join("testdata", "ok", "minimal.yaml")))
+			if status != ExitStatusInvalidCommandOption {
+				t.Fatalf("exit status should be %d but got %d", ExitStatusInvalidCommandOption, status)
+			}
+			if out := output.String(); !strings.Contains(out, tc.want) {
+				t.Fatalf("output should contain %q: %q", tc.want, out)
+			}
+		})
+	}
+}
+
+func TestCommandListRules(t *testing.T) {
+	tests := []struct {
+		what   string
+		args   []string
+		status int
+		out    string
+	}{
+		{"text", []string{}, ExitStatusSuccessNoProblem, "gating-jobs "},
+		{"json", []string{"-format", "json"}, ExitStatusSuccessNoProblem, `"id": "gating-jobs"`},
+		{"unsupported format", []string{"-format", "sarif"}, ExitStatusInvalidCommandOption, `only supports "json"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			var output bytes.Buffer
+			cmd := Command{
+				Stdin:  os.Stdin,
+				Stdout: &output,
+				Stderr: &output,
+			}
+			args := append([]string{"actionlint", "-list-rules"}, tc.args...)
+			if status := cmd.Main(args); status != tc.status {
+				t.Fatalf("exit status should be %d but got %d: %q", tc.status, status, output.String())
+			}
+			if out := output.String(); !strings.Contains(out, tc.out) {
+				t.Fatalf("output should contain %q: %q", tc.out, out)
+			}
+		})
+	}
+
+	var output bytes.Buffer
+	cmd := Command{Stdin: os.Stdin, Stdout: &output, Stderr: &output}
+	if status := cmd.Main([]string{"actionlint", "-list-rules", "-format", "json"}); status != ExitStatusSuccessNoProblem {
+		t.Fatalf("exit status should be %d but got %d: %q", ExitStatusSuccessNoProblem, status, output.String())
+	}
+	var rules []*ruleListEntry
+	if err := json.Unmarshal(output.Bytes(), &rules); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != len(builtinRuleFields()) {
+		t.Fatalf("wanted %d rules but got %d: %v", len(builtinRuleFields()), len(rules), rules)
+	}
+	for _, r := range rules {
+		switch r.ID {
+		case "gating-jobs", "job-timeout", "action-permissions", "working-directory", "broad-permissions", "unused-inputs", "secret-print":
+			if !r.OptIn {
+				t.Errorf("rule %q should be opt-in", r.ID)
+			}
+		default:
+			if r.OptIn {
+				t.Errorf("rule %q should not be opt-in", r.ID)
+			}
+		}
+		if r.Severity != (*Config)(nil).RuleSeverity(r.ID) {
+			t.Errorf("severity of rule %q is unexpected: %q", r.ID, r.Severity)
+		}
+	}
+}
+
+func TestCommandExplain(t *testing.T) {
+	tests := []struct {
+		what   string
+		args   []string
+		status int
+		out    string
+	}{
+		{"list rules", []string{}, ExitStatusSuccessNoProblem, "runner-label "},
+		{"explain rule", []string{"permissions"}, ExitStatusSuccessNoProblem, "permissions: Checks for"},
+		{"unknown rule", []string{"foo"}, ExitStatusInvalidCommandOption, `unknown rule "foo"`},
+		{"too many args", []string{"foo", "bar"}, ExitStatusInvalidCommandOption, "at most one rule name"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			var output bytes.Buffer
+			cmd := Command{
+				Stdin:  os.Stdin,
+				Stdout: &output,
+				Stderr: &output,
+			}
+			args := append([]string{"actionlint", "explain"}, tc.args...)
+			if status := cmd.Main(args); status != tc.status {
+				t.Fatalf("exit status should be %d but got %d: %q", tc.status, status, output.String())
+			}
+			if out := output.String(); !strings.Contains(out, tc.out) {
+				t.Fatalf("output should contain %q: %q", tc.out, out)
+			}
+		})
+	}
+}
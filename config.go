@@ -12,6 +12,8 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+var reUntrustedInputPath = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*(\.([a-zA-Z0-9_-]+|\*))+$`)
+
 // IgnorePatterns is a list of regular expressions. These patterns are used for filtering errors by
 // matching the error messages.
 type IgnorePatterns []*regexp.Regexp
@@ -43,6 +45,23 @@ func (pats *IgnorePatterns) UnmarshalYAML(n *yaml.Node) error {
 	return nil
 }
 
+const (
+	// PlatformGitHub is a platform name of GitHub.com. This is the default platform.
+	PlatformGitHub = "github"
+	// PlatformGHES is a platform name of GitHub Enterprise Server. GitHub-hosted runners and some
+	// features of GitHub.com are not available on it.
+	// https://docs.github.com/en/enterprise-server@latest/actions
+	PlatformGHES = "ghes"
+)
+
+const (
+	// BroadPermissionsWriteAll is a value of "broad-permissions" to report "permissions: write-all".
+	BroadPermissionsWriteAll = "write-all"
+	// BroadPermissionsStrict is a value of "broad-permissions" to report "permissions: read-all" and
+	// "write" scopes at workflow-level "permissions:" in addition to "permissions: write-all".
+	BroadPermissionsStrict = "strict"
+)
+
 // PathConfig is a configuration for specific file path pattern. This is for values of the "paths" mapping
 // in the configuration file.
 type PathConfig struct {
@@ -58,12 +77,98 @@ type Config struct {
 	SelfHostedRunner struct {
 		// Labels is label names for self-hosted runner.
 		Labels []string `yaml:"labels"`
+		// LabelsFiles is glob patterns of YAML files which define label names for self-hosted runner. The
+		// patterns are relative to the repository root, or relative to the current working directory when
+		// the config file is given via -config-file option. Labels in the matched files are merged into
+		// Labels when the config file is loaded.
+		LabelsFiles []string `yaml:"labels-files"`
 	} `yaml:"self-hosted-runner"`
 	// ConfigVariables is names of configuration variables used in the checked workflows. When this value is nil,
 	// property names of `vars` context will not be checked. Otherwise actionlint will report a name which is not
 	// listed here as undefined config variables.
 	// https://docs.github.com/en/actions/learn-github-actions/variables
 	ConfigVariables []string `yaml:"config-variables"`
+	// RequirePinnedActions is a flag to require actions at `uses:` to be pinned to full length commit SHAs. Local
+	// actions, Docker actions, actions owned by GitHub, and actions matching to TrustedActions are not checked.
+	RequirePinnedActions bool `yaml:"require-pinned-actions"`
+	// TrustedActions is glob patterns of actions which are allowed not to be pinned to commit SHAs when
+	// RequirePinnedActions is true. The patterns match to "{owner}/{repo}" like "rhysd/*".
+	TrustedActions []string `yaml:"trusted-actions"`
+	// AllowedActions is glob patterns of actions which are allowed to be used at `uses:`. The patterns
+	// match to "{owner}/{repo}" like "actions/*", or "docker://{image}" without tag and digest for
+	// Docker actions. When this value is empty, all actions are allowed. Local actions are not checked.
+	AllowedActions []string `yaml:"allowed-actions"`
+	// DeniedActions is glob patterns of actions which are not allowed to be used at `uses:`. The format
+	// of patterns is the same as AllowedActions. DeniedActions takes precedence over AllowedActions.
+	DeniedActions []string `yaml:"denied-actions"`
+	// RequireDockerActionDigest is a flag to require Docker actions like "docker://alpine:3" at `uses:`
+	// to be pinned to image digests. Tags of images can be moved to other images.
+	RequireDockerActionDigest bool `yaml:"require-docker-action-digest"`
+	// CheckPathGlobs is a flag to check patterns of "paths" and "paths-ignore" filters match to files
+	// in the repository. The check is skipped when the workflow is not in a repository.
+	CheckPathGlobs bool `yaml:"check-path-globs"`
+	// CheckUnusedNeeds is a flag to check jobs at "needs:" which are never used via "needs" context
+	// in the job.
+	CheckUnusedNeeds bool `yaml:"check-unused-needs"`
+	// CheckUnusedInputs is a flag to check inputs of "workflow_dispatch" event which are never used
+	// in the workflow.
+	CheckUnusedInputs bool `yaml:"check-unused-inputs"`
+	// CheckSecretPrint is a flag to check secrets printed to logs with commands such as `echo` in
+	// scripts at `run:`.
+	CheckSecretPrint bool `yaml:"check-secret-print"`
+	// CheckWorkingDirectory is a flag to check paths at "working-directory" exist in the repository.
+	// The check is skipped when the workflow is not in a repository.
+	CheckWorkingDirectory bool `yaml:"check-working-directory"`
+	// GatingJobs is a list of job IDs which are meant to gate merges as required status checks. Such
+	// jobs must not set `continue-on-error: true`. Job IDs are case-insensitive.
+	GatingJobs []string `yaml:"gating-jobs"`
+	// RequireTimeout is a flag to require every job to set `timeout-minutes`. Jobs calling reusable
+	// workflows are not checked since `timeout-minutes` is not available at them.
+	RequireTimeout bool `yaml:"require-timeout"`
+	// AllowedCredentialPrefixes is a list of prefixes of strings which look like hard-coded credentials
+	// but are known to be safe such as dummy tokens for testing.
+	AllowedCredentialPrefixes []string `yaml:"allowed-credential-prefixes"`
+	// ForbidWorkflowCommands is a list of workflow commands such as "warning" or "group" which must not
+	// be used with `::` syntax in scripts at `run:`. Command names are case-insensitive.
+	ForbidWorkflowCommands []string `yaml:"forbid-workflow-commands"`
+	// UntrustedInputs is a list of paths to properties of contexts which are potentially untrusted in
+	// addition to the built-in ones such as "github.event.workflow_run.head_branch". "*" in the path
+	// matches any element of array like "github.event.commits.*.message".
+	UntrustedInputs []string `yaml:"untrusted-inputs"`
+	// AllowedReservedEnvVars is a list of names of the default environment variables such as "CI" which
+	// are allowed to be shadowed by "env:" in workflows.
+	AllowedReservedEnvVars []string `yaml:"allowed-reserved-env-vars"`
+	// CheckActionPermissions is a flag to check the permissions of GITHUB_TOKEN are sufficient for
+	// the actions used in jobs. The required permissions are looked up from the built-in table of
+	// well-known actions and ActionPermissions.
+	CheckActionPermissions bool `yaml:"check-action-permissions"`
+	// ActionPermissions is a mapping from actions to the permission scopes they require. The keys are
+	// "{owner}/{repo}" or "{owner}/{repo}/{path}" without a ref. The values are mappings from permission
+	// scopes to "read" or "write". An entry overrides the built-in table for the same action.
+	ActionPermissions map[string]map[string]string `yaml:"action-permissions"`
+	// CheckActionInputTypes is a flag to check literal values at `with:` match to the types of the
+	// inputs of actions. The types are looked up from the built-in table of well-known actions and
+	// ActionInputTypes.
+	CheckActionInputTypes bool `yaml:"check-action-input-types"`
+	// ActionInputTypes is a mapping from actions to the types of their inputs. The keys are
+	// "{owner}/{repo}" or "{owner}/{repo}/{path}" without a ref. The values are mappings from input
+	// names to "boolean", "number", or "string". An entry overrides the built-in table for the same input.
+	ActionInputTypes map[string]map[string]string `yaml:"action-input-types"`
+	// ActionRuntimes is a mapping from actions to their runtimes at `runs.using` such as "node16". The
+	// keys are "{owner}/{repo}@{ref}" or "{owner}/{repo}/{path}@{ref}". A key without "@{ref}" matches
+	// to all versions of the action. It is used for detecting remote actions on deprecated runtimes.
+	ActionRuntimes map[string]string `yaml:"action-runtimes"`
+	// BroadPermissions is the strictness of checking broad permissions of GITHUB_TOKEN at
+	// `permissions:`. BroadPermissionsWriteAll reports "write-all". BroadPermissionsStrict also
+	// reports "read-all" and "write" scopes at workflow-level `permissions:`. Empty string disables
+	// the check.
+	BroadPermissions string `yaml:"broad-permissions"`
+	// Platform is the platform where workflows run. It is PlatformGitHub or PlatformGHES. Empty string
+	// means PlatformGitHub. It can be overridden by Target of LinterOptions.
+	Platform string `yaml:"platform"`
+	// Severity is a mapping from rule names to their severities. The severity is "error" or "warning".
+	// Rules not in this mapping report errors with "error" severity.
+	Severity map[string]string `yaml:"severity"`
 	// Paths is a "paths" mapping in the configuration file. The keys are glob patterns to match file paths.
 	// And the values are corresponding configurations applied to the file paths.
 	Paths map[string]PathConfig `yaml:"paths"`
@@ -86,22 +191,251 @@ func (cfg *Config) PathConfigs(path string) []PathConfig {
 	return ret
 }
 
+// defaultRuleSeverities is a mapping from rule names to their severities when they are not configured
+// by "severity" in the config file. Heuristic rules which may cause false positives report warnings.
+var defaultRuleSeverities = map[string]string{
+	"cache-key":           SeverityWarning,
+	"concurrency":         SeverityWarning,
+	"job-outputs":         SeverityWarning,
+	"multiline-env":       SeverityWarning,
+	"node-runtime":        SeverityWarning,
+	"reserved-env":        SeverityWarning,
+	"unused-step-outputs": SeverityWarning,
+}
+
+// RuleSeverity returns the severity of errors reported by the rule. When the severity of the rule is
+// not configured, the default severity of the rule is returned. It is SeverityError for most rules.
+func (cfg *Config) RuleSeverity(rule string) string {
+	if cfg != nil {
+		if s, ok := cfg.Severity[rule]; ok {
+			return s
+		}
+	}
+	if s, ok := defaultRuleSeverities[rule]; ok {
+		return s
+	}
+	return SeverityError
+}
+
+// isGHES returns whether the workflows are configured to run on GitHub Enterprise Server.
+func (cfg *Config) isGHES() bool {
+	return cfg != nil && cfg.Platform == PlatformGHES
+}
+
+// actionPermissions returns the permission scopes required by the action. The action is
+// "{owner}/{repo}" or "{owner}/{repo}/{path}" in lower case. Configured entries take precedence
+// over the built-in table.
+func (cfg *Config) actionPermissions(action string) map[string]string {
+	if cfg != nil {
+		for a, p := range cfg.ActionPermissions {
+			if strings.ToLower(a) == action {
+				return p
+			}
+		}
+	}
+	return builtinActionPermissions[action]
+}
+
+// actionInputType returns the type of the input of the action. The action is "{owner}/{repo}" or
+// "{owner}/{repo}/{path}" in lower case and the input is in lower case. Configured entries take
+// precedence over the built-in table. An empty string is returned when the type is unknown.
+func (cfg *Config) actionInputType(action, input string) string {
+	if cfg != nil {
+		for a, is := range cfg.ActionInputTypes {
+			if strings.ToLower(a) != action {
+				continue
+			}
+			for i, ty := range is {
+				if strings.ToLower(i) == input {
+					return ty
+				}
+			}
+		}
+	}
+	return builtinActionInputTypes[action][input]
+}
+
+// actionRuntime returns the runtime of the action configured in ActionRuntimes. The spec is
+// "{owner}/{repo}@{ref}" or "{owner}/{repo}/{path}@{ref}". An entry for the exact version takes
+// precedence over an entry without ref. Empty string is returned when no entry matches.
+func (cfg *Config) actionRuntime(spec string) string {
+	if cfg == nil {
+		return ""
+	}
+	spec = strings.ToLower(spec)
+	action := spec
+	if i := strings.IndexRune(spec, '@'); i >= 0 {
+		action = spec[:i]
+	}
+	r := ""
+	for a, v := range cfg.ActionRuntimes {
+		switch strings.ToLower(a) {
+		case spec:
+			return v
+		case action:
+			r = v
+		}
+	}
+	return r
+}
+
 // ParseConfig parses the given bytes as an actionlint config file. When deserializing the YAML file
 // or the config validation fails, this function returns an error.
 func ParseConfig(b []byte) (*Config, error) {
-	var c Config
-	if err := yaml.Unmarshal(b, &c); err != nil {
+	n, err := parseConfigYAML(b)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfig(n)
+}
+
+func parseConfigYAML(b []byte) (*yaml.Node, error) {
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
 		msg := strings.ReplaceAll(err.Error(), "\n", " ")
 		return nil, errors.New(msg)
 	}
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		return n.Content[0], nil
+	}
+	return nil, nil // Empty document
+}
+
+func decodeConfig(n *yaml.Node) (*Config, error) {
+	var c Config
+	if n != nil {
+		if err := n.Decode(&c); err != nil {
+			msg := strings.ReplaceAll(err.Error(), "\n", " ")
+			return nil, errors.New(msg)
+		}
+	}
 	for pat := range c.Paths {
 		if !doublestar.ValidatePattern(pat) {
 			return nil, fmt.Errorf("invalid glob pattern %q in \"paths\"", pat)
 		}
 	}
+	for _, pat := range c.TrustedActions {
+		if !doublestar.ValidatePattern(pat) {
+			return nil, fmt.Errorf("invalid glob pattern %q in \"trusted-actions\"", pat)
+		}
+	}
+	for _, pat := range c.AllowedActions {
+		if !doublestar.ValidatePattern(pat) {
+			return nil, fmt.Errorf("invalid glob pattern %q in \"allowed-actions\"", pat)
+		}
+	}
+	for _, pat := range c.DeniedActions {
+		if !doublestar.ValidatePattern(pat) {
+			return nil, fmt.Errorf("invalid glob pattern %q in \"denied-actions\"", pat)
+		}
+	}
+	if c.Platform != "" && c.Platform != PlatformGitHub && c.Platform != PlatformGHES {
+		return nil, fmt.Errorf("invalid platform %q in \"platform\". it must be %q or %q", c.Platform, PlatformGitHub, PlatformGHES)
+	}
+	for rule, s := range c.Severity {
+		if s != SeverityError && s != SeverityWarning {
+			return nil, fmt.Errorf("invalid severity %q for rule %q in \"severity\". it must be %q or %q", s, rule, SeverityError, SeverityWarning)
+		}
+	}
+	for _, cmd := range c.ForbidWorkflowCommands {
+		if _, ok := allWorkflowCommands[strings.ToLower(cmd)]; !ok {
+			return nil, fmt.Errorf("unknown workflow command %q in \"forbid-workflow-commands\". see https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions", cmd)
+		}
+	}
+	for _, p := range c.UntrustedInputs {
+		if !reUntrustedInputPath.MatchString(p) {
+			return nil, fmt.Errorf("invalid path %q in \"untrusted-inputs\". it must be a path to property of context separated by \".\" such as \"github.event.workflow_run.head_branch\". \"*\" can be used for elements of array", p)
+		}
+	}
+	for action, perms := range c.ActionPermissions {
+		for scope, v := range perms {
+			if _, ok := allPermissionScopes[scope]; !ok {
+				return nil, fmt.Errorf("unknown permission scope %q for action %q in \"action-permissions\"", scope, action)
+			}
+			if v != "read" && v != "write" {
+				return nil, fmt.Errorf("invalid permission %q of scope %q for action %q in \"action-permissions\". it must be \"read\" or \"write\"", v, scope, action)
+			}
+		}
+	}
+	for action, inputs := range c.ActionInputTypes {
+		for input, ty := range inputs {
+			if ty != "boolean" && ty != "number" && ty != "string" {
+				return nil, fmt.Errorf("invalid type %q of input %q for action %q in \"action-input-types\". it must be \"boolean\", \"number\", or \"string\"", ty, input, action)
+			}
+		}
+	}
+	if b := c.BroadPermissions; b != "" && b != BroadPermissionsWriteAll && b != BroadPermissionsStrict {
+		return nil, fmt.Errorf("invalid value %q in \"broad-permissions\". it must be %q or %q", b, BroadPermissionsWriteAll, BroadPermissionsStrict)
+	}
+	for _, pat := range c.SelfHostedRunner.LabelsFiles {
+		if !doublestar.ValidatePattern(filepath.ToSlash(pat)) {
+			return nil, fmt.Errorf("invalid glob pattern %q in \"labels-files\"", pat)
+		}
+	}
 	return &c, nil
 }
 
+// loadSelfHostedRunnerLabelsFiles reads all files matching to the glob patterns in "labels-files" and
+// merges the labels into the "labels" configuration. The glob patterns are relative to the given
+// directory. The file is a YAML file which contains a sequence of labels or a mapping which has a
+// "labels" key like:
+//
+//	labels:
+//	  - linux-gpu
+//	  - windows-xl
+func (cfg *Config) loadSelfHostedRunnerLabelsFiles(dir string) error {
+	for _, pat := range cfg.SelfHostedRunner.LabelsFiles {
+		files, err := doublestar.FilepathGlob(filepath.Join(dir, filepath.FromSlash(pat)))
+		if err != nil {
+			return fmt.Errorf("invalid glob pattern %q in \"labels-files\": %w", pat, err)
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no file matches to the glob pattern %q in \"labels-files\"", pat)
+		}
+		for _, f := range files {
+			ls, err := readSelfHostedRunnerLabelsFile(f)
+			if err != nil {
+				return err
+			}
+			cfg.SelfHostedRunner.Labels = append(cfg.SelfHostedRunner.Labels, ls...)
+		}
+	}
+	return nil
+}
+
+func readSelfHostedRunnerLabelsFile(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read self-hosted runner labels file %q: %w", path, err)
+	}
+
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		msg := strings.ReplaceAll(err.Error(), "\n", " ")
+		return nil, fmt.Errorf("could not parse self-hosted runner labels file %q: %s", path, msg)
+	}
+
+	var labels []string
+	if len(n.Content) > 0 && n.Content[0].Kind == yaml.MappingNode {
+		var m struct {
+			Labels []string `yaml:"labels"`
+		}
+		err = n.Decode(&m)
+		labels = m.Labels
+		if err == nil && labels == nil {
+			err = errors.New("\"labels\" key is not found")
+		}
+	} else {
+		err = n.Decode(&labels)
+	}
+	if err != nil {
+		msg := strings.ReplaceAll(err.Error(), "\n", " ")
+		return nil, fmt.Errorf("could not parse self-hosted runner labels file %q: %s", path, msg)
+	}
+
+	return labels, nil
+}
+
 // ReadConfigFile reads actionlint config file (actionlint.yaml) from the given file path.
 func ReadConfigFile(path string) (*Config, error) {
 	b, err := os.ReadFile(path)
@@ -115,6 +449,78 @@ func ReadConfigFile(path string) (*Config, error) {
 	return c, nil
 }
 
+// ReadConfigFiles reads multiple actionlint config files from the given file paths and merges them
+// into one config. Later files take precedence over earlier ones. Mappings are merged recursively,
+// sequences are merged into their union, and other values such as booleans and strings are
+// overridden by the later files.
+func ReadConfigFiles(paths ...string) (*Config, error) {
+	var merged *yaml.Node
+	for _, p := range paths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("could not read config file %q: %w", p, err)
+		}
+		n, err := parseConfigYAML(b)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse config file %q: %w", p, err)
+		}
+		// Validate each file before merging to report the file path on error
+		if _, err := decodeConfig(n); err != nil {
+			return nil, fmt.Errorf("could not parse config file %q: %w", p, err)
+		}
+		merged = mergeConfigNodes(merged, n)
+	}
+
+	c, err := decodeConfig(merged)
+	if err != nil {
+		return nil, fmt.Errorf("could not merge config files %s: %w", quotes(paths), err)
+	}
+	return c, nil
+}
+
+// mergeConfigNodes merges the src node into the dst node and returns the merged node. Mappings are
+// merged key by key recursively. Items of the src sequence which are not in the dst sequence are
+// appended. Otherwise the src node overrides the dst node.
+func mergeConfigNodes(dst, src *yaml.Node) *yaml.Node {
+	if dst == nil {
+		return src
+	}
+	if src == nil {
+		return dst
+	}
+
+	switch {
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+	Src:
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			k, v := src.Content[i], src.Content[i+1]
+			for j := 0; j+1 < len(dst.Content); j += 2 {
+				if dst.Content[j].Value == k.Value {
+					dst.Content[j+1] = mergeConfigNodes(dst.Content[j+1], v)
+					continue Src
+				}
+			}
+			dst.Content = append(dst.Content, k, v)
+		}
+		return dst
+	case dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+	Items:
+		for _, v := range src.Content {
+			if v.Kind == yaml.ScalarNode {
+				for _, d := range dst.Content {
+					if d.Kind == yaml.ScalarNode && d.Value == v.Value {
+						continue Items
+					}
+				}
+			}
+			dst.Content = append(dst.Content, v)
+		}
+		return dst
+	default:
+		return src
+	}
+}
+
 // loadRepoConfig reads config file from the repository's .github/actionlint.yml or
 // .github/actionlint.yaml.
 func loadRepoConfig(root string) (*Config, error) {
@@ -127,6 +533,9 @@ func loadRepoConfig(root string) (*Config, error) {
 		case err != nil:
 			return nil, fmt.Errorf("could not parse config file %q: %w", p, err)
 		default:
+			if err := c.loadSelfHostedRunnerLabelsFiles(root); err != nil {
+				return nil, fmt.Errorf("could not load config file %q: %w", p, err)
+			}
 			return c, nil
 		}
 	}
@@ -137,12 +546,102 @@ func writeDefaultConfigFile(path string) error {
 	b := []byte(`self-hosted-runner:
   # Labels of self-hosted runner in array of strings.
   labels: []
+  # Glob patterns of YAML files which define labels of self-hosted runner.
+  # The patterns are relative to the repository root.
+  # labels-files: []
 
 # Configuration variables in array of strings defined in your repository or
 # organization. ` + "`null`" + ` means disabling configuration variables check.
 # Empty array means no configuration variable is allowed.
 config-variables: null
 
+# Require actions at ` + "`uses:`" + ` to be pinned to full length commit SHAs. Local
+# actions, Docker actions, and actions owned by GitHub are not checked.
+require-pinned-actions: false
+
+# Glob patterns of actions which are allowed not to be pinned when
+# "require-pinned-actions" is true. The patterns match to "{owner}/{repo}".
+trusted-actions: []
+
+# Glob patterns of actions which are allowed to be used at ` + "`uses:`" + `. The patterns
+# match to "{owner}/{repo}" or "docker://{image}". Empty array means all actions
+# are allowed. Local actions are not checked.
+allowed-actions: []
+
+# Glob patterns of actions which are not allowed to be used at ` + "`uses:`" + `. They
+# take precedence over "allowed-actions".
+denied-actions: []
+
+# Require Docker actions like "docker://image:tag" at ` + "`uses:`" + ` to be pinned to image
+# digests like "docker://image@sha256:{digest}" for reproducibility.
+require-docker-action-digest: false
+
+# Check patterns at "paths:" and "paths-ignore:" filters match to at least one
+# file in the repository.
+check-path-globs: false
+
+# Check inputs of "workflow_dispatch" event are used in the workflow.
+check-unused-inputs: false
+
+# Check secrets are not printed to logs with "echo", "printf", or "cat" at "run:".
+check-secret-print: false
+
+# IDs of jobs which are meant to be required status checks. "continue-on-error:
+# true" is not allowed for these jobs since it makes their failures non-blocking.
+gating-jobs: []
+
+# Require all jobs to set "timeout-minutes:". Jobs calling reusable workflows are
+# not checked.
+require-timeout: false
+
+# Prefixes of strings which look like hard-coded credentials but are known to be
+# safe, such as dummy tokens for testing.
+allowed-credential-prefixes: []
+
+# Paths to properties of contexts which are potentially untrusted in addition
+# to the built-in ones. "*" matches any element of array.
+untrusted-inputs: []
+
+# Names of the default environment variables such as "CI" which are allowed to
+# be shadowed by "env:" in workflows.
+allowed-reserved-env-vars: []
+
+# Check the permissions of GITHUB_TOKEN set by "permissions:" are sufficient
+# for the actions used in jobs.
+check-action-permissions: false
+
+# Permission scopes required by actions in addition to the built-in table. The
+# keys are "{owner}/{repo}" of the actions.
+action-permissions:
+#  my-org/deploy-action:
+#    contents: read
+#    deployments: write
+
+# Check literal values at "with:" match to the types of the inputs of actions.
+check-action-input-types: false
+
+# Types of inputs of actions in addition to the built-in table. The keys are
+# "{owner}/{repo}" of the actions and the types are "boolean", "number", or
+# "string".
+action-input-types:
+#  my-org/deploy-action:
+#    dry-run: boolean
+#    retries: number
+
+# Report broad permissions of GITHUB_TOKEN at "permissions:". "write-all"
+# reports "permissions: write-all". "strict" also reports "read-all" and "write"
+# scopes at workflow-level "permissions:". Empty string disables the check.
+broad-permissions: ""
+
+# Platform where the workflows run. "github" for GitHub.com or "ghes" for GitHub
+# Enterprise Server. GitHub-hosted runner labels are not available on "ghes".
+platform: github
+
+# Severities of rules. The keys are rule names and the values are "error" or
+# "warning". Warnings don't make actionlint fail with "-fail-on error" option.
+severity:
+#  shellcheck: warning
+
 # Configuration for file paths. The keys are glob patterns to match to file
 # paths relative to the repository root. The values are the configurations for
 # the file paths. Note that the path separator is always '/'.
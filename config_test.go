@@ -1,6 +1,7 @@
 package actionlint
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -88,6 +89,61 @@ paths:
 `,
 			want: `invalid glob pattern`,
 		},
+		{
+			in: `
+self-hosted-runner:
+  labels-files: ['foo.{txt,xml']
+`,
+			want: `invalid glob pattern "foo.{txt,xml" in "labels-files"`,
+		},
+		{
+			in:   `trusted-actions: ['rhysd/{foo,bar']`,
+			want: `invalid glob pattern "rhysd/{foo,bar" in "trusted-actions"`,
+		},
+		{
+			in:   `allowed-actions: ['actions/[checkout']`,
+			want: `invalid glob pattern "actions/[checkout" in "allowed-actions"`,
+		},
+		{
+			in:   `denied-actions: ['docker://{alpine']`,
+			want: `invalid glob pattern "docker://{alpine" in "denied-actions"`,
+		},
+		{
+			in:   `severity: {shellcheck: info}`,
+			want: `invalid severity "info" for rule "shellcheck" in "severity"`,
+		},
+		{
+			in:   `action-permissions: {my-org/deploy: {content: write}}`,
+			want: `unknown permission scope "content" for action "my-org/deploy" in "action-permissions"`,
+		},
+		{
+			in:   `action-permissions: {my-org/deploy: {contents: none}}`,
+			want: `invalid permission "none" of scope "contents" for action "my-org/deploy" in "action-permissions"`,
+		},
+		{
+			in:   `action-input-types: {my-org/deploy: {dry-run: bool}}`,
+			want: `invalid type "bool" of input "dry-run" for action "my-org/deploy" in "action-input-types"`,
+		},
+		{
+			in:   `broad-permissions: read-all`,
+			want: `invalid value "read-all" in "broad-permissions". it must be "write-all" or "strict"`,
+		},
+		{
+			in:   `platform: github-enterprise`,
+			want: `invalid platform "github-enterprise" in "platform"`,
+		},
+		{
+			in:   `untrusted-inputs: [github.event.foo.]`,
+			want: `invalid path "github.event.foo." in "untrusted-inputs"`,
+		},
+		{
+			in:   `untrusted-inputs: [github]`,
+			want: `invalid path "github" in "untrusted-inputs"`,
+		},
+		{
+			in:   `forbid-workflow-commands: [warning, log]`,
+			want: `unknown workflow command "log" in "forbid-workflow-commands"`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -103,6 +159,32 @@ paths:
 	}
 }
 
+func TestConfigRuleSeverity(t *testing.T) {
+	c, err := ParseConfig([]byte("severity:\n  shellcheck: warning\n  runner-label: error\n  unused-step-outputs: error\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for rule, want := range map[string]string{
+		"shellcheck":          SeverityWarning,
+		"runner-label":        SeverityError,
+		"expression":          SeverityError,
+		"unused-step-outputs": SeverityError,
+	} {
+		if have := c.RuleSeverity(rule); have != want {
+			t.Errorf("wanted severity %q for rule %q but have %q", want, rule, have)
+		}
+	}
+
+	var nilCfg *Config
+	if have := nilCfg.RuleSeverity("shellcheck"); have != SeverityError {
+		t.Errorf("wanted severity %q for nil config but have %q", SeverityError, have)
+	}
+	if have := nilCfg.RuleSeverity("unused-step-outputs"); have != SeverityWarning {
+		t.Errorf("wanted default severity %q for rule \"unused-step-outputs\" but have %q", SeverityWarning, have)
+	}
+}
+
 func TestConfigPathConfigIgnores(t *testing.T) {
 	tests := []struct {
 		input string
@@ -257,6 +339,155 @@ func TestConfigReadFileParseError(t *testing.T) {
 	}
 }
 
+func TestConfigReadFilesMerge(t *testing.T) {
+	dir := filepath.Join("testdata", "config", "merge")
+	c, err := ReadConfigFiles(filepath.Join(dir, "base.yml"), filepath.Join(dir, "repo.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"linux-gpu", "linux-arm", "windows-xl"}, c.SelfHostedRunner.Labels); diff != "" {
+		t.Error("labels:", diff)
+	}
+	if diff := cmp.Diff([]string{"ORG_VAR", "REPO_VAR"}, c.ConfigVariables); diff != "" {
+		t.Error("config variables:", diff)
+	}
+	if c.RequirePinnedActions {
+		t.Error("require-pinned-actions should be overridden by the later file")
+	}
+	if diff := cmp.Diff(map[string]string{"shellcheck": "warning", "runner-label": "error"}, c.Severity); diff != "" {
+		t.Error("severity:", diff)
+	}
+
+	ignores := map[string][]string{}
+	for p, c := range c.Paths {
+		for _, r := range c.Ignore {
+			ignores[p] = append(ignores[p], r.String())
+		}
+	}
+	want := map[string][]string{
+		".github/workflows/**/*.yaml":    {"SC2086:", "SC2016:"},
+		".github/workflows/release.yaml": {"foo"},
+	}
+	if diff := cmp.Diff(want, ignores); diff != "" {
+		t.Error("paths:", diff)
+	}
+}
+
+func TestConfigReadFilesOverrideByNull(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yml")
+	if err := os.WriteFile(base, []byte("config-variables: [FOO]\n"), 0644); err != nil {
+		panic(err)
+	}
+	repo := filepath.Join(dir, "repo.yml")
+	if err := os.WriteFile(repo, []byte("config-variables: null\n"), 0644); err != nil {
+		panic(err)
+	}
+	empty := filepath.Join(dir, "empty.yml")
+	if err := os.WriteFile(empty, []byte(""), 0644); err != nil {
+		panic(err)
+	}
+
+	c, err := ReadConfigFiles(base, empty, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ConfigVariables != nil {
+		t.Fatalf("config-variables should be overridden by null but got %v", c.ConfigVariables)
+	}
+}
+
+func TestConfigReadFilesError(t *testing.T) {
+	ok := filepath.Join("testdata", "config", "ok.yml")
+	broken := filepath.Join("testdata", "config", "broken.yml")
+	missing := filepath.Join("testdata", "config", "does-not-exist.yml")
+
+	for _, tc := range []struct {
+		files []string
+		want  string
+	}{
+		{[]string{ok, broken}, "could not parse config file \"" + broken + "\""},
+		{[]string{missing, ok}, "could not read config file \"" + missing + "\""},
+	} {
+		_, err := ReadConfigFiles(tc.files...)
+		if err == nil {
+			t.Fatal("error did not occur for", tc.files)
+		}
+		if msg := err.Error(); !strings.Contains(msg, tc.want) {
+			t.Fatalf("wanted error message %q to contain %q", msg, tc.want)
+		}
+	}
+}
+
+func TestConfigLoadSelfHostedRunnerLabelsFilesOK(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.yaml":        "- foo\n- bar\n",
+		"b.yaml":        "labels: [piyo]\n",
+		"sub/c.yaml":    "labels:\n  - hoge\n",
+		"sub/ignored.x": "this is not YAML: [",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			panic(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			panic(err)
+		}
+	}
+
+	c, err := ParseConfig([]byte("self-hosted-runner:\n  labels: [label1]\n  labels-files: ['*.yaml', 'sub/**/*.yaml']\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.loadSelfHostedRunnerLabelsFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"label1", "foo", "bar", "piyo", "hoge"}
+	if diff := cmp.Diff(want, c.SelfHostedRunner.Labels); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestConfigLoadSelfHostedRunnerLabelsFilesError(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"broken.yaml":   "labels: [",
+		"no_key.yaml":   "names: [foo]\n",
+		"not_list.yaml": "labels: {foo: bar}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			panic(err)
+		}
+	}
+
+	tests := []struct {
+		pat  string
+		want string
+	}{
+		{"does-not-exist/*.yaml", `no file matches to the glob pattern "does-not-exist/*.yaml" in "labels-files"`},
+		{"broken.yaml", `could not parse self-hosted runner labels file`},
+		{"no_key.yaml", `"labels" key is not found`},
+		{"not_list.yaml", `cannot unmarshal`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pat, func(t *testing.T) {
+			c := &Config{}
+			c.SelfHostedRunner.LabelsFiles = []string{tc.pat}
+			err := c.loadSelfHostedRunnerLabelsFiles(dir)
+			if err == nil {
+				t.Fatal("no error occurred")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("wanted error message %q to contain %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
 func TestConfigGenerateDefaultConfigFileOK(t *testing.T) {
 	f := filepath.Join(t.TempDir(), "default-config-for-test.yml")
 	if err := writeDefaultConfigFile(f); err != nil {
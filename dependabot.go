@@ -0,0 +1,150 @@
+package actionlint
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// https://docs.github.com/en/code-security/dependabot/working-with-dependabot/dependabot-options-reference#schedule-
+var dependabotScheduleIntervals = []string{
+	"daily",
+	"weekly",
+	"monthly",
+	"quarterly",
+	"semiannually",
+	"yearly",
+	"cron",
+}
+
+var dependabotScheduleDays = []string{
+	"monday",
+	"tuesday",
+	"wednesday",
+	"thursday",
+	"friday",
+	"saturday",
+	"sunday",
+}
+
+var reDependabotScheduleTime = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// isDependabotConfigPath returns true when the file path is a configuration file of Dependabot put in
+// ".github" directory.
+func isDependabotConfigPath(path string) bool {
+	b := filepath.Base(path)
+	return (b == "dependabot.yml" || b == "dependabot.yaml") && filepath.Base(filepath.Dir(path)) == ".github"
+}
+
+// checkDependabotConfig checks the schedules in the Dependabot configuration file. Only "schedule"
+// sections in "updates" and "multi-ecosystem-groups" are checked. Other keys are ignored since
+// actionlint does not know the entire schema of the file.
+// https://docs.github.com/en/code-security/dependabot/working-with-dependabot/dependabot-options-reference
+func checkDependabotConfig(b []byte) []*Error {
+	var n yaml.Node
+
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return handleYAMLError(err)
+	}
+
+	if len(n.Content) == 0 {
+		return nil
+	}
+
+	p := &parser{}
+	for _, kv := range p.parseMapping("dependabot configuration", n.Content[0], true, true) {
+		switch kv.id {
+		case "updates":
+			if !p.checkSequence("updates", kv.val, true) {
+				continue
+			}
+			for _, u := range kv.val.Content {
+				p.checkDependabotScheduleIn("element of \"updates\" section", u)
+			}
+		case "multi-ecosystem-groups":
+			for _, g := range p.parseSectionMapping("multi-ecosystem-groups", kv.val, true, true) {
+				p.checkDependabotScheduleIn(g.id+" in \"multi-ecosystem-groups\" section", g.val)
+			}
+		}
+	}
+
+	return p.errors
+}
+
+func (p *parser) checkDependabotScheduleIn(what string, n *yaml.Node) {
+	for _, kv := range p.parseMapping(what, n, true, true) {
+		if kv.id == "schedule" {
+			p.checkDependabotSchedule(kv.key.Pos, kv.val)
+		}
+	}
+}
+
+func (p *parser) checkDependabotSchedule(pos *Pos, n *yaml.Node) {
+	var interval, day, cronjob *String
+	for _, kv := range p.parseSectionMapping("schedule", n, false, true) {
+		switch kv.id {
+		case "interval":
+			interval = p.parseString(kv.val, false)
+		case "day":
+			day = p.parseString(kv.val, false)
+		case "cronjob":
+			cronjob = p.parseString(kv.val, false)
+		case "time":
+			t := p.parseString(kv.val, false)
+			if t.Value != "" && !reDependabotScheduleTime.MatchString(t.Value) {
+				p.errorfAt(t.Pos, "time %q at \"time\" in \"schedule\" section must be in \"hh:mm\" format such as \"09:00\"", t.Value)
+			}
+		}
+	}
+
+	if interval == nil {
+		p.errorAt(pos, "\"interval\" is required in \"schedule\" section")
+		return
+	}
+	if interval.Value == "" {
+		return // Empty value was already reported
+	}
+	if !contains(dependabotScheduleIntervals, interval.Value) {
+		p.errorfAt(
+			interval.Pos,
+			"invalid interval %q at \"interval\" in \"schedule\" section.%s available intervals are %s",
+			interval.Value,
+			didYouMean(interval.Value, dependabotScheduleIntervals),
+			quotes(dependabotScheduleIntervals),
+		)
+		return
+	}
+
+	if day != nil && day.Value != "" {
+		if interval.Value != "weekly" {
+			p.errorfAt(day.Pos, "\"day\" in \"schedule\" section is only available when \"interval\" is \"weekly\" but it is %q", interval.Value)
+		} else if !contains(dependabotScheduleDays, strings.ToLower(day.Value)) {
+			p.errorfAt(
+				day.Pos,
+				"invalid day %q at \"day\" in \"schedule\" section.%s available days are %s",
+				day.Value,
+				didYouMean(strings.ToLower(day.Value), dependabotScheduleDays),
+				quotes(dependabotScheduleDays),
+			)
+		}
+	}
+
+	if interval.Value != "cron" {
+		if cronjob != nil {
+			p.errorfAt(cronjob.Pos, "\"cronjob\" in \"schedule\" section is only available when \"interval\" is \"cron\" but it is %q", interval.Value)
+		}
+		return
+	}
+	if cronjob == nil {
+		p.errorAt(interval.Pos, "\"cronjob\" is required in \"schedule\" section when \"interval\" is \"cron\"")
+		return
+	}
+	// "cronjob" also accepts natural language such as "every day at 5pm". Check only CRON syntax.
+	if c := cronjob.Value; c != "" && (c[0] == '*' || '0' <= c[0] && c[0] <= '9') {
+		if _, err := parseCron(c); err != nil {
+			p.errorfAt(cronjob.Pos, "invalid CRON format %q at \"cronjob\" in \"schedule\" section: %s", c, err.Error())
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package actionlint
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDependabotConfigOK(t *testing.T) {
+	src := `version: 2
+updates:
+  - package-ecosystem: github-actions
+    directory: /
+    schedule:
+      interval: weekly
+      day: Friday
+      time: "09:30"
+      timezone: Asia/Tokyo
+  - package-ecosystem: npm
+    directory: /
+    schedule:
+      interval: cron
+      cronjob: "0 9 * * 1-5"
+  - package-ecosystem: pip
+    directory: /
+    schedule:
+      interval: cron
+      cronjob: every day at 5pm
+  - package-ecosystem: docker
+    directory: /
+    unknown-key: ignored
+multi-ecosystem-groups:
+  infra:
+    schedule:
+      interval: monthly
+`
+	if errs := checkDependabotConfig([]byte(src)); len(errs) > 0 {
+		t.Fatal("unexpected errors:", errs)
+	}
+}
+
+func TestDependabotConfigError(t *testing.T) {
+	testCases := []struct {
+		what string
+		src  string
+		want string
+	}{
+		{
+			what: "unknown interval",
+			src:  "updates:\n  - schedule:\n      interval: weekley\n",
+			want: `invalid interval "weekley" at "interval" in "schedule" section. did you mean "weekly"?`,
+		},
+		{
+			what: "missing interval",
+			src:  "updates:\n  - schedule:\n      day: monday\n",
+			want: `"interval" is required in "schedule" section`,
+		},
+		{
+			what: "day with daily interval",
+			src:  "updates:\n  - schedule:\n      interval: daily\n      day: monday\n",
+			want: `"day" in "schedule" section is only available when "interval" is "weekly" but it is "daily"`,
+		},
+		{
+			what: "unknown day",
+			src:  "updates:\n  - schedule:\n      interval: weekly\n      day: mon\n",
+			want: `invalid day "mon" at "day" in "schedule" section.`,
+		},
+		{
+			what: "invalid time",
+			src:  "updates:\n  - schedule:\n      interval: daily\n      time: '9:00'\n",
+			want: `time "9:00" at "time" in "schedule" section must be in "hh:mm" format`,
+		},
+		{
+			what: "missing cronjob",
+			src:  "updates:\n  - schedule:\n      interval: cron\n",
+			want: `"cronjob" is required in "schedule" section when "interval" is "cron"`,
+		},
+		{
+			what: "cronjob without cron interval",
+			src:  "updates:\n  - schedule:\n      interval: daily\n      cronjob: '0 9 * * *'\n",
+			want: `"cronjob" in "schedule" section is only available when "interval" is "cron" but it is "daily"`,
+		},
+		{
+			what: "invalid cronjob",
+			src:  "updates:\n  - schedule:\n      interval: cron\n      cronjob: '0 25 * * *'\n",
+			want: `invalid CRON format "0 25 * * *" at "cronjob" in "schedule" section: `,
+		},
+		{
+			what: "question mark in cronjob",
+			src:  "updates:\n  - schedule:\n      interval: cron\n      cronjob: '0 9 ? * *'\n",
+			want: `"?" is not available. use "*" instead`,
+		},
+		{
+			what: "schedule in multi-ecosystem-groups",
+			src:  "multi-ecosystem-groups:\n  infra:\n    schedule:\n      interval: hourly\n",
+			want: `invalid interval "hourly" at "interval" in "schedule" section.`,
+		},
+		{
+			what: "broken YAML",
+			src:  "updates: [\n",
+			want: `could not parse as YAML`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			errs := checkDependabotConfig([]byte(tc.src))
+			if len(errs) != 1 {
+				t.Fatalf("wanted one error but got %v", errs)
+			}
+			if !strings.Contains(errs[0].Message, tc.want) {
+				t.Fatalf("wanted error message %q to contain %q", errs[0].Message, tc.want)
+			}
+		})
+	}
+}
+
+func TestDependabotConfigPath(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		want bool
+	}{
+		{".github/dependabot.yml", true},
+		{"/path/to/repo/.github/dependabot.yaml", true},
+		{".github/workflows/dependabot.yml", false},
+		{"dependabot.yml", false},
+	} {
+		if have := isDependabotConfigPath(filepath.FromSlash(tc.path)); have != tc.want {
+			t.Errorf("wanted %v for %q but got %v", tc.want, tc.path, have)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/mattn/go-runewidth"
@@ -22,6 +23,14 @@ var (
 	gray   = color.New(color.FgHiBlack)
 )
 
+const (
+	// SeverityError is a severity of errors which make actionlint fail. This is the default severity.
+	SeverityError = "error"
+	// SeverityWarning is a severity of errors which are reported but don't make actionlint fail when
+	// "-fail-on error" is specified.
+	SeverityWarning = "warning"
+)
+
 // Error represents an error detected by actionlint rules
 type Error struct {
 	// Message is an error message.
@@ -30,17 +39,35 @@ type Error struct {
 	Filepath string
 	// Line is a line number where the error occurred. This value is 1-based.
 	Line int
-	// Column is a column number where the error occurred. This value is 1-based.
+	// Column is a column number where the error occurred. This value is 1-based. The column counts
+	// characters, not bytes.
 	Column int
+	// Offset is a byte offset in the source where the error occurred. This value is 0-based. It is
+	// -1 when the position is not in the source. This value is populated by Linter.
+	Offset int
 	// Kind is a string to represent kind of the error. Usually rule name which found the error.
 	Kind string
+	// Severity is a severity of the error. SeverityError or SeverityWarning. Empty string means
+	// SeverityError. This value is populated by Linter following the "severity" configuration.
+	Severity string
+	// Category is a category of the error such as CategorySyntax or CategorySecurity. This value is
+	// populated by Linter. See RuleCategories for the categories of the built-in rules.
+	Category string
 }
 
 // Error returns summary of the error as string.
 func (e *Error) Error() string {
+	if e.IsWarning() {
+		return fmt.Sprintf("%s:%d:%d: warning: %s [%s]", e.Filepath, e.Line, e.Column, e.Message, e.Kind)
+	}
 	return fmt.Sprintf("%s:%d:%d: %s [%s]", e.Filepath, e.Line, e.Column, e.Message, e.Kind)
 }
 
+// IsWarning returns true when the severity of the error is SeverityWarning.
+func (e *Error) IsWarning() bool {
+	return e.Severity == SeverityWarning
+}
+
 func (e *Error) String() string {
 	return e.Error()
 }
@@ -50,6 +77,7 @@ func errorAt(pos *Pos, kind string, msg string) *Error {
 		Message: msg,
 		Line:    pos.Line,
 		Column:  pos.Col,
+		Offset:  -1,
 		Kind:    kind,
 	}
 }
@@ -59,6 +87,7 @@ func errorfAt(pos *Pos, kind string, format string, args ...interface{}) *Error
 		Message: fmt.Sprintf(format, args...),
 		Line:    pos.Line,
 		Column:  pos.Col,
+		Offset:  -1,
 		Kind:    kind,
 	}
 }
@@ -70,7 +99,7 @@ func (e *Error) GetTemplateFields(source []byte) *ErrorTemplateFields {
 	if len(source) > 0 && e.Line > 0 {
 		if l, ok := e.getLine(source); ok {
 			snippet = l
-			if len(l) >= e.Column-1 {
+			if utf8.RuneCountInString(l) >= e.Column-1 {
 				if i := e.getIndicator(l); i != "" {
 					snippet += "\n" + i
 					end = len(i) // Byte length can be used here because this line only contains ASCII
@@ -84,7 +113,10 @@ func (e *Error) GetTemplateFields(source []byte) *ErrorTemplateFields {
 		Filepath:  e.Filepath,
 		Line:      e.Line,
 		Column:    e.Column,
+		Offset:    e.Offset,
 		Kind:      e.Kind,
+		Severity:  severityOrDefault(e.Severity),
+		Category:  e.Category,
 		Snippet:   snippet,
 		EndColumn: end,
 	}
@@ -100,6 +132,9 @@ func (e *Error) PrettyPrint(w io.Writer, source []byte) {
 	gray.Fprint(w, ":")
 	fmt.Fprint(w, e.Column)
 	gray.Fprint(w, ": ")
+	if e.IsWarning() {
+		yellow.Fprint(w, "warning: ")
+	}
 	bold.Fprint(w, e.Message)
 	gray.Fprintf(w, " [%s]\n", e.Kind)
 
@@ -107,7 +142,7 @@ func (e *Error) PrettyPrint(w io.Writer, source []byte) {
 		return
 	}
 	line, ok := e.getLine(source)
-	if !ok || len(line) < e.Column-1 {
+	if !ok || utf8.RuneCountInString(line) < e.Column-1 {
 		return
 	}
 
@@ -137,7 +172,10 @@ func (e *Error) getIndicator(line string) string {
 		return ""
 	}
 
-	start := e.Column - 1 // Column is 1-based
+	start := byteOffsetOfColumn(line, e.Column)
+	if start < 0 {
+		return ""
+	}
 
 	// Count width of non-space characters after '^' for underline
 	uw := 0
@@ -158,6 +196,59 @@ func (e *Error) getIndicator(line string) string {
 	return fmt.Sprintf("%s^%s", strings.Repeat(" ", sw), strings.Repeat("~", uw))
 }
 
+// severityOrDefault returns the given severity. When it is empty, the default severity is returned.
+func severityOrDefault(s string) string {
+	if s == "" {
+		return SeverityError
+	}
+	return s
+}
+
+// offsetIn returns the 0-based byte offset of the error position in the source. -1 is returned
+// when the position is not in the source.
+func (e *Error) offsetIn(source []byte) int {
+	if e.Line <= 0 || e.Column <= 0 {
+		return -1
+	}
+	start := 0
+	for l := 1; l < e.Line; l++ {
+		i := bytes.IndexByte(source[start:], '\n')
+		if i < 0 {
+			return -1
+		}
+		start += i + 1
+	}
+	line := source[start:]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	o := byteOffsetOfColumn(string(line), e.Column)
+	if o < 0 {
+		return -1
+	}
+	return start + o
+}
+
+// byteOffsetOfColumn returns the byte offset of the 1-based column in the line. The column counts
+// characters so multi-byte characters are considered. -1 is returned when the column is out of the
+// line. The column next to the last character is in the line.
+func byteOffsetOfColumn(line string, col int) int {
+	if col <= 0 {
+		return -1
+	}
+	c := 1
+	for i := range line {
+		if c == col {
+			return i
+		}
+		c++
+	}
+	if c == col {
+		return len(line)
+	}
+	return -1
+}
+
 // ByErrorPosition is predicate for sort.Interface. It sorts errors slice by file path, line, and
 // column.
 type ByErrorPosition []*Error
@@ -191,8 +282,16 @@ type ErrorTemplateFields struct {
 	Line int `json:"line"`
 	// Column is a column number of error position.
 	Column int `json:"column"`
+	// Offset is a 0-based byte offset of error position in the source. It is -1 when the position is
+	// not in the source. When encoding into JSON, this field may be omitted when the offset is 0.
+	Offset int `json:"offset,omitempty"`
 	// Kind is a rule name the error belongs to.
 	Kind string `json:"kind"`
+	// Severity is a severity of the error. "error" or "warning".
+	Severity string `json:"severity,omitempty"`
+	// Category is a category of the error. See RuleCategories for the categories of the built-in
+	// rules. When encoding into JSON, this field may be omitted when the category is empty.
+	Category string `json:"category,omitempty"`
 	// Snippet is a code snippet and indicator to indicate where the error occurred.
 	// When encoding into JSON, this field may be omitted when the snippet is empty.
 	Snippet string `json:"snippet,omitempty"`
@@ -232,6 +331,10 @@ func toPascalCase(s string) string {
 	return strings.Join(ss, "")
 }
 
+// syntaxCheckRuleDesc is a description of "syntax-check" kind. Errors of this kind are reported by
+// the parser, not by any rule.
+const syntaxCheckRuleDesc = "Checks for GitHub Actions workflow syntax"
+
 type ruleTemplateFields struct {
 	Name        string
 	Description string
@@ -249,23 +352,75 @@ func (by byRuleNameField) Swap(i, j int) {
 	by[i], by[j] = by[j], by[i]
 }
 
+func sortedRuleFields(r map[string]*ruleTemplateFields) []*ruleTemplateFields {
+	ret := make([]*ruleTemplateFields, 0, len(r))
+	for _, e := range r {
+		ret = append(ret, e)
+	}
+	sort.Sort(byRuleNameField(ret))
+	return ret
+}
+
+// builtinErrorFormat is a function to print errors in the built-in format. files is a sorted list
+// of all checked file paths including files which have no error.
+type builtinErrorFormat func(out io.Writer, errs []*ErrorTemplateFields, rules []*ruleTemplateFields, files []string) error
+
+// printErrorsInGCC prints the errors in the one-line format used by GCC like "path:line:col: message [rule]".
+// The format is recognized by quickfix of many editors. Newlines in messages are replaced with spaces
+// so that each error is always printed in one line.
+func printErrorsInGCC(out io.Writer, errs []*ErrorTemplateFields, rules []*ruleTemplateFields, files []string) error {
+	for _, e := range errs {
+		sev := ""
+		if e.Severity == SeverityWarning {
+			sev = "warning: "
+		}
+		msg := strings.ReplaceAll(e.Message, "\n", " ")
+		if _, err := fmt.Fprintf(out, "%s:%d:%d: %s%s [%s]\n", e.Filepath, e.Line, e.Column, sev, msg, e.Kind); err != nil {
+			return fmt.Errorf("could not write errors in GCC format: %w", err)
+		}
+	}
+	return nil
+}
+
+// builtinErrorFormats is a map from the names of built-in formats to their implementations. The
+// names can be given to NewErrorFormatter instead of templates.
+var builtinErrorFormats = map[string]builtinErrorFormat{
+	"checkstyle": printErrorsInCheckstyle,
+	"gcc":        printErrorsInGCC,
+	"github":     printErrorsInGitHubAnnotation,
+	"json":       printErrorsInJSON,
+	"rdjsonl":    printErrorsInRDJSONL,
+	"sarif":      printErrorsInSARIF,
+}
+
 // ErrorFormatter is a formatter to format a slice of ErrorTemplateFields. It is used for
 // formatting error messages with -format option.
 type ErrorFormatter struct {
 	temp    *template.Template
+	builtin builtinErrorFormat
 	rules   map[string]*ruleTemplateFields
-	rulesMu sync.Mutex
+	files   map[string]struct{}
+	mu      sync.Mutex
 }
 
 // NewErrorFormatter creates new ErrorFormatter instance. Given format must contain at least one
 // {{ }} placeholder. Escaped characters like \n in the format string are unescaped.
+// Instead of a template, a name of built-in format can be given. Currently "json" to output errors
+// in the versioned JSON schema, "sarif" to output errors in SARIF 2.1.0 format, "checkstyle" to
+// output errors in Checkstyle XML format, "gcc" to output one error per line in GCC-style format,
+// "github" to output errors as annotations of GitHub Actions, and "rdjsonl" to output errors in
+// reviewdog's RDJSONL format are supported.
 func NewErrorFormatter(format string) (*ErrorFormatter, error) {
-	if !strings.Contains(format, "{{") {
-		return nil, fmt.Errorf("template to format error messages must contain at least one {{ }} placeholder: %s", format)
+	r := map[string]*ruleTemplateFields{
+		"syntax-check": {"syntax-check", syntaxCheckRuleDesc},
 	}
 
-	r := map[string]*ruleTemplateFields{
-		"syntax-check": {"syntax-check", "Checks for GitHub Actions workflow syntax"},
+	if b, ok := builtinErrorFormats[format]; ok {
+		return &ErrorFormatter{nil, b, r, map[string]struct{}{}, sync.Mutex{}}, nil
+	}
+
+	if !strings.Contains(format, "{{") {
+		return nil, fmt.Errorf("template to format error messages must contain at least one {{ }} placeholder: %s", format)
 	}
 
 	funcs := template.FuncMap(map[string]interface{}{
@@ -283,12 +438,7 @@ func NewErrorFormatter(format string) (*ErrorFormatter, error) {
 		"toPascalCase": toPascalCase,
 		"getVersion":   getCommandVersion,
 		"allKinds": func() []*ruleTemplateFields {
-			ret := make([]*ruleTemplateFields, 0, len(r))
-			for _, e := range r {
-				ret = append(ret, e)
-			}
-			sort.Sort(byRuleNameField(ret))
-			return ret
+			return sortedRuleFields(r)
 		},
 	})
 	t, err := template.New("error formatter").Funcs(funcs).Parse(unescapeBackslash(format))
@@ -296,11 +446,19 @@ func NewErrorFormatter(format string) (*ErrorFormatter, error) {
 		return nil, fmt.Errorf("template %q to format error messages could not be parsed: %w", format, err)
 	}
 
-	return &ErrorFormatter{t, r, sync.Mutex{}}, nil
+	return &ErrorFormatter{t, nil, r, map[string]struct{}{}, sync.Mutex{}}, nil
 }
 
 // Print formats the slice of template fields and prints it with given writer.
 func (f *ErrorFormatter) Print(out io.Writer, t []*ErrorTemplateFields) error {
+	if f.builtin != nil {
+		files := make([]string, 0, len(f.files))
+		for p := range f.files {
+			files = append(files, p)
+		}
+		sort.Strings(files)
+		return f.builtin(out, t, sortedRuleFields(f.rules), files)
+	}
 	if err := f.temp.Execute(out, t); err != nil {
 		return fmt.Errorf("could not format error messages: %w", err)
 	}
@@ -321,11 +479,20 @@ func (f *ErrorFormatter) PrintErrors(out io.Writer, errs []*Error, src []byte) e
 // method can be called multiple times safely in parallel.
 func (f *ErrorFormatter) RegisterRule(r Rule) {
 	// Synchronize access to f.rules (#370)
-	f.rulesMu.Lock()
-	defer f.rulesMu.Unlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
 
 	n := r.Name()
 	if _, ok := f.rules[n]; !ok {
 		f.rules[n] = &ruleTemplateFields{n, r.Description()}
 	}
 }
+
+// RegisterFile registers the path of the checked file. Registered files are used by built-in
+// formats which report files with no error. This method can be called multiple times safely in
+// parallel.
+func (f *ErrorFormatter) RegisterFile(path string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.files[path] = struct{}{}
+}
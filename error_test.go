@@ -373,6 +373,48 @@ func TestErrorGetTemplateFieldsColumnIsOutOfBounds(t *testing.T) {
 	}
 }
 
+func TestErrorGetTemplateFieldsMultiByteCharacters(t *testing.T) {
+	err := errorAt(&Pos{1, 14}, "kind", "this is message")
+	err.Filepath = "filename.yaml"
+	f := err.GetTemplateFields([]byte("{name: 'ああ', wit: 1}"))
+	want := "{name: 'ああ', wit: 1}\n               ^~~~"
+	if f.Snippet != want {
+		t.Fatalf("wanted %q but have %q", want, f.Snippet)
+	}
+}
+
+func TestErrorOffsetIn(t *testing.T) {
+	testCases := []struct {
+		what   string
+		line   int
+		col    int
+		source string
+		want   int
+	}{
+		{"first character", 1, 1, "foo\nbar\n", 0},
+		{"first line", 1, 3, "foo\nbar\n", 2},
+		{"second line", 2, 2, "foo\nbar\n", 5},
+		{"end of line", 2, 4, "foo\nbar\n", 7},
+		{"last line without newline", 2, 3, "foo\nbar", 6},
+		{"multi-byte characters", 1, 6, "'ああ' x", 9},
+		{"tab", 2, 2, "foo\n\tbar", 5},
+		{"CRLF", 2, 1, "foo\r\nbar", 5},
+		{"line out of source", 3, 1, "foo\nbar", -1},
+		{"column out of line", 1, 5, "foo\nbar", -1},
+		{"zero line", 0, 1, "foo", -1},
+		{"zero column", 1, 0, "foo", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			err := errorAt(&Pos{tc.line, tc.col}, "kind", "message")
+			if have := err.offsetIn([]byte(tc.source)); have != tc.want {
+				t.Fatalf("wanted %d but have %d", tc.want, have)
+			}
+		})
+	}
+}
+
 func TestErrorErrorToString(t *testing.T) {
 	err := &Error{
 		Message: "this is message",
@@ -387,6 +429,29 @@ func TestErrorErrorToString(t *testing.T) {
 	}
 }
 
+func TestErrorWarningSeverity(t *testing.T) {
+	err := &Error{
+		Message:  "this is message",
+		Filepath: "test.yaml",
+		Line:     1,
+		Column:   2,
+		Kind:     "test",
+		Severity: SeverityWarning,
+	}
+	want := "test.yaml:1:2: warning: this is message [test]"
+	if have := err.Error(); have != want {
+		t.Fatalf("wanted %q but have %q", want, have)
+	}
+	if f := err.GetTemplateFields(nil); f.Severity != SeverityWarning {
+		t.Fatalf("wanted severity %q but have %q", SeverityWarning, f.Severity)
+	}
+
+	err.Severity = ""
+	if f := err.GetTemplateFields(nil); f.Severity != SeverityError {
+		t.Fatalf("wanted default severity %q but have %q", SeverityError, f.Severity)
+	}
+}
+
 var testErrorTemplateFields = []*ErrorTemplateFields{
 	{
 		Message:   "message 1",
@@ -555,6 +620,7 @@ func TestErrorFormatterPrintError(t *testing.T) {
 	}{
 		{io.Discard, "{{.Foo}}", "can't evaluate field Foo in type"},
 		{testErrorWriter{}, "{{(index . 0).Message}}", "dummy write error"},
+		{testErrorWriter{}, "sarif", "could not encode errors into SARIF"},
 	}
 
 	for _, tc := range testCases {
@@ -590,6 +656,37 @@ func TestErrorFormatterPrintJSONEncodeError(t *testing.T) {
 	}
 }
 
+func TestErrorPrintErrorsInGCCFormat(t *testing.T) {
+	errs := []*ErrorTemplateFields{
+		{
+			Message:  "this is error",
+			Filepath: "a.yaml",
+			Line:     1,
+			Column:   2,
+			Kind:     "expression",
+			Severity: SeverityError,
+		},
+		{
+			Message:  "multi\nline",
+			Filepath: "b.yaml",
+			Line:     3,
+			Column:   4,
+			Kind:     "shellcheck",
+			Severity: SeverityWarning,
+		},
+	}
+
+	var b strings.Builder
+	if err := printErrorsInGCC(&b, errs, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "a.yaml:1:2: this is error [expression]\nb.yaml:3:4: warning: multi line [shellcheck]\n"
+	if have := b.String(); have != want {
+		t.Fatalf("wanted %q but have %q", want, have)
+	}
+}
+
 func TestErrorFormatterPrintToPascalCase(t *testing.T) {
 	tests := []struct {
 		input string
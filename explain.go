@@ -0,0 +1,490 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ruleExplanation is a detailed explanation of a rule shown by `actionlint explain` subcommand.
+type ruleExplanation struct {
+	// example is an example of workflow which causes an error of the rule.
+	example string
+	// fix is the recommended way to fix the error.
+	fix string
+	// config is a list of keys in the configuration file which affect the rule.
+	config []string
+	// anchor is an anchor of the section in checks.md document.
+	anchor string
+	// optIn is true when the rule checks nothing until it is enabled in the configuration file.
+	optIn bool
+}
+
+var ruleExplanations = map[string]*ruleExplanation{
+	"syntax-check": {
+		example: `on:
+  push:
+    branch: main # "branches" is correct
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello`,
+		fix:    "Follow the workflow syntax. Remove unexpected keys, add missing required keys, and fix the types of values.",
+		anchor: "check-unexpected-keys",
+	},
+	"action": {
+		example: `steps:
+  - uses: actions/checkout@v4
+    with:
+      path_to_checkout: foo # "path" is correct`,
+		fix:    "Fix the format of the action at \"uses:\" and the inputs at \"with:\" following the action's metadata (action.yml).",
+		config: []string{"require-pinned-actions", "trusted-actions", "allowed-actions", "denied-actions", "require-docker-action-digest", "check-action-input-types", "action-input-types"},
+		anchor: "check-action-format",
+	},
+	"action-permissions": {
+		example: `permissions:
+  contents: read
+jobs:
+  release:
+    steps:
+      - uses: softprops/action-gh-release@v2 # Requires "contents: write"`,
+		fix:    "Grant the permission scopes required by the action at \"permissions:\" of the job or the workflow. Add required permissions of internal actions to \"action-permissions\" in the configuration file.",
+		config: []string{"check-action-permissions", "action-permissions"},
+		anchor: "check-action-permissions",
+		optIn:  true,
+	},
+	"artifact": {
+		example: `jobs:
+  build:
+    steps:
+      - uses: actions/upload-artifact@v3
+        with:
+          name: dist
+  deploy:
+    steps:
+      - uses: actions/download-artifact@v4
+        with:
+          name: dist`,
+		fix:    "Use the same major version of actions/upload-artifact and actions/download-artifact, and include matrix values in artifact names uploaded from matrix jobs.",
+		anchor: "check-artifact-actions",
+	},
+	"broad-permissions": {
+		example: `permissions: write-all
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test`,
+		fix:    "List only the permission scopes needed by each job at \"permissions:\" of the job instead of granting all scopes at once.",
+		config: []string{"broad-permissions"},
+		anchor: "check-broad-permissions",
+		optIn:  true,
+	},
+	"cache-key": {
+		example: `steps:
+  - uses: actions/cache@v4
+    with:
+      path: ~/.npm
+      key: npm-cache`,
+		fix:    "Include values which change with the cached content such as hashFiles() of lock files in the key. Per-run values such as github.run_id should not be the only variation.",
+		anchor: "check-cache-key",
+	},
+	"concurrency": {
+		example: `on: pull_request
+concurrency:
+  group: ${{ github.workflow }}-${{ github.event.pull_request.number }}
+  # "cancel-in-progress: true" is missing`,
+		fix:    "Set \"cancel-in-progress: true\" in \"concurrency:\", or put comment \"# " + ignoreComment("concurrency") + "\" at the line of \"concurrency:\" if runs should not be canceled. Remove job-level \"concurrency:\" whose group is the same as the workflow-level one.",
+		anchor: "check-concurrency",
+	},
+	"credentials": {
+		example: `container:
+  image: 'example.com/my-image'
+  credentials:
+    username: user
+    password: pass`,
+		fix:    "Put the credentials in secrets and refer them with ${{ secrets.XXX }}. If a string is not a credential, add its prefix to \"allowed-credential-prefixes\" in the configuration file.",
+		anchor: "check-hardcoded-credentials",
+	},
+	"deprecated-commands": {
+		example: `steps:
+  - run: echo "::set-output name=foo::bar"`,
+		fix:    "Use the environment files such as $GITHUB_OUTPUT instead. -fix option rewrites \"set-output\" and \"save-state\" commands automatically. Commands listed in \"forbid-workflow-commands\" should be replaced with the alternatives decided in your team.",
+		config: []string{"forbid-workflow-commands"},
+		anchor: "check-deprecated-workflow-commands",
+	},
+	"env-var": {
+		example: `env:
+  FOO BAR: value`,
+		fix:    "Use a name which starts with a letter or '_' and consists of only letters, digits, and '_' for the environment variable.",
+		anchor: "check-env-var-names",
+	},
+	"environment": {
+		example: `jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    environment:
+      name: production
+      url: example.com # "https://example.com" is correct`,
+		fix:    "Use a descriptive environment name which does not consist of only digits, and an absolute URL with http or https scheme at \"url:\".",
+		anchor: "check-environment",
+	},
+	"events": {
+		example: `on:
+  pull_request:
+    types: [opened, foo]`,
+		fix:    "Fix the event names, the event types, the filters, and the CRON syntax at \"on:\" following the document of events.",
+		anchor: "check-webhook-events",
+	},
+	"expression": {
+		example: `steps:
+  - run: echo '${{ github.event.head_commit.messag }}'`,
+		fix:    "Fix the syntax and the types in ${{ }}. Only properties, functions, and contexts available at the place can be used.",
+		config: []string{"config-variables", "untrusted-inputs"},
+		anchor: "check-syntax-expression",
+	},
+	"gating-jobs": {
+		example: `jobs:
+  test: # "test" is listed in "gating-jobs"
+    runs-on: ubuntu-latest
+    continue-on-error: true`,
+		fix:    "Remove \"continue-on-error: true\" from the job so that its failure blocks merging.",
+		config: []string{"gating-jobs"},
+		anchor: "check-gating-jobs",
+		optIn:  true,
+	},
+	"glob": {
+		example: `on:
+  push:
+    branches: ['^foo-']`,
+		fix:    "Fix the glob pattern. Note that regular expression is not available for filters.",
+		anchor: "check-glob-pattern",
+	},
+	"id": {
+		example: `jobs:
+  test:
+    steps:
+      - id: step1
+        run: echo a
+      - id: step1
+        run: echo b`,
+		fix:    "Use unique IDs which consist of alphanumeric characters, '-' and '_', and start with a letter or '_'.",
+		anchor: "check-job-step-ids",
+	},
+	"if-cond": {
+		example: `steps:
+  - run: echo hello
+    if: |
+      ${{ false }}`,
+		fix:    "Remove extra characters outside ${{ }} at \"if:\" or remove ${{ }} entirely. When the condition consists of only constant values, remove it or fix it to depend on contexts.",
+		anchor: "if-cond-always-true",
+	},
+	"job-timeout": {
+		example: `jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make test`,
+		fix:    "Set \"timeout-minutes:\" to the job. Jobs calling reusable workflows are not checked.",
+		config: []string{"require-timeout"},
+		anchor: "check-job-timeout",
+		optIn:  true,
+	},
+	"job-needs": {
+		example: `jobs:
+  test:
+    needs: [buld] # "build" is correct
+    runs-on: ubuntu-latest`,
+		fix:    "Specify existing job IDs at \"needs:\" and remove cyclic dependencies between jobs. Add the jobs referred via \"needs\" context to \"needs:\".",
+		config: []string{"check-unused-needs"},
+		anchor: "check-job-deps",
+	},
+	"job-outputs": {
+		example: `outputs:
+  version: ${{ steps.meta.outputs.verison }} # "version" is correct
+steps:
+  - id: meta
+    run: echo "version=1.2.3" >> "$GITHUB_OUTPUT"`,
+		fix:    "Refer the output names set in the script of the step at \"outputs:\" of the job, or set the outputs in the script.",
+		anchor: "check-job-outputs",
+	},
+	"matrix": {
+		example: `strategy:
+  matrix:
+    os: [ubuntu-latest, ubuntu-latest]`,
+		fix:    "Remove duplicate values and fix \"include:\" and \"exclude:\" to refer existing matrix values.",
+		anchor: "check-matrix-values",
+	},
+	"multiline-env": {
+		example: `steps:
+  - run: echo "CHANGES=$(git diff --name-only)" >> "$GITHUB_ENV"`,
+		fix:    "Write the multiline value with delimiter like \"{ echo 'CHANGES<<EOF'; git diff --name-only; echo EOF; } >> \"$GITHUB_ENV\"\". Put \"# " + ignoreComment("multiline-env") + "\" comment at the line of \"run:\" if the value is always one line.",
+		anchor: "check-multiline-env",
+	},
+	"node-runtime": {
+		example: `steps:
+  # This action runs on "node16" runtime
+  - uses: some-org/some-action@v1`,
+		fix:    "Update the action to the version running on \"node20\" runtime. For remote actions, runtimes are configured at \"action-runtimes\". Put \"# " + ignoreComment("node-runtime") + "\" comment at the line of \"uses:\" if this is intended.",
+		config: []string{"action-runtimes"},
+		anchor: "check-deprecated-node-runtime",
+	},
+	"permissions": {
+		example: `permissions:
+  content: read # "contents" is correct`,
+		fix:    "Use the valid permission scopes and the access levels \"read\", \"write\", or \"none\".",
+		anchor: "permissions",
+	},
+	"pyflakes": {
+		example: `steps:
+  - run: print(hello)
+    shell: python`,
+		fix:    "Fix the issue in the Python script reported by pyflakes.",
+		anchor: "check-pyflakes-integ",
+	},
+	"reserved-env": {
+		example: `env:
+  CI: false # "CI" is set by GitHub Actions`,
+		fix:    "Use another name for the environment variable. Add the name to \"allowed-reserved-env-vars\" in the configuration file if shadowing the default variable is intended.",
+		config: []string{"allowed-reserved-env-vars"},
+		anchor: "check-reserved-env",
+	},
+	"runner-label": {
+		example: `jobs:
+  test:
+    runs-on: linux-latest # "ubuntu-latest" is correct`,
+		fix:    "Use the labels of GitHub-hosted runners, or add labels of your self-hosted runners to the configuration file.",
+		config: []string{"self-hosted-runner.labels", "self-hosted-runner.labels-files"},
+		anchor: "check-runner-labels",
+	},
+	"secret-print": {
+		example: `steps:
+  - run: echo "${{ secrets.TOKEN }}"`,
+		fix:    "Pass the secret via environment variable and do not print it. Put \"# " + ignoreComment("secret-print") + "\" comment at the line of \"run:\" if printing it is intended.",
+		config: []string{"check-secret-print"},
+		anchor: "check-secrets-printed-in-scripts",
+		optIn:  true,
+	},
+	"shallow-checkout": {
+		example: `steps:
+  - uses: actions/checkout@v4
+  - run: git describe --tags`,
+		fix:    "Set \"fetch-depth: 0\" at \"with:\" of actions/checkout to fetch all history and tags. Put \"# " + ignoreComment("shallow-checkout") + "\" comment at the line of \"run:\" if the shallow clone is intended.",
+		anchor: "check-shallow-checkout",
+	},
+	"services": {
+		example: `services:
+  redis:
+    image: redis:7
+    ports:
+      - 70000:6379 # Port number is out of range
+    options: --network host # Not supported`,
+		fix:    "Fix the image reference at \"image:\", the port mappings in the form of \"[[ip:]host:]container[/protocol]\" at \"ports:\", and the docker run options at \"options:\".",
+		anchor: "check-services",
+	},
+	"shell-name": {
+		example: `steps:
+  - run: echo hello
+    shell: dash`,
+		fix:    "Use a shell name available on the runner such as \"bash\", \"pwsh\", \"python\", or \"sh\". When the script uses bash-specific syntax, set \"shell: bash\" instead of \"shell: sh\".",
+		anchor: "check-shell-names",
+	},
+	"shellcheck": {
+		example: `steps:
+  - run: echo $FOO`,
+		fix:    "Fix the issue in the shell script reported by shellcheck. See the wiki page of the reported SC code.",
+		anchor: "check-shellcheck-integ",
+	},
+	"unreachable-steps": {
+		example: `steps:
+  - run: |
+      echo 'Not supported'
+      exit 1
+  - run: echo 'This step never runs'`,
+		fix:    "Remove the \"exit\" at the end of the script, set \"continue-on-error: true\" at the failing step, or add \"if:\" condition such as \"if: failure()\" to the following step.",
+		anchor: "check-unreachable-steps",
+	},
+	"untrusted-checkout": {
+		example: `on: pull_request_target
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - run: make test # Runs untrusted code with secrets`,
+		fix:    "Use \"pull_request\" event to run the code of pull requests. Put \"# " + ignoreComment("untrusted-checkout") + "\" comment at the line of \"uses:\" only when the checked out code is never executed.",
+		anchor: "check-untrusted-checkout",
+	},
+	"unused-inputs": {
+		example: `on:
+  workflow_dispatch:
+    inputs:
+      verbose:
+        type: boolean`,
+		fix:    "Remove the unused input. Put \"# " + ignoreComment("unused-inputs") + "\" comment at the line of the input if it is used outside the workflow.",
+		config: []string{"check-unused-inputs"},
+		anchor: "check-unused-workflow-dispatch-inputs",
+		optIn:  true,
+	},
+	"unused-step-outputs": {
+		example: `steps:
+  - id: version
+    run: echo "version=1.2.3" >> "$GITHUB_OUTPUT"
+  - run: echo 'version is not used'`,
+		fix:    "Remove the unused output or use it via \"steps.<id>.outputs.<name>\". Put \"# " + ignoreComment("unused-step-outputs") + "\" comment at the line of \"run:\" if this is intended.",
+		anchor: "check-unused-step-outputs",
+	},
+	"workflow-call": {
+		example: `jobs:
+  call:
+    uses: ./.github/workflows/reusable.yaml
+    with:
+      unknown_input: foo`,
+		fix:    "Pass the inputs and the secrets defined in the called reusable workflow.",
+		anchor: "check-reusable-workflows",
+	},
+	"working-directory": {
+		example: `steps:
+  - uses: actions/checkout@v4
+  - run: make
+    working-directory: ./not-existing-dir`,
+		fix:    "Fix the path at \"working-directory:\". It is resolved from the path where the repository is checked out by actions/checkout.",
+		config: []string{"check-working-directory"},
+		anchor: "check-working-directory",
+		optIn:  true,
+	},
+}
+
+// builtinRuleFields returns names and descriptions of all built-in rules sorted by the names. The
+// list is derived from the rules used by the linter.
+func builtinRuleFields() []*ruleTemplateFields {
+	rules := append(newRules("", nil, nil, nil, nil, false), newRuleShellcheck(nil), newRulePyflakes(nil))
+	fields := map[string]*ruleTemplateFields{
+		"syntax-check": {"syntax-check", syntaxCheckRuleDesc},
+	}
+	for _, r := range rules {
+		fields[r.Name()] = &ruleTemplateFields{r.Name(), r.Description()}
+	}
+	return sortedRuleFields(fields)
+}
+
+func printRuleList(out io.Writer) {
+	rules := builtinRuleFields()
+	w := 0
+	for _, r := range rules {
+		if len(r.Name) > w {
+			w = len(r.Name)
+		}
+	}
+	for _, r := range rules {
+		fmt.Fprintf(out, "%-*s  %s\n", w, r.Name, r.Description)
+	}
+}
+
+// ruleListEntry is an entry of the list of built-in rules printed by -list-rules option.
+type ruleListEntry struct {
+	// ID is the name of the rule.
+	ID string `json:"id"`
+	// Description is the short description of the rule.
+	Description string `json:"description"`
+	// Severity is the default severity of errors reported by the rule.
+	Severity string `json:"severity"`
+	// Category is the category of errors reported by the rule.
+	Category string `json:"category"`
+	// OptIn is true when the rule needs to be enabled in the configuration file.
+	OptIn bool `json:"opt_in"`
+}
+
+func builtinRuleList() []*ruleListEntry {
+	rules := builtinRuleFields()
+	ret := make([]*ruleListEntry, 0, len(rules))
+	for _, r := range rules {
+		e := &ruleListEntry{
+			ID:          r.Name,
+			Description: r.Description,
+			Severity:    (*Config)(nil).RuleSeverity(r.Name),
+			Category:    RuleCategory(r.Name),
+		}
+		if x, ok := ruleExplanations[r.Name]; ok {
+			e.OptIn = x.optIn
+		}
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+// printRuleListWithDetails prints all built-in rules with their default severities and whether they
+// are opt-in. The format is "json" or empty for human-readable table.
+func printRuleListWithDetails(out io.Writer, format string) error {
+	rules := builtinRuleList()
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rules); err != nil {
+			return fmt.Errorf("could not encode rules into JSON: %w", err)
+		}
+		return nil
+	case "":
+		w := len("ID")
+		for _, r := range rules {
+			if len(r.ID) > w {
+				w = len(r.ID)
+			}
+		}
+		fmt.Fprintf(out, "%-*s  %-8s  %-10s  %-6s  %s\n", w, "ID", "SEVERITY", "CATEGORY", "OPT-IN", "DESCRIPTION")
+		for _, r := range rules {
+			o := "no"
+			if r.OptIn {
+				o = "yes"
+			}
+			fmt.Fprintf(out, "%-*s  %-8s  %-10s  %-6s  %s\n", w, r.ID, r.Severity, r.Category, o, r.Description)
+		}
+		return nil
+	default:
+		return fmt.Errorf("-list-rules option only supports \"json\" for -format option but got %q", format)
+	}
+}
+
+func printRuleExplanation(out io.Writer, name string) error {
+	rules := builtinRuleFields()
+	var rule *ruleTemplateFields
+	for _, r := range rules {
+		if r.Name == name {
+			rule = r
+			break
+		}
+	}
+	if rule == nil {
+		names := make([]string, 0, len(rules))
+		for _, r := range rules {
+			names = append(names, r.Name)
+		}
+		return fmt.Errorf("unknown rule %q.%s available rules are %s", name, didYouMean(name, names), quotes(names))
+	}
+
+	fmt.Fprintf(out, "%s: %s\n", rule.Name, rule.Description)
+
+	e, ok := ruleExplanations[name]
+	if !ok {
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nExample:\n\n%s\n", indentLines(e.example, "  "))
+	fmt.Fprintf(out, "\nFix:\n\n  %s\n", e.fix)
+	if len(e.config) > 0 {
+		fmt.Fprintf(out, "\nConfiguration:\n\n  %s\n", strings.Join(e.config, "\n  "))
+	}
+	fmt.Fprintf(out, "\nIgnore:\n\n  Use -ignore option or \"ignore\" in \"paths\" configuration with regular expressions matching to the error messages.\n")
+	fmt.Fprintf(out, "\nDocument:\n\n  %s#%s\n", checksDocumentURL, e.anchor)
+	return nil
+}
+
+func indentLines(s, indent string) string {
+	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+}
@@ -0,0 +1,75 @@
+package actionlint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplainAllRulesHaveExplanation(t *testing.T) {
+	rules := builtinRuleFields()
+	names := map[string]struct{}{}
+	for _, r := range rules {
+		names[r.Name] = struct{}{}
+		if _, ok := ruleExplanations[r.Name]; !ok {
+			t.Errorf("explanation of rule %q is missing", r.Name)
+		}
+	}
+	for n := range ruleExplanations {
+		if _, ok := names[n]; !ok {
+			t.Errorf("explanation of rule %q exists but the rule is not registered", n)
+		}
+	}
+}
+
+func TestExplainRuleOK(t *testing.T) {
+	var b strings.Builder
+	if err := printRuleExplanation(&b, "runner-label"); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"runner-label: Checks for GitHub-hosted",
+		"\nExample:\n\n  jobs:\n",
+		"\nFix:\n\n",
+		"\nConfiguration:\n\n  self-hosted-runner.labels\n  self-hosted-runner.labels-files\n",
+		"\nIgnore:\n\n",
+		"docs/checks.md#check-runner-labels",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q but got %q", want, out)
+		}
+	}
+}
+
+func TestExplainRuleUnknown(t *testing.T) {
+	var b strings.Builder
+	err := printRuleExplanation(&b, "runer-label")
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, `unknown rule "runer-label". did you mean "runner-label"?`) {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestExplainRuleList(t *testing.T) {
+	var b strings.Builder
+	printRuleList(&b)
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != len(builtinRuleFields()) {
+		t.Fatalf("number of lines should be number of rules but got %d lines: %q", len(lines), b.String())
+	}
+	for _, want := range []string{"syntax-check", "shellcheck", "pyflakes", "expression"} {
+		found := false
+		for _, l := range lines {
+			if strings.HasPrefix(l, want+" ") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("rule %q is not listed: %q", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// exprCollector traverses strings in the syntax tree and calls the visit callback for each
+// expression enclosed with ${{ }}. Conditions at `if:` without ${{ }} are also parsed as expressions.
+// It is used by rules which check references to some contexts across a job or a workflow. The
+// position passed to the callback is the position of the string containing the expression.
+type exprCollector struct {
+	visit func(expr ExprNode, pos *Pos)
+}
+
+// collectJob collects expressions in the job-level configurations of the job. Expressions in its
+// steps are not collected.
+func (c *exprCollector) collectJob(n *Job) {
+	c.collectString(n.Name)
+	c.collectIfCondition(n.If)
+	if n.RunsOn != nil {
+		c.collectString(n.RunsOn.LabelsExpr)
+		c.collectStrings(n.RunsOn.Labels)
+		c.collectString(n.RunsOn.Group)
+	}
+	if n.Environment != nil {
+		c.collectString(n.Environment.Name)
+		c.collectString(n.Environment.URL)
+	}
+	c.collectConcurrency(n.Concurrency)
+	for _, o := range n.Outputs {
+		c.collectString(o.Value)
+	}
+	c.collectEnv(n.Env)
+	c.collectDefaults(n.Defaults)
+	if s := n.Strategy; s != nil {
+		if s.Matrix != nil {
+			c.collectMatrix(s.Matrix)
+		}
+		if s.FailFast != nil {
+			c.collectString(s.FailFast.Expression)
+		}
+		if s.MaxParallel != nil {
+			c.collectString(s.MaxParallel.Expression)
+		}
+	}
+	if n.ContinueOnError != nil {
+		c.collectString(n.ContinueOnError.Expression)
+	}
+	if n.TimeoutMinutes != nil {
+		c.collectString(n.TimeoutMinutes.Expression)
+	}
+	c.collectContainer(n.Container)
+	if n.Services != nil {
+		c.collectString(n.Services.Expression)
+		for _, s := range n.Services.Value {
+			c.collectContainer(s.Container)
+		}
+	}
+	if w := n.WorkflowCall; w != nil {
+		c.collectString(w.Uses)
+		for _, i := range w.Inputs {
+			c.collectString(i.Value)
+		}
+		for _, s := range w.Secrets {
+			c.collectString(s.Value)
+		}
+	}
+}
+
+// collectStep collects expressions in the step.
+func (c *exprCollector) collectStep(n *Step) {
+	c.collectString(n.ID)
+	c.collectString(n.Name)
+	c.collectIfCondition(n.If)
+	switch e := n.Exec.(type) {
+	case *ExecRun:
+		c.collectString(e.Run)
+		c.collectString(e.Shell)
+		c.collectString(e.WorkingDirectory)
+	case *ExecAction:
+		c.collectString(e.Uses)
+		for _, i := range e.Inputs {
+			c.collectString(i.Value)
+		}
+		c.collectString(e.Entrypoint)
+		c.collectString(e.Args)
+	}
+	c.collectEnv(n.Env)
+	if n.ContinueOnError != nil {
+		c.collectString(n.ContinueOnError.Expression)
+	}
+	if n.TimeoutMinutes != nil {
+		c.collectString(n.TimeoutMinutes.Expression)
+	}
+}
+
+func (c *exprCollector) collectStrings(ss []*String) {
+	for _, s := range ss {
+		c.collectString(s)
+	}
+}
+
+func (c *exprCollector) collectEnv(e *Env) {
+	if e == nil {
+		return
+	}
+	c.collectString(e.Expression)
+	for _, v := range e.Vars {
+		c.collectString(v.Name)
+		c.collectString(v.Value)
+	}
+}
+
+func (c *exprCollector) collectDefaults(d *Defaults) {
+	if d == nil || d.Run == nil {
+		return
+	}
+	c.collectString(d.Run.Shell)
+	c.collectString(d.Run.WorkingDirectory)
+}
+
+func (c *exprCollector) collectConcurrency(cc *Concurrency) {
+	if cc == nil {
+		return
+	}
+	c.collectString(cc.Group)
+	if cc.CancelInProgress != nil {
+		c.collectString(cc.CancelInProgress.Expression)
+	}
+}
+
+func (c *exprCollector) collectContainer(ct *Container) {
+	if ct == nil {
+		return
+	}
+	c.collectString(ct.Image)
+	if ct.Credentials != nil {
+		c.collectString(ct.Credentials.Username)
+		c.collectString(ct.Credentials.Password)
+	}
+	c.collectEnv(ct.Env)
+	c.collectStrings(ct.Ports)
+	c.collectStrings(ct.Volumes)
+	c.collectString(ct.Options)
+}
+
+func (c *exprCollector) collectMatrix(m *Matrix) {
+	c.collectString(m.Expression)
+	for _, r := range m.Rows {
+		c.collectString(r.Expression)
+		for _, v := range r.Values {
+			c.collectRawYAMLValue(v)
+		}
+	}
+	for _, cs := range []*MatrixCombinations{m.Include, m.Exclude} {
+		if cs == nil {
+			continue
+		}
+		c.collectString(cs.Expression)
+		for _, cb := range cs.Combinations {
+			c.collectString(cb.Expression)
+			for _, a := range cb.Assigns {
+				c.collectRawYAMLValue(a.Value)
+			}
+		}
+	}
+}
+
+func (c *exprCollector) collectRawYAMLValue(v RawYAMLValue) {
+	switch v := v.(type) {
+	case *RawYAMLObject:
+		for _, p := range v.Props {
+			c.collectRawYAMLValue(p)
+		}
+	case *RawYAMLArray:
+		for _, e := range v.Elems {
+			c.collectRawYAMLValue(e)
+		}
+	case *RawYAMLString:
+		c.collectExprsIn(v.Value, v.Pos())
+	}
+}
+
+func (c *exprCollector) collectString(s *String) {
+	if s == nil {
+		return
+	}
+	c.collectExprsIn(s.Value, s.Pos)
+}
+
+// collectIfCondition collects expressions in the if: condition. The condition may not be enclosed
+// with ${{ }}.
+func (c *exprCollector) collectIfCondition(s *String) {
+	if s == nil {
+		return
+	}
+	if s.ContainsExpression() {
+		c.collectString(s)
+		return
+	}
+	// Parse errors are reported by "expression" rule
+	if expr, err := NewExprParser().Parse(NewExprLexer(s.Value + "}}")); err == nil {
+		c.visit(expr, s.Pos)
+	}
+}
+
+func (c *exprCollector) collectExprsIn(s string, pos *Pos) {
+	for {
+		idx := strings.Index(s, "${{")
+		if idx == -1 {
+			return
+		}
+		s = s[idx+3:] // 3 means removing "${{"
+
+		l := NewExprLexer(s)
+		expr, err := NewExprParser().Parse(l)
+		if err != nil {
+			return // Parse errors are reported by "expression" rule
+		}
+		c.visit(expr, pos)
+		s = s[l.Offset():]
+	}
+}
@@ -76,6 +76,56 @@ func (ms UntrustedInputSearchRoots) AddRoot(m *UntrustedInputMap) {
 	ms[m.Name] = m
 }
 
+// AddPath adds the path to untrusted input such as "github.event.workflow_run.head_branch" to the
+// search tree. "*" in the path means any element of array like "github.event.commits.*.message".
+// Property names are case-insensitive. When the path or its ancestor is already an untrusted input,
+// or the path is an ancestor of other untrusted inputs, this method does nothing. Note that the tree
+// is modified in place so copy it with Copy method in advance when it is shared like
+// BuiltinUntrustedInputs.
+func (ms UntrustedInputSearchRoots) AddPath(path string) {
+	names := strings.Split(strings.ToLower(path), ".")
+	m, existed := ms[names[0]]
+	if !existed {
+		m = NewUntrustedInputMap(names[0])
+		ms.AddRoot(m)
+	}
+	for _, n := range names[1:] {
+		if m.Children == nil {
+			if existed {
+				return // The ancestor is already untrusted
+			}
+			m.Children = map[string]*UntrustedInputMap{}
+		}
+		c, ok := m.Children[n]
+		if !ok {
+			c = NewUntrustedInputMap(n)
+			c.Parent = m
+			m.Children[n] = c
+		}
+		m, existed = c, ok
+	}
+}
+
+// Copy returns a deep copy of the search roots.
+func (ms UntrustedInputSearchRoots) Copy() UntrustedInputSearchRoots {
+	ret := make(UntrustedInputSearchRoots, len(ms))
+	for n, m := range ms {
+		ret[n] = m.copy(nil)
+	}
+	return ret
+}
+
+func (m *UntrustedInputMap) copy(parent *UntrustedInputMap) *UntrustedInputMap {
+	ret := &UntrustedInputMap{Name: m.Name, Parent: parent}
+	if m.Children != nil {
+		ret.Children = make(map[string]*UntrustedInputMap, len(m.Children))
+		for n, c := range m.Children {
+			ret.Children[n] = c.copy(ret)
+		}
+	}
+	return ret
+}
+
 // TODO: Automatically generate BuiltinUntrustedInputs from https://github.com/github/codeql/blob/main/javascript/ql/src/experimental/Security/CWE-094/ExpressionInjection.ql
 
 // BuiltinUntrustedInputs is list of untrusted inputs. These inputs are detected as untrusted in
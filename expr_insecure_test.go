@@ -430,6 +430,66 @@ func TestExprInsecureCustomizedUntrustedInputMapping(t *testing.T) {
 	}
 }
 
+func TestExprInsecureAddUntrustedInputPath(t *testing.T) {
+	roots := BuiltinUntrustedInputs.Copy()
+	for _, p := range []string{
+		"github.event.workflow_run.head_branch",
+		"github.event.Workflow_Run.head_commit.message",
+		"github.event.issue.title.foo",   // Ancestor is already untrusted
+		"github.event.pull_request.head", // Ancestor of other untrusted inputs
+		"inputs.title",
+		"github.event.commits.*.committer.name",
+	} {
+		roots.AddPath(p)
+	}
+
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{"github.event.workflow_run.head_branch", `"github.event.workflow_run.head_branch"`},
+		{"github.event.workflow_run.head_commit.message", `"github.event.workflow_run.head_commit.message"`},
+		{"github.event.issue.title", `"github.event.issue.title"`},
+		{"inputs.title", `"inputs.title"`},
+		{"github.event.commits[0].committer.name", `"github.event.commits.*.committer.name"`},
+		{"github.event.pull_request.head.ref", `"github.event.pull_request.head.ref"`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			c := NewUntrustedInputChecker(roots)
+			testRunTrustedInputsCheckerForNode(t, c, tc.input)
+			errs := c.Errs()
+			if len(errs) != 1 {
+				t.Fatalf("1 error was wanted but got %d error(s)", len(errs))
+			}
+			if err := errs[0]; !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("%q was wanted to be contained in error message %q", tc.want, err.Error())
+			}
+		})
+	}
+
+	for _, input := range []string{
+		"github.event.workflow_run.head_commit.id",
+		"github.event.pull_request.head.sha",
+		"inputs.body",
+	} {
+		t.Run(input, func(t *testing.T) {
+			c := NewUntrustedInputChecker(roots)
+			testRunTrustedInputsCheckerForNode(t, c, input)
+			if errs := c.Errs(); len(errs) > 0 {
+				t.Fatalf("no error was wanted but got %v", errs)
+			}
+		})
+	}
+
+	// Built-in untrusted inputs are not modified
+	if _, ok := BuiltinUntrustedInputs["inputs"]; ok {
+		t.Fatal("BuiltinUntrustedInputs was modified")
+	}
+	if _, ok := BuiltinUntrustedInputs["github"].Children["event"].Children["workflow_run"]; ok {
+		t.Fatal("BuiltinUntrustedInputs was modified")
+	}
+}
+
 func TestExprInsecureDetectUntrustedObjectFiltering(t *testing.T) {
 	tests := []struct {
 		input    string
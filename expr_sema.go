@@ -194,6 +194,18 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 		Ret:    BoolType{},
 		Params: []ExprType{},
 	}},
+	// case(pred1, val1, pred2, val2, ..., default) returns the value of the first true predicate.
+	// The number of arguments is checked in checkBuiltinFuncCall.
+	"case": {{
+		Name: "case",
+		Ret:  AnyType{},
+		Params: []ExprType{
+			BoolType{},
+			AnyType{},
+			AnyType{},
+		},
+		VariableLengthParams: true,
+	}},
 }
 
 // Global variables
@@ -313,8 +325,10 @@ type ExprSemanticsChecker struct {
 	githubVarCopied       bool
 	untrusted             *UntrustedInputChecker
 	availableContexts     []string
+	contextsRestricted    bool
 	availableSpecialFuncs []string
 	configVars            []string
+	eventNames            []string
 }
 
 // NewExprSemanticsChecker creates new ExprSemanticsChecker instance. When checkUntrustedInput is
@@ -407,6 +421,7 @@ func (sema *ExprSemanticsChecker) UpdateSecrets(ty *ObjectType) {
 	for n, v := range ty.Props {
 		copied.Props[n] = v
 	}
+	copied.Mapped = ty.Mapped // Undeclared secrets may be allowed. e.g. `secrets: inherit`
 	sema.vars["secrets"] = copied
 }
 
@@ -448,6 +463,24 @@ func (sema *ExprSemanticsChecker) UpdateJobs(ty *ObjectType) {
 	sema.vars["jobs"] = ty
 }
 
+// SetEventNames sets names of events which can trigger the expression. Properties of `github.event`
+// object which are specific to other events are reported as errors. Usually the event names are
+// narrowed by `github.event_name` condition at `if:` like `if: github.event_name == 'push'`.
+//
+// Elements of 'names' parameter must be in lower case. nil means that any event can trigger the
+// expression, which is the default.
+func (sema *ExprSemanticsChecker) SetEventNames(names []string) {
+	sema.eventNames = names
+}
+
+// SetUntrustedInputs sets the search tree of untrusted inputs instead of BuiltinUntrustedInputs.
+// This method does nothing when the checker was created without checking untrusted inputs.
+func (sema *ExprSemanticsChecker) SetUntrustedInputs(roots UntrustedInputSearchRoots) {
+	if sema.untrusted != nil {
+		sema.untrusted = NewUntrustedInputChecker(roots)
+	}
+}
+
 // SetContextAvailability sets available context names while semantics checks. Some contexts limit
 // where they can be used.
 // https://docs.github.com/en/actions/learn-github-actions/contexts#context-availability
@@ -459,15 +492,31 @@ func (sema *ExprSemanticsChecker) UpdateJobs(ty *ObjectType) {
 // Available contexts for workflow keys can be obtained from actionlint.ContextAvailability.
 func (sema *ExprSemanticsChecker) SetContextAvailability(avail []string) {
 	sema.availableContexts = avail
+	sema.contextsRestricted = true
 }
 
-func (sema *ExprSemanticsChecker) checkAvailableContext(n *VariableNode) {
-	ctx := strings.ToLower(n.Name)
+// isContextAvailable returns true when the context is available at the expression being checked.
+// All contexts are available when SetContextAvailability was not called.
+func (sema *ExprSemanticsChecker) isContextAvailable(name string) bool {
+	if !sema.contextsRestricted {
+		return true
+	}
+	name = strings.ToLower(name)
 	for _, c := range sema.availableContexts {
-		if c == ctx {
-			return
+		if c == name {
+			return true
 		}
 	}
+	return false
+}
+
+// checkAvailableContext reports the context which is not available at the position of the
+// expression such as "steps" context at job-level "if:". It returns false when the error was
+// reported.
+func (sema *ExprSemanticsChecker) checkAvailableContext(n *VariableNode) bool {
+	if sema.isContextAvailable(n.Name) {
+		return true
+	}
 
 	var notes string
 	switch len(sema.availableContexts) {
@@ -484,6 +533,7 @@ func (sema *ExprSemanticsChecker) checkAvailableContext(n *VariableNode) {
 		n.Name,
 		notes,
 	)
+	return false
 }
 
 // SetSpecialFunctionAvailability sets names of available special functions while semantics checks.
@@ -539,15 +589,27 @@ func (sema *ExprSemanticsChecker) visitUntrustedCheckerOnLeaveNode(n ExprNode) {
 func (sema *ExprSemanticsChecker) checkVariable(n *VariableNode) ExprType {
 	v, ok := sema.vars[n.Name]
 	if !ok {
+		// Show only variables available at the position
 		ss := make([]string, 0, len(sema.vars))
-		for n := range sema.vars {
-			ss = append(ss, n)
+		for v := range sema.vars {
+			if sema.isContextAvailable(v) {
+				ss = append(ss, v)
+			}
 		}
-		sema.errorf(n, "undefined variable %q. available variables are %s", n.Token().Value, sortedQuotes(ss))
+		name := n.Token().Value
+		if len(ss) == 0 {
+			sema.errorf(n, "undefined variable %q. no variable is available here", name)
+			return AnyType{}
+		}
+		sema.errorf(n, "undefined variable %q.%s available variables are %s", name, didYouMean(name, ss), sortedQuotes(ss))
 		return AnyType{}
 	}
 
-	sema.checkAvailableContext(n)
+	if !sema.checkAvailableContext(n) {
+		// The value of the context is not known at the position. Avoid reporting confusing errors
+		// such as undefined properties caused by the unavailable context
+		return AnyType{}
+	}
 	return v
 }
 
@@ -556,6 +618,9 @@ func (sema *ExprSemanticsChecker) checkObjectDeref(n *ObjectDerefNode) ExprType
 	case AnyType:
 		return AnyType{}
 	case *ObjectType:
+		if sema.eventNames != nil && isGithubEventNode(n.Receiver) {
+			sema.checkEventPayloadProperty(n)
+		}
 		if t, ok := ty.Props[n.Property]; ok {
 			return t
 		}
@@ -566,6 +631,12 @@ func (sema *ExprSemanticsChecker) checkObjectDeref(n *ObjectDerefNode) ExprType
 			return ty.Mapped
 		}
 		if ty.IsStrict() {
+			if sema.checkUndefinedNeedsProp(n, n.Receiver, n.Property, ty) {
+				return AnyType{}
+			}
+			if sema.checkUndefinedMatrixProp(n, n.Receiver, n.Property, ty) {
+				return AnyType{}
+			}
 			sema.errorf(n, "property %q is not defined in object type %s", n.Property, ty.String())
 		}
 		return AnyType{}
@@ -604,6 +675,228 @@ func (sema *ExprSemanticsChecker) checkObjectDeref(n *ObjectDerefNode) ExprType
 	}
 }
 
+// eventPayloadProperties is a map from property names of `github.event` object to events whose
+// payloads have the properties. Only properties which are specific to some events are listed.
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads
+var eventPayloadProperties = map[string][]string{
+	"base_ref":          {"push"},
+	"check_run":         {"check_run"},
+	"check_suite":       {"check_suite"},
+	"comment":           {"commit_comment", "discussion_comment", "issue_comment", "pull_request_review_comment"},
+	"commits":           {"push"},
+	"compare":           {"push"},
+	"deployment":        {"deployment", "deployment_status"},
+	"deployment_status": {"deployment_status"},
+	"discussion":        {"discussion", "discussion_comment"},
+	"forced":            {"push"},
+	"forkee":            {"fork"},
+	"head_commit":       {"push"},
+	"issue":             {"issue_comment", "issues"},
+	"merge_group":       {"merge_group"},
+	"number":            {"pull_request", "pull_request_target"},
+	"pages":             {"gollum"},
+	"pull_request":      {"pull_request", "pull_request_review", "pull_request_review_comment", "pull_request_target"},
+	"pusher":            {"push"},
+	"ref_type":          {"create", "delete"},
+	"registry_package":  {"registry_package"},
+	"release":           {"release"},
+	"review":            {"pull_request_review"},
+	"schedule":          {"schedule"},
+	"workflow_run":      {"workflow_run"},
+}
+
+func isGithubEventNode(n ExprNode) bool {
+	d, ok := n.(*ObjectDerefNode)
+	if !ok || d.Property != "event" {
+		return false
+	}
+	v, ok := d.Receiver.(*VariableNode)
+	return ok && v.Name == "github"
+}
+
+func (sema *ExprSemanticsChecker) checkEventPayloadProperty(n *ObjectDerefNode) {
+	events, ok := eventPayloadProperties[n.Property]
+	if !ok {
+		return
+	}
+	for _, e := range sema.eventNames {
+		for _, a := range events {
+			if e == a {
+				return
+			}
+		}
+	}
+	e := "events"
+	if len(events) == 1 {
+		e = "event"
+	}
+	sema.errorf(
+		n,
+		"property %q of \"github.event\" object is not available since the event is narrowed to %s by \"github.event_name\" condition. it is only available for %s %s",
+		n.Property,
+		quotes(sema.eventNames),
+		quotes(events),
+		e,
+	)
+}
+
+// eventNamesGuardedBy returns event names in lower case when the given expression is true only on
+// the events such as `github.event_name == 'push'`. It returns nil when the expression does not
+// narrow the events.
+func eventNamesGuardedBy(n ExprNode) []string {
+	switch n := n.(type) {
+	case *CompareOpNode:
+		if n.Kind != CompareOpNodeKindEq {
+			return nil
+		}
+		l, r := n.Left, n.Right
+		if _, ok := l.(*StringNode); ok {
+			l, r = r, l
+		}
+		d, ok := l.(*ObjectDerefNode)
+		if !ok || d.Property != "event_name" {
+			return nil
+		}
+		if v, ok := d.Receiver.(*VariableNode); !ok || v.Name != "github" {
+			return nil
+		}
+		if s, ok := r.(*StringNode); ok {
+			return []string{strings.ToLower(s.Value)}
+		}
+	case *LogicalOpNode:
+		l, r := eventNamesGuardedBy(n.Left), eventNamesGuardedBy(n.Right)
+		switch n.Kind {
+		case LogicalOpNodeKindAnd:
+			// `github.event_name == 'push' && cond` is true only on push event
+			if l == nil {
+				return r
+			}
+			if r == nil {
+				return l
+			}
+			return intersectEventNames(l, r)
+		case LogicalOpNodeKindOr:
+			// `github.event_name == 'push' || cond` may be true on any event
+			if l == nil || r == nil {
+				return nil
+			}
+			return append(l, r...)
+		}
+	}
+	return nil
+}
+
+// intersectEventNames returns event names included in both a and b. When no event name is included
+// in both, the expression is never evaluated. In the case, it returns b not to report confusing
+// errors.
+func intersectEventNames(a, b []string) []string {
+	if a == nil {
+		return b
+	}
+	ret := []string{}
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				ret = append(ret, x)
+				break
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return b
+	}
+	return ret
+}
+
+// checkGuarded checks the expression assuming it is evaluated only when the guard expression is true.
+// For example, `r` in `l && r` is evaluated only when `l` is true.
+func (sema *ExprSemanticsChecker) checkGuarded(n, guard ExprNode) ExprType {
+	names := eventNamesGuardedBy(guard)
+	if names == nil {
+		return sema.check(n)
+	}
+	saved := sema.eventNames
+	sema.eventNames = intersectEventNames(saved, names)
+	ty := sema.check(n)
+	sema.eventNames = saved
+	return ty
+}
+
+// needsJobOf returns the job ID when the node accesses the job in `needs` context like `needs.build`
+// or `needs['build']`.
+func needsJobOf(n ExprNode) (string, bool) {
+	switch n := n.(type) {
+	case *ObjectDerefNode:
+		if v, ok := n.Receiver.(*VariableNode); ok && v.Name == "needs" {
+			return n.Property, true
+		}
+	case *IndexAccessNode:
+		if v, ok := n.Operand.(*VariableNode); ok && v.Name == "needs" {
+			if lit, ok := n.Index.(*StringNode); ok {
+				return lit.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// checkUndefinedNeedsProp reports the undefined property of `needs` context with more precise
+// message than the general one for object types. `needs` context only has jobs listed at `needs:`
+// and their outputs are defined at `outputs:` of the jobs. It returns true when the error was
+// reported.
+func (sema *ExprSemanticsChecker) checkUndefinedNeedsProp(n, recv ExprNode, prop string, ty *ObjectType) bool {
+	props := make([]string, 0, len(ty.Props))
+	for p := range ty.Props {
+		props = append(props, p)
+	}
+
+	if v, ok := recv.(*VariableNode); ok && v.Name == "needs" {
+		if len(props) == 0 {
+			sema.errorf(n, "job %q is not available in \"needs\" context because no job is listed at \"needs:\" of this job. add the job to \"needs:\" to use its result and outputs", prop)
+		} else {
+			sema.errorf(n, "job %q is not listed at \"needs:\" of this job so it is not available in \"needs\" context. available jobs are %s", prop, sortedQuotes(props))
+		}
+		return true
+	}
+
+	d, ok := recv.(*ObjectDerefNode)
+	if !ok || d.Property != "outputs" {
+		return false
+	}
+	id, ok := needsJobOf(d.Receiver)
+	if !ok {
+		return false
+	}
+	if len(props) == 0 {
+		sema.errorf(n, "output %q is not defined in job %q since the job has no output", prop, id)
+	} else {
+		sema.errorf(n, "output %q is not defined in job %q. available outputs are %s", prop, id, sortedQuotes(props))
+	}
+	return true
+}
+
+// checkUndefinedMatrixProp reports the undefined property of `matrix` context with more precise
+// message than the general one for object types. `matrix` context only has the keys defined at
+// `strategy.matrix:` of the job including the keys added by `include:`. It returns true when the
+// error was reported.
+func (sema *ExprSemanticsChecker) checkUndefinedMatrixProp(n, recv ExprNode, prop string, ty *ObjectType) bool {
+	if v, ok := recv.(*VariableNode); !ok || v.Name != "matrix" {
+		return false
+	}
+
+	if len(ty.Props) == 0 {
+		sema.errorf(n, "matrix key %q is not defined because no matrix is defined at \"strategy.matrix:\" of this job", prop)
+		return true
+	}
+
+	keys := make([]string, 0, len(ty.Props))
+	for k := range ty.Props {
+		keys = append(keys, k)
+	}
+	sema.errorf(n, "matrix key %q is not defined at \"strategy.matrix:\" of this job.%s available matrix keys are %s", prop, didYouMean(prop, keys), sortedQuotes(keys))
+	return true
+}
+
 func (sema *ExprSemanticsChecker) checkConfigVariables(n *ObjectDerefNode) {
 	// https://docs.github.com/en/actions/learn-github-actions/variables#naming-conventions-for-configuration-variables
 	if strings.HasPrefix(n.Property, "github_") {
@@ -648,8 +941,9 @@ func (sema *ExprSemanticsChecker) checkConfigVariables(n *ObjectDerefNode) {
 
 	sema.errorf(
 		n,
-		"undefined configuration variable %q. defined configuration variables in actionlint.yaml are %s",
+		"undefined configuration variable %q.%s defined configuration variables in actionlint.yaml are %s",
 		n.Property,
+		didYouMean(n.Property, sema.configVars),
 		sortedQuotes(sema.configVars),
 	)
 }
@@ -728,7 +1022,7 @@ func (sema *ExprSemanticsChecker) checkIndexAccess(n *IndexAccessNode) ExprType
 				if ty.Mapped != nil {
 					return ty.Mapped
 				}
-				if ty.IsStrict() {
+				if ty.IsStrict() && !sema.checkUndefinedNeedsProp(n, n.Operand, lit.Value, ty) {
 					sema.errorf(n, "property %q is not defined in object type %s", lit.Value, ty.String())
 				}
 			}
@@ -799,7 +1093,7 @@ func checkFuncSignature(n *FuncCallNode, sig *FuncSignature, args []ExprType) *E
 	return nil
 }
 
-func (sema *ExprSemanticsChecker) checkBuiltinFuncCall(n *FuncCallNode, sig *FuncSignature) ExprType {
+func (sema *ExprSemanticsChecker) checkBuiltinFuncCall(n *FuncCallNode, sig *FuncSignature, args []ExprType) ExprType {
 	sema.checkSpecialFunctionAvailability(n)
 
 	// Special checks for specific built-in functions
@@ -837,6 +1131,48 @@ func (sema *ExprSemanticsChecker) checkBuiltinFuncCall(n *FuncCallNode, sig *Fun
 		if s, ok := err.(*json.SyntaxError); ok {
 			sema.errorf(lit, "broken JSON string is passed to fromJSON() at offset %d: %s", s.Offset, s)
 		}
+	case "hashfiles":
+		for i, a := range n.Args {
+			// Number is assignable to string, but it is never a meaningful glob pattern
+			if _, ok := args[i].(NumberType); ok {
+				sema.errorf(a, "%s argument of hashFiles() must be a string of glob pattern but \"number\" is given", ordinal(i+1))
+				continue
+			}
+			lit, ok := a.(*StringNode)
+			if !ok {
+				continue
+			}
+			// Patterns starting with '!' exclude the matched files
+			pat := strings.TrimPrefix(lit.Value, "!")
+			if pat == "" {
+				sema.errorf(lit, "%s argument of hashFiles() is empty glob pattern %q", ordinal(i+1), lit.Value)
+				continue
+			}
+			for _, err := range ValidatePathGlob(pat) {
+				sema.errorf(lit, "%s argument %q of hashFiles() is broken: %s", ordinal(i+1), lit.Value, err.Message)
+			}
+		}
+	case "case":
+		l := len(args)
+		if l%2 == 0 {
+			sema.errorf(n, "case() takes pairs of predicate and value followed by default value like case(pred1, val1, pred2, val2, default) so the number of arguments must be odd but %d arguments are given", l)
+			return sig.Ret
+		}
+		// Predicates at odd positions must be bool. Any type is assignable to bool parameter so the
+		// signature cannot check them
+		for i := 0; i < l-1; i += 2 {
+			switch args[i].(type) {
+			case BoolType, AnyType:
+			default:
+				sema.errorf(n.Args[i], "%s argument of case() is predicate so it must be \"bool\" but %q is given", ordinal(i+1), args[i].String())
+			}
+		}
+		// The result is one of the values or the default value
+		ret := args[l-1]
+		for i := 1; i < l-1; i += 2 {
+			ret = ret.Merge(args[i])
+		}
+		return ret
 	}
 
 	return sig.Ret
@@ -866,7 +1202,7 @@ func (sema *ExprSemanticsChecker) checkFuncCall(n *FuncCallNode) ExprType {
 		err := checkFuncSignature(n, sig, tys)
 		if err == nil {
 			// When one of overload pass type check, overload was resolved correctly
-			return sema.checkBuiltinFuncCall(n, sig)
+			return sema.checkBuiltinFuncCall(n, sig, tys)
 		}
 		errs = append(errs, err)
 	}
@@ -964,7 +1300,7 @@ func (sema *ExprSemanticsChecker) checkWithNarrowing(n ExprNode, isTruthy bool)
 			// When `l && r` is true, narrow its type to `typeof(r)`
 			if isTruthy {
 				sema.check(n.Left)
-				return sema.check(n.Right)
+				return sema.checkGuarded(n.Right, n.Left)
 			}
 		case LogicalOpNodeKindOr:
 			// When `l || r` is false, narrow its type to `typeof(r)`
@@ -986,7 +1322,7 @@ func (sema *ExprSemanticsChecker) checkLogicalOp(n *LogicalOpNode) ExprType {
 	case LogicalOpNodeKindAnd:
 		// When `l` is false in `l && r`, its type is `typeof(l)`. Otherwise `typeof(r)`.
 		// Narrow the type of LHS expression by assuming its value is falsy.
-		return sema.checkWithNarrowing(n.Left, false).Merge(sema.check(n.Right))
+		return sema.checkWithNarrowing(n.Left, false).Merge(sema.checkGuarded(n.Right, n.Left))
 	case LogicalOpNodeKindOr:
 		// When `l` is true in `l || r`, its type is `typeof(l)`. Otherwise `typeof(r).
 		// Narrow the type of LHS expression by assuming its value is truthy.
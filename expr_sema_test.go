@@ -24,6 +24,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 		availContexts []string
 		availSPFuncs  []string
 		configVars    []string
+		events        []string
 	}{
 		{
 			what:     "null",
@@ -647,6 +648,26 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			expected:     StringType{},
 			availSPFuncs: []string{"hashfiles"},
 		},
+		{
+			what:     "glob patterns at hashFiles arguments",
+			input:    "hashFiles('**/go.sum', '!vendor/**', format('{0}/*.lock', env.DIR))",
+			expected: StringType{},
+		},
+		{
+			what:     "case function",
+			input:    "case(github.event_name == 'push', 'production', 'development')",
+			expected: StringType{},
+		},
+		{
+			what:     "case function with multiple predicates",
+			input:    "case(github.run_attempt == '1', 10, github.run_attempt == '2', 20, 30)",
+			expected: NumberType{},
+		},
+		{
+			what:     "case function with values of different types",
+			input:    "case(true, fromJSON('{\"a\": 1}'), fromJSON('{\"b\": \"x\"}'))",
+			expected: NewStrictObjectType(map[string]ExprType{"a": NumberType{}, "b": StringType{}}),
+		},
 		{
 			what:     "configuration variable",
 			input:    "vars.SOME_VARIABLE",
@@ -738,6 +759,28 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"piyo": NullType{},
 			}),
 		},
+		{
+			what:     "property of github.event available for narrowed event",
+			input:    "github.event.pull_request",
+			expected: AnyType{},
+			events:   []string{"push", "pull_request"},
+		},
+		{
+			what:     "property of github.event narrowed by event_name in logical and",
+			input:    "github.event_name == 'push' && github.event.head_commit",
+			expected: AnyType{},
+		},
+		{
+			what:     "property of github.event not narrowed by event_name in logical or",
+			input:    "github.event_name == 'push' || github.event.pull_request",
+			expected: AnyType{},
+		},
+		{
+			what:     "property of github.event for any events",
+			input:    "github.event.repository",
+			expected: AnyType{},
+			events:   []string{"push"},
+		},
 	}
 
 	allSPFuncs := []string{}
@@ -776,6 +819,9 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			if tc.jobs != nil {
 				c.UpdateJobs(tc.jobs)
 			}
+			if tc.events != nil {
+				c.SetEventNames(tc.events)
+			}
 			if len(tc.availContexts) > 0 {
 				c.SetContextAvailability(tc.availContexts)
 			}
@@ -808,6 +854,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 		availCtx   []string
 		availSP    []string
 		configVars []string
+		events     []string
 	}{
 		{
 			what:  "undefined variable",
@@ -971,6 +1018,34 @@ func TestExprSemanticsCheckError(t *testing.T) {
 				"number of arguments is wrong. function \"hashFiles(string...) -> string\" takes at least 1 parameters but 0 arguments are given",
 			},
 		},
+		{
+			what:  "too few arguments at case function",
+			input: "case(true, 'foo')",
+			expected: []string{
+				"number of arguments is wrong. function \"case(bool, any, any...) -> any\" takes at least 3 parameters but 2 arguments are given",
+			},
+		},
+		{
+			what:  "even number of arguments at case function",
+			input: "case(true, 'foo', false, 'bar')",
+			expected: []string{
+				"case() takes pairs of predicate and value followed by default value like case(pred1, val1, pred2, val2, default) so the number of arguments must be odd but 4 arguments are given",
+			},
+		},
+		{
+			what:  "predicate of case function is not bool",
+			input: "case(github.event_name, 'foo', 'bar')",
+			expected: []string{
+				"1st argument of case() is predicate so it must be \"bool\" but \"string\" is given",
+			},
+		},
+		{
+			what:  "second predicate of case function is not bool",
+			input: "case(true, 'foo', github.run_attempt, 'bar', 'baz')",
+			expected: []string{
+				"3rd argument of case() is predicate so it must be \"bool\" but \"string\" is given",
+			},
+		},
 		{
 			what:  "wrong type at parameter",
 			input: "startsWith('foo', null)",
@@ -1061,12 +1136,23 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			what:  "undefined matrix value",
 			input: "matrix.bar",
 			expected: []string{
-				"property \"bar\" is not defined in object type {foo: any}",
+				"matrix key \"bar\" is not defined at \"strategy.matrix:\" of this job. available matrix keys are \"foo\"",
 			},
 			matrix: NewStrictObjectType(map[string]ExprType{
 				"foo": AnyType{},
 			}),
 		},
+		{
+			what:  "typo of matrix key",
+			input: "matrix.platfrom",
+			expected: []string{
+				"matrix key \"platfrom\" is not defined at \"strategy.matrix:\" of this job. did you mean \"platform\"? available matrix keys are \"arch\", \"platform\"",
+			},
+			matrix: NewStrictObjectType(map[string]ExprType{
+				"arch":     StringType{},
+				"platform": StringType{},
+			}),
+		},
 		{
 			what:  "type mismatch in matrix value",
 			input: "startsWith('hello', matrix.foo)",
@@ -1081,7 +1167,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			what:  "matrix value with untyped matrix values",
 			input: "matrix.foooo",
 			expected: []string{
-				"property \"foooo\" is not defined in object type {}",
+				"matrix key \"foooo\" is not defined because no matrix is defined at \"strategy.matrix:\" of this job",
 			},
 		},
 		{
@@ -1123,7 +1209,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			what:  "undefined job id in needs context",
 			input: "needs.bar",
 			expected: []string{
-				"property \"bar\" is not defined in object type ",
+				"job \"bar\" is not listed at \"needs:\" of this job so it is not available in \"needs\" context. available jobs are \"foo\"",
 			},
 			needs: NewStrictObjectType(map[string]ExprType{
 				"foo": NewStrictObjectType(map[string]ExprType{
@@ -1139,7 +1225,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			what:  "undefined output in needs context",
 			input: "needs.foo.outputs.out3",
 			expected: []string{
-				"property \"out3\" is not defined in object type ",
+				"output \"out3\" is not defined in job \"foo\". available outputs are \"out1\", \"out2\"",
 			},
 			needs: NewStrictObjectType(map[string]ExprType{
 				"foo": NewStrictObjectType(map[string]ExprType{
@@ -1151,6 +1237,19 @@ func TestExprSemanticsCheckError(t *testing.T) {
 				}),
 			}),
 		},
+		{
+			what:  "undefined output in needs context with index access",
+			input: "needs['foo'].outputs['out3']",
+			expected: []string{
+				"output \"out3\" is not defined in job \"foo\" since the job has no output",
+			},
+			needs: NewStrictObjectType(map[string]ExprType{
+				"foo": NewStrictObjectType(map[string]ExprType{
+					"outputs": NewEmptyStrictObjectType(),
+					"result":  StringType{},
+				}),
+			}),
+		},
 		{
 			what:  "undefined prop in needs context",
 			input: "needs.foo.bar",
@@ -1171,7 +1270,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			what:  "undefined prop in untyped needs context",
 			input: "needs.foo",
 			expected: []string{
-				"property \"foo\" is not defined in object type ",
+				"job \"foo\" is not available in \"needs\" context because no job is listed at \"needs:\"",
 			},
 		},
 		{
@@ -1219,6 +1318,30 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			},
 			availCtx: []string{},
 		},
+		{
+			what:  "properties of unavailable context are not checked",
+			input: "steps.check.outputs.ok",
+			expected: []string{
+				"context \"steps\" is not allowed here. available contexts are \"github\", \"needs\"",
+			},
+			availCtx: []string{"github", "needs"},
+		},
+		{
+			what:  "undefined variable with available contexts",
+			input: "envv.FOO",
+			expected: []string{
+				"undefined variable \"envv\". did you mean \"env\"? available variables are \"env\", \"github\"",
+			},
+			availCtx: []string{"github", "env"},
+		},
+		{
+			what:  "undefined variable without available context",
+			input: "foo",
+			expected: []string{
+				"undefined variable \"foo\". no variable is available here",
+			},
+			availCtx: []string{},
+		},
 		{
 			what:  "no special function allowed",
 			input: "success()",
@@ -1273,6 +1396,27 @@ func TestExprSemanticsCheckError(t *testing.T) {
 				"must not start with the GITHUB_ prefix",
 			},
 		},
+		{
+			what:  "number argument at hashFiles",
+			input: "hashFiles('**/go.sum', 42)",
+			expected: []string{
+				"2nd argument of hashFiles() must be a string of glob pattern but \"number\" is given",
+			},
+		},
+		{
+			what:  "invalid glob pattern at hashFiles argument",
+			input: "hashFiles('**/package-lock.json', 'foo/[a-')",
+			expected: []string{
+				"2nd argument \"foo/[a-\" of hashFiles() is broken: invalid glob pattern",
+			},
+		},
+		{
+			what:  "empty glob pattern at hashFiles argument",
+			input: "hashFiles('!')",
+			expected: []string{
+				"1st argument of hashFiles() is empty glob pattern \"!\"",
+			},
+		},
 		{
 			what:  "broken JSON value at fromJSON argument",
 			input: `fromJSON('{"foo": true')`,
@@ -1280,6 +1424,49 @@ func TestExprSemanticsCheckError(t *testing.T) {
 				"broken JSON string is passed to fromJSON() at offset 12",
 			},
 		},
+		{
+			what:  "undefined property of object parsed from JSON constant value",
+			input: `fromJSON('{"foo": true}').bar`,
+			expected: []string{
+				"property \"bar\" is not defined in object type {foo: bool}",
+			},
+		},
+		{
+			what:  "property of element of array parsed from JSON constant value",
+			input: `fromJSON('["foo", "bar"]')[0].length`,
+			expected: []string{
+				"receiver of object dereference \"length\" must be type of object but got \"string\"",
+			},
+		},
+		{
+			what:  "index access to object parsed from JSON constant value with number",
+			input: `fromJSON('{"foo": true}')[0]`,
+			expected: []string{
+				"property access of object must be type of string but got \"number\"",
+			},
+		},
+		{
+			what:  "property of github.event not available for narrowed event",
+			input: "github.event.pull_request.number",
+			expected: []string{
+				"property \"pull_request\" of \"github.event\" object is not available since the event is narrowed to \"push\"",
+			},
+			events: []string{"push"},
+		},
+		{
+			what:  "property of github.event narrowed by event_name in logical and",
+			input: "github.event_name == 'push' && github.event.issue",
+			expected: []string{
+				"property \"issue\" of \"github.event\" object is not available since the event is narrowed to \"push\"",
+			},
+		},
+		{
+			what:  "property of github.event narrowed by multiple event_name conditions",
+			input: "(github.event_name == 'push' || github.event_name == 'issues') && github.event.comment",
+			expected: []string{
+				"property \"comment\" of \"github.event\" object is not available since the event is narrowed to \"push\", \"issues\"",
+			},
+		},
 	}
 
 	allSP := []string{}
@@ -1308,6 +1495,9 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			if tc.needs != nil {
 				c.UpdateNeeds(tc.needs)
 			}
+			if tc.events != nil {
+				c.SetEventNames(tc.events)
+			}
 			if tc.availCtx != nil {
 				c.SetContextAvailability(tc.availCtx)
 			} else {
@@ -0,0 +1,48 @@
+package actionlint
+
+import (
+	"bytes"
+)
+
+// sourceFixer rewrites lines of a workflow source in place. Rules which can fix their errors
+// automatically replace lines through this struct. Only line-wise replacements are supported so that
+// line numbers of the other errors reported for the same source are not changed by the fixes.
+type sourceFixer struct {
+	lines [][]byte
+	fixed int
+}
+
+func newSourceFixer(src []byte) *sourceFixer {
+	return &sourceFixer{lines: bytes.SplitAfter(src, []byte{'\n'})}
+}
+
+// line returns the content of the line at the given 1-based line number without the trailing line
+// break. The second return value is false when the line does not exist.
+func (f *sourceFixer) line(lnum int) (string, bool) {
+	if lnum <= 0 || len(f.lines) < lnum {
+		return "", false
+	}
+	l := f.lines[lnum-1]
+	l = bytes.TrimSuffix(l, []byte{'\n'})
+	l = bytes.TrimSuffix(l, []byte{'\r'})
+	return string(l), true
+}
+
+// replaceLine replaces the content of the line at the given 1-based line number. The old parameter
+// must be the current content of the line without the trailing line break. The line break is
+// preserved. It returns false when the line was not replaced since its content did not match.
+func (f *sourceFixer) replaceLine(lnum int, old, new string) bool {
+	l, ok := f.line(lnum)
+	if !ok || l != old {
+		return false
+	}
+	b := f.lines[lnum-1]
+	f.lines[lnum-1] = append([]byte(new), b[len(l):]...)
+	f.fixed++
+	return true
+}
+
+// source returns the entire source after applying all the fixes.
+func (f *sourceFixer) source() []byte {
+	return bytes.Join(f.lines, nil)
+}
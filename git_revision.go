@@ -0,0 +1,114 @@
+package actionlint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/sys/execabs"
+)
+
+// gitRevision represents a revision in a Git repository. Files at the revision are read from the Git
+// object database with `git` command so that workflow files can be linted without a working tree,
+// such as in a bare repository on a Git server.
+type gitRevision struct {
+	// dir is a directory in the Git repository where `git` command runs.
+	dir string
+	// rev is the revision such as commit SHA, branch name, or tag name.
+	rev string
+	// commit is the commit SHA which the revision points to.
+	commit string
+}
+
+// newGitRevision resolves the revision to a commit in the Git repository at the directory. It returns
+// an error when the revision does not exist or `git` command is not available.
+func newGitRevision(dir, rev string) (*gitRevision, error) {
+	g := &gitRevision{dir: dir, rev: rev}
+	out, err := g.git("rev-parse", "--verify", "--end-of-options", rev+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve revision %q to a commit: %w", rev, err)
+	}
+	g.commit = strings.TrimSpace(string(out))
+	return g, nil
+}
+
+func (g *gitRevision) git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := execabs.Command("git", append([]string{"-C", g.dir, "-c", "core.quotePath=false"}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("`git %s` failed in %q: %s", strings.Join(args, " "), g.dir, msg)
+		}
+		return nil, fmt.Errorf("`git %s` failed in %q: %w", strings.Join(args, " "), g.dir, err)
+	}
+	return out, nil
+}
+
+// listFiles returns the paths of files in the given directories at the revision. The paths are
+// relative to the root of the repository and the separator is always '/'. The paths are sorted.
+func (g *gitRevision) listFiles(dirs ...string) ([]string, error) {
+	out, err := g.git(append([]string{"ls-tree", "-r", "-z", "--name-only", g.commit, "--"}, dirs...)...)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, f := range strings.Split(string(out), "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// workflowFiles returns the paths of YAML workflow files in ".github/workflows" directory at the
+// revision.
+func (g *gitRevision) workflowFiles() ([]string, error) {
+	all, err := g.listFiles(".github/workflows/")
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, f := range all {
+		if strings.HasSuffix(f, ".yml") || strings.HasSuffix(f, ".yaml") {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// readFile reads the content of the file at the revision. The path is relative to the root of the
+// repository.
+func (g *gitRevision) readFile(path string) ([]byte, error) {
+	b, err := g.git("cat-file", "blob", g.commit+":"+path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %q at revision %q: %w", path, g.rev, err)
+	}
+	return b, nil
+}
+
+// config reads the config file ".github/actionlint.yaml" or ".github/actionlint.yml" at the revision.
+// It returns nil when no config file exists at the revision. "labels-files" in the config file is not
+// loaded since the files are read from the working tree.
+func (g *gitRevision) config() (*Config, error) {
+	files, err := g.listFiles(".github/actionlint.yaml", ".github/actionlint.yml")
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	f := files[0] // ".yaml" precedes ".yml" in the sorted paths
+	b, err := g.readFile(f)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ParseConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config file %q at revision %q: %w", f, g.rev, err)
+	}
+	return c, nil
+}
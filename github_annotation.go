@@ -0,0 +1,54 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Escape characters in workflow commands in the same way as @actions/core.
+// https://github.com/actions/toolkit/blob/main/packages/core/src/command.ts
+var (
+	annotationDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	annotationPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// printErrorsInGitHubAnnotation prints the errors as "::error" and "::warning" workflow commands so
+// that they are shown as annotations when actionlint runs on GitHub Actions. The errors are grouped
+// by file with "::group::" and "::endgroup::" commands so that each file is collapsible in the log.
+// The title of each annotation is the rule name.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/workflow-commands-for-github-actions#setting-an-error-message
+func printErrorsInGitHubAnnotation(out io.Writer, errs []*ErrorTemplateFields, rules []*ruleTemplateFields, files []string) error {
+	var b strings.Builder
+	for i, e := range errs {
+		if i == 0 || errs[i-1].Filepath != e.Filepath {
+			if i > 0 {
+				b.WriteString("::endgroup::\n")
+			}
+			fmt.Fprintf(&b, "::group::%s\n", annotationDataEscaper.Replace(e.Filepath))
+		}
+
+		cmd := "error"
+		if e.Severity == SeverityWarning {
+			cmd = "warning"
+		}
+		fmt.Fprintf(
+			&b,
+			"::%s file=%s,line=%d,col=%d,title=%s::%s\n",
+			cmd,
+			annotationPropertyEscaper.Replace(e.Filepath),
+			e.Line,
+			e.Column,
+			annotationPropertyEscaper.Replace(e.Kind),
+			annotationDataEscaper.Replace(e.Message),
+		)
+	}
+	if len(errs) > 0 {
+		b.WriteString("::endgroup::\n")
+	}
+
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return fmt.Errorf("could not write errors as GitHub Actions annotations: %w", err)
+	}
+	return nil
+}
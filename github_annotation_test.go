@@ -0,0 +1,66 @@
+package actionlint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGitHubAnnotationPrintErrors(t *testing.T) {
+	errs := []*ErrorTemplateFields{
+		{
+			Message:  "this is error",
+			Filepath: "a.yaml",
+			Line:     1,
+			Column:   2,
+			Kind:     "expression",
+			Severity: SeverityError,
+		},
+		{
+			Message:  "50% of\r\nlines",
+			Filepath: "a.yaml",
+			Line:     3,
+			Column:   4,
+			Kind:     "shellcheck",
+			Severity: SeverityWarning,
+		},
+		{
+			Message:  "path contains special characters",
+			Filepath: "dir:1/b,c.yaml",
+			Line:     5,
+			Column:   6,
+			Kind:     "syntax-check",
+			Severity: SeverityError,
+		},
+	}
+
+	var b strings.Builder
+	if err := printErrorsInGitHubAnnotation(&b, errs, nil, []string{"a.yaml", "dir:1/b,c.yaml", "d.yaml"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"::group::a.yaml",
+		"::error file=a.yaml,line=1,col=2,title=expression::this is error",
+		"::warning file=a.yaml,line=3,col=4,title=shellcheck::50%25 of%0D%0Alines",
+		"::endgroup::",
+		"::group::dir:1/b,c.yaml",
+		"::error file=dir%3A1/b%2Cc.yaml,line=5,col=6,title=syntax-check::path contains special characters",
+		"::endgroup::",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestGitHubAnnotationPrintNoError(t *testing.T) {
+	var b strings.Builder
+	if err := printErrorsInGitHubAnnotation(&b, nil, nil, []string{"a.yaml"}); err != nil {
+		t.Fatal(err)
+	}
+	if out := b.String(); out != "" {
+		t.Fatalf("nothing should be output but got %q", out)
+	}
+}
@@ -2,6 +2,7 @@ package actionlint
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"text/scanner"
 	"unicode"
@@ -18,6 +19,13 @@ import (
 //   - `man git-check-ref-format` for more details
 //   - \ is invalid character for ref names. it means that \ can be used only for escaping special chars
 
+// Brace expansion like {a,b} and extended globs like @(a|b) are supported by some shells and glob
+// libraries, but not by the filter pattern of GitHub Actions. They are matched literally.
+var (
+	reGlobBraceExpansion = regexp.MustCompile(`\{[^{}]*,[^{}]*\}`)
+	reGlobExtended       = regexp.MustCompile(`[?*+!]\([^()]*\|[^()]*\)|@\([^()]*\)`)
+)
+
 // InvalidGlobPattern is an error on invalid glob pattern.
 type InvalidGlobPattern struct {
 	// Message is a human readable error message.
@@ -202,6 +210,8 @@ func (v *globValidator) validate(pat string) {
 		return
 	}
 
+	v.checkUnsupportedSyntax(pat)
+
 	// Handle first character if necessary
 	switch v.scan.Peek() {
 	case '/':
@@ -223,6 +233,23 @@ func (v *globValidator) validate(pat string) {
 	}
 }
 
+// checkUnsupportedSyntax reports glob syntax which is not supported by GitHub Actions. Since such
+// syntax consists of normal characters in filter patterns, the pattern silently matches nothing.
+func (v *globValidator) checkUnsupportedSyntax(pat string) {
+	if m := reGlobBraceExpansion.FindStringIndex(pat); m != nil {
+		v.errs = append(v.errs, InvalidGlobPattern{
+			fmt.Sprintf("brace expansion %q is not supported in filter pattern. the braces are matched literally. list each pattern separately instead", pat[m[0]:m[1]]),
+			m[0] + 1,
+		})
+	}
+	if m := reGlobExtended.FindStringIndex(pat); m != nil {
+		v.errs = append(v.errs, InvalidGlobPattern{
+			fmt.Sprintf("extended glob %q is not supported in filter pattern. the parentheses are matched literally. list each pattern separately instead", pat[m[0]:m[1]]),
+			m[0] + 1,
+		})
+	}
+}
+
 func validateGlob(pat string, isRef bool) []InvalidGlobPattern {
 	v := globValidator{}
 	v.isRef = isRef
@@ -253,3 +280,52 @@ func ValidatePathGlob(pat string) []InvalidGlobPattern {
 	}
 	return validateGlob(pat, false)
 }
+
+// compilePathGlob converts the file path glob pattern of "paths" and "paths-ignore" filters into
+// a regular expression. The pattern must not start with '!' and should be validated with
+// ValidatePathGlob in advance.
+// https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions#filter-pattern-cheat-sheet
+func compilePathGlob(pat string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteRune('^')
+	rs := []rune(pat)
+	for i := 0; i < len(rs); i++ {
+		switch r := rs[i]; r {
+		case '\\':
+			if i+1 < len(rs) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(string(rs[i])))
+		case '*':
+			if i+1 < len(rs) && rs[i+1] == '*' {
+				i++
+				if i+1 < len(rs) && rs[i+1] == '/' {
+					// "**/" matches to zero or more directories
+					i++
+					b.WriteString(`(?:.*/)?`)
+				} else {
+					b.WriteString(`.*`)
+				}
+			} else {
+				b.WriteString(`[^/]*`)
+			}
+		case '?', '+':
+			// '?' and '+' mean zero or one and one or more preceding characters as well as regular expression
+			b.WriteRune(r)
+		case '[':
+			j := i + 1
+			for j < len(rs) && rs[j] != ']' {
+				j++
+			}
+			if j == len(rs) {
+				return nil, fmt.Errorf("missing ] in glob pattern %q", pat)
+			}
+			b.WriteString(string(rs[i : j+1]))
+			i = j
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteRune('$')
+	return regexp.Compile(b.String())
+}
@@ -54,6 +54,10 @@ func TestValidateGlobOK(t *testing.T) {
 		"*feature",
 		"v2*",
 		"v[12].[0-9]+.[0-9]+",
+		// braces and parentheses without special meaning
+		"foo{bar}",
+		"a(b)",
+		"*(draft)",
 	}
 
 	for _, input := range testCases {
@@ -228,6 +232,21 @@ func TestValidateGlobSyntaxError(t *testing.T) {
 			input:    "!+",
 			expected: "the preceding character must not be special character",
 		},
+		{
+			what:     "brace expansion",
+			input:    "src/{a,b}/**",
+			expected: `brace expansion "{a,b}" is not supported`,
+		},
+		{
+			what:     "extended glob with @",
+			input:    "docs/@(api)/**",
+			expected: `extended glob "@(api)" is not supported`,
+		},
+		{
+			what:     "extended glob with alternatives",
+			input:    "!lib/*(foo|bar)",
+			expected: `extended glob "*(foo|bar)" is not supported`,
+		},
 	}
 
 	for _, kind := range []string{"ref", "path"} {
@@ -411,3 +430,92 @@ func TestValidateGlobQuoteCharacterInErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestCompilePathGlob(t *testing.T) {
+	tests := []struct {
+		pat     string
+		match   []string
+		unmatch []string
+	}{
+		{
+			pat:     "foo.txt",
+			match:   []string{"foo.txt"},
+			unmatch: []string{"foo.txtx", "dir/foo.txt", "fooatxt"},
+		},
+		{
+			pat:     "*.js",
+			match:   []string{"a.js", ".js"},
+			unmatch: []string{"dir/a.js", "a.ts"},
+		},
+		{
+			pat:     "**.js",
+			match:   []string{"a.js", "dir/a.js", "dir/sub/a.js"},
+			unmatch: []string{"a.ts"},
+		},
+		{
+			pat:     "docs/**",
+			match:   []string{"docs/a.md", "docs/sub/a.md"},
+			unmatch: []string{"docs", "doc/a.md", "a/docs/a.md"},
+		},
+		{
+			pat:     "**/README.md",
+			match:   []string{"README.md", "dir/README.md", "dir/sub/README.md"},
+			unmatch: []string{"README.mdx", "dir/NOT_README.md"},
+		},
+		{
+			pat:     "docs/**/*.md",
+			match:   []string{"docs/a.md", "docs/sub/a.md"},
+			unmatch: []string{"docs/a.txt", "a.md"},
+		},
+		{
+			pat:     "foo?.txt",
+			match:   []string{"fo.txt", "foo.txt"},
+			unmatch: []string{"fooo.txt"},
+		},
+		{
+			pat:     "fo+.txt",
+			match:   []string{"fo.txt", "foo.txt", "fooo.txt"},
+			unmatch: []string{"f.txt"},
+		},
+		{
+			pat:     "v[0-9].txt",
+			match:   []string{"v1.txt", "v9.txt"},
+			unmatch: []string{"va.txt", "v10.txt"},
+		},
+		{
+			pat:     `\*.txt`,
+			match:   []string{"*.txt"},
+			unmatch: []string{"a.txt"},
+		},
+		{
+			pat:     "日本語/*.md",
+			match:   []string{"日本語/a.md"},
+			unmatch: []string{"日本/a.md"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pat, func(t *testing.T) {
+			re, err := compilePathGlob(tc.pat)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tc.match {
+				if !re.MatchString(s) {
+					t.Errorf("pattern %q should match to %q (regexp: %s)", tc.pat, s, re)
+				}
+			}
+			for _, s := range tc.unmatch {
+				if re.MatchString(s) {
+					t.Errorf("pattern %q should not match to %q (regexp: %s)", tc.pat, s, re)
+				}
+			}
+		})
+	}
+}
+
+func TestCompilePathGlobError(t *testing.T) {
+	if _, err := compilePathGlob("foo[0-9"); err == nil {
+		t.Fatal("error did not occur")
+	}
+}
@@ -0,0 +1,127 @@
+package actionlint
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ignoreFileName is a name of the file to exclude workflow files from linting. The syntax of the
+// file is the same as .gitignore.
+const ignoreFileName = ".actionlintignore"
+
+type ignoreFilePattern struct {
+	glob    string
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreFile is a parsed .actionlintignore file. The patterns are relative to the directory where
+// the file is put.
+type ignoreFile struct {
+	dir      string
+	patterns []ignoreFilePattern
+}
+
+// parseIgnoreFile parses the content of .actionlintignore in gitignore-style syntax. dir is the
+// directory where the file is put and path is the file path used for error messages.
+// https://git-scm.com/docs/gitignore#_pattern_format
+func parseIgnoreFile(dir, path string, b []byte) (*ignoreFile, error) {
+	f := &ignoreFile{dir: dir}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	lnum := 0
+	for s.Scan() {
+		lnum++
+		l := strings.TrimRight(s.Text(), " \t\r")
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+
+		p := ignoreFilePattern{}
+		if strings.HasPrefix(l, "!") {
+			p.negate = true
+			l = l[1:]
+		} else if strings.HasPrefix(l, `\!`) || strings.HasPrefix(l, `\#`) {
+			l = l[1:]
+		}
+		if strings.HasSuffix(l, "/") {
+			p.dirOnly = true
+			l = strings.TrimRight(l, "/")
+		}
+		if l == "" {
+			continue
+		}
+
+		// Patterns containing a slash except at the end are relative to the directory of the file.
+		// Otherwise they match to file names at any depth.
+		if strings.Contains(l, "/") {
+			l = strings.TrimPrefix(l, "/")
+		} else {
+			l = "**/" + l
+		}
+		if !doublestar.ValidatePattern(l) {
+			return nil, fmt.Errorf("invalid pattern %q at line %d in %q", s.Text(), lnum, path)
+		}
+		p.glob = l
+		f.patterns = append(f.patterns, p)
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("could not read %q: %w", path, err)
+	}
+	return f, nil
+}
+
+// readIgnoreFile reads .actionlintignore in the directory. It returns nil when the file does not
+// exist.
+func readIgnoreFile(dir string) (*ignoreFile, error) {
+	p := filepath.Join(dir, ignoreFileName)
+	b, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read %q: %w", p, err)
+	}
+	return parseIgnoreFile(dir, p, b)
+}
+
+// match checks the file path matches to the patterns. The path must be absolute. The first return
+// value is true when some pattern matched and the second return value is true when the path is
+// ignored. Like .gitignore, the last matching pattern decides whether the path is ignored or not.
+// A pattern matching to a parent directory of the path also matches to the path.
+func (f *ignoreFile) match(path string) (bool, bool) {
+	r, err := filepath.Rel(f.dir, path)
+	if err != nil {
+		return false, false
+	}
+	r = filepath.ToSlash(r)
+	if r == ".." || strings.HasPrefix(r, "../") {
+		return false, false
+	}
+
+	matched, ignored := false, false
+	for _, p := range f.patterns {
+		if p.matches(r) {
+			matched, ignored = true, !p.negate
+		}
+	}
+	return matched, ignored
+}
+
+func (p *ignoreFilePattern) matches(path string) bool {
+	if !p.dirOnly && doublestar.MatchUnvalidated(p.glob, path) {
+		return true
+	}
+	for d := filepath.ToSlash(filepath.Dir(path)); d != "."; d = filepath.ToSlash(filepath.Dir(d)) {
+		if doublestar.MatchUnvalidated(p.glob, d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package actionlint
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreFileMatch(t *testing.T) {
+	src := `
+# Comment line
+legacy-*.yaml
+/.github/workflows/old/
+!legacy-keep.yaml
+generated/
+\#hash.yaml
+`
+	root := filepath.FromSlash("/repo")
+	f, err := parseIgnoreFile(root, ".actionlintignore", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path    string
+		matched bool
+		ignored bool
+	}{
+		{".github/workflows/ci.yaml", false, false},
+		{".github/workflows/legacy-release.yaml", true, true},
+		{"sub/.github/workflows/legacy-release.yaml", true, true},
+		{".github/workflows/legacy-keep.yaml", true, false},
+		{".github/workflows/old/test.yaml", true, true},
+		{".github/workflows/old/nested/test.yaml", true, true},
+		{"sub/.github/workflows/old/test.yaml", false, false},
+		{".github/workflows/generated/test.yaml", true, true},
+		{"generated.yaml", false, false},
+		{".github/workflows/#hash.yaml", true, true},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			m, i := f.match(filepath.Join(root, filepath.FromSlash(tc.path)))
+			if m != tc.matched || i != tc.ignored {
+				t.Fatalf("wanted matched=%v and ignored=%v but got matched=%v and ignored=%v", tc.matched, tc.ignored, m, i)
+			}
+		})
+	}
+
+	if m, _ := f.match(filepath.FromSlash("/other/legacy-foo.yaml")); m {
+		t.Fatal("file outside the directory of ignore file should not match")
+	}
+}
+
+func TestIgnoreFileInvalidPattern(t *testing.T) {
+	_, err := parseIgnoreFile("/repo", "path/to/.actionlintignore", []byte("ok.yaml\nfoo[.yaml\n"))
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	want := `invalid pattern "foo[.yaml" at line 2 in "path/to/.actionlintignore"`
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("wanted error message %q but got %q", want, err.Error())
+	}
+}
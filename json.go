@@ -0,0 +1,56 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonFormatVersion is a version of the schema of the built-in "json" format. This value must be
+// incremented when the schema is changed in a backward incompatible way.
+const jsonFormatVersion = 1
+
+type jsonFormatError struct {
+	Message   string `json:"message"`
+	Filepath  string `json:"filepath"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Offset    int    `json:"offset"`
+	EndColumn int    `json:"end_column"`
+	Kind      string `json:"kind"`
+	Severity  string `json:"severity"`
+	Category  string `json:"category"`
+	Snippet   string `json:"snippet"`
+}
+
+type jsonFormatOutput struct {
+	Version int                `json:"version"`
+	Errors  []*jsonFormatError `json:"errors"`
+}
+
+// printErrorsInJSON prints the errors in the built-in JSON format. Unlike templates with `json`
+// function, all fields are always included and the schema is versioned with the "version" field.
+func printErrorsInJSON(out io.Writer, errs []*ErrorTemplateFields, rules []*ruleTemplateFields, files []string) error {
+	es := make([]*jsonFormatError, 0, len(errs))
+	for _, e := range errs {
+		es = append(es, &jsonFormatError{
+			Message:   e.Message,
+			Filepath:  e.Filepath,
+			Line:      e.Line,
+			Column:    e.Column,
+			Offset:    e.Offset,
+			EndColumn: e.EndColumn,
+			Kind:      e.Kind,
+			Severity:  severityOrDefault(e.Severity),
+			Category:  e.Category,
+			Snippet:   e.Snippet,
+		})
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(&jsonFormatOutput{jsonFormatVersion, es}); err != nil {
+		return fmt.Errorf("could not encode errors into JSON: %w", err)
+	}
+	return nil
+}
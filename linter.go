@@ -16,6 +16,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 )
@@ -38,8 +39,9 @@ type ColorOptionKind int
 
 const (
 	// ColorOptionKindAuto is kind to determine to colorize errors output automatically. It is
-	// determined based on pty and $NO_COLOR environment variable. See document of fatih/color
-	// for more details.
+	// determined based on whether the output is a terminal and $NO_COLOR environment variable.
+	// When the output is not a file, the default of fatih/color is used. See document of
+	// fatih/color for more details.
 	ColorOptionKindAuto ColorOptionKind = iota
 	// ColorOptionKindAlways is kind to always colorize errors output.
 	ColorOptionKindAlways
@@ -47,6 +49,16 @@ const (
 	ColorOptionKindNever
 )
 
+// isColorTerminal returns true when colorful output is available on the file. It follows the
+// NO_COLOR convention (https://no-color.org/) and disables colors on a dumb terminal.
+func isColorTerminal(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	fd := f.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
 // LinterOptions is set of options for Linter instance. This struct is used for NewLinter factory
 // function call. The zero value LinterOptions{} represents the default behavior.
 type LinterOptions struct {
@@ -76,8 +88,13 @@ type LinterOptions struct {
 	// ConfigFile is a path to config file. Empty string means no config file path is given. In
 	// the case, actionlint will try to read config from .github/actionlint.yaml.
 	ConfigFile string
+	// ConfigFiles is paths to config files merged in order. Later files take precedence over earlier
+	// ones. When ConfigFile is also given, it is read before these files. See ReadConfigFiles for
+	// the details of merging config files.
+	ConfigFiles []string
 	// Format is a custom template to format error messages. It must follow Go Template format and
 	// contain at least one {{ }} placeholder. https://pkg.go.dev/text/template
+	// Name of built-in format such as "json", "sarif", "checkstyle", "gcc", "github", or "rdjsonl" can also be specified instead of a template.
 	Format string
 	// StdinFileName is a file name when reading input from stdin. When this value is empty, "<stdin>"
 	// is used as the default value.
@@ -88,26 +105,79 @@ type LinterOptions struct {
 	// OnRulesCreated is a hook to add or remove the check rules. This function is called on checking
 	// every workflow files. Rules created by Linter instance are passed to the argument and the
 	// function should return the modified rules.
+	// Rules are visited in the order of the returned slice at each node. Built-in rules come first so
+	// rules appended to the slice are called after them. Since rules store their errors, the function
+	// must return new rule instances on each call. See Visitor.Visit for the visiting order.
 	// Note that syntax errors may be reported even if this function returns nil or an empty slice.
 	OnRulesCreated func([]Rule) []Rule
+	// Fix is flag to fix some errors automatically. Currently deprecated "set-output" and "save-state"
+	// workflow commands are rewritten. Fixed workflow files are overwritten in place. Errors which
+	// could not be fixed are reported as usual. Note that this option does not affect the input from
+	// stdin.
+	Fix bool
+	// ShellcheckCache is flag to enable the on-disk cache of shellcheck results. The cache is stored in
+	// the user cache directory such as $XDG_CACHE_HOME/actionlint/shellcheck. Cache entries which are
+	// not used for a long time are evicted, and the number of entries is bounded. The cache is disabled
+	// by default.
+	ShellcheckCache bool
+	// Template is flag to lint the workflow files as workflow templates. Placeholders such as
+	// $default-branch are allowed in workflow templates. Files in "workflow-templates" directory are
+	// always linted as workflow templates regardless of this flag.
+	// https://docs.github.com/en/actions/using-workflows/creating-starter-workflows-for-your-organization
+	Template bool
+	// Action is flag to lint the input files as action metadata files instead of workflow files. Files
+	// named "action.yml" or "action.yaml" outside "workflows" directory are always linted as action
+	// metadata files regardless of this flag.
+	// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions
+	Action bool
+	// Since is a Git ref such as "origin/main". When this value is not empty, only workflow files
+	// changed since the ref are linted by LintRepository. When the changed files cannot be retrieved
+	// (e.g. the ref is invalid), all workflow files are linted.
+	Since string
+	// Jobs is the number of workflow files linted in parallel and the number of external processes
+	// such as shellcheck run in parallel. When this value is zero, runtime.GOMAXPROCS(0) is used.
+	Jobs int
+	// Timing is flag to print cumulative wall time spent in each rule and each external command such
+	// as shellcheck to LogWriter after linting. It is useful for finding rules which make linting slow.
+	Timing bool
+	// Target is the platform where the workflows run. It is PlatformGitHub or PlatformGHES. When this
+	// value is not empty, it overrides "platform" in the config file.
+	Target string
+	// CheckDependabot is flag to also check schedules in Dependabot configuration file
+	// (.github/dependabot.yml). LintRepository lints the file in addition to workflow files. The file
+	// given explicitly is checked as Dependabot configuration instead of a workflow file.
+	CheckDependabot bool
+	// Stats is flag to print the summary of found errors such as "3 errors in 2 files (5 files
+	// checked)" with the numbers of errors per rule to LogWriter after linting.
+	Stats bool
 	// More options will come here
 }
 
 // Linter is struct to lint workflow files.
 type Linter struct {
-	projects       *Projects
-	out            io.Writer
-	logOut         io.Writer
-	logLevel       LogLevel
-	oneline        bool
-	shellcheck     string
-	pyflakes       string
-	ignorePats     IgnorePatterns
-	stdin          string
-	defaultConfig  *Config
-	errFmt         *ErrorFormatter
-	cwd            string
-	onRulesCreated func([]Rule) []Rule
+	projects        *Projects
+	out             io.Writer
+	logOut          io.Writer
+	logLevel        LogLevel
+	oneline         bool
+	shellcheck      string
+	pyflakes        string
+	ignorePats      IgnorePatterns
+	stdin           string
+	defaultConfig   *Config
+	errFmt          *ErrorFormatter
+	cwd             string
+	onRulesCreated  func([]Rule) []Rule
+	fix             bool
+	shellcheckCache *shellcheckCache
+	template        bool
+	action          bool
+	since           string
+	jobs            int
+	timings         *lintTimings
+	target          string
+	checkDependabot bool
+	stats           bool
 }
 
 // NewLinter creates a new Linter instance.
@@ -125,11 +195,19 @@ func NewLinter(out io.Writer, opts *LinterOptions) (*Linter, error) {
 	if opts.Color == ColorOptionKindNever {
 		color.NoColor = true
 	} else {
-		if opts.Color == ColorOptionKindAlways {
+		f, isFile := out.(*os.File)
+		switch opts.Color {
+		case ColorOptionKindAlways:
 			color.NoColor = false
+		case ColorOptionKindAuto:
+			// fatih/color determines the default value with stdout. Check the actual output instead
+			// since it may be other file such as stderr
+			if isFile {
+				color.NoColor = !isColorTerminal(f)
+			}
 		}
 		// Allow colorful output on Windows
-		if f, ok := out.(*os.File); ok {
+		if isFile {
 			out = colorable.NewColorable(f)
 		}
 	}
@@ -139,9 +217,22 @@ func NewLinter(out io.Writer, opts *LinterOptions) (*Linter, error) {
 		lout = opts.LogWriter
 	}
 
-	var cfg *Config
+	cfgFiles := opts.ConfigFiles
 	if opts.ConfigFile != "" {
-		c, err := ReadConfigFile(opts.ConfigFile)
+		cfgFiles = append([]string{opts.ConfigFile}, cfgFiles...)
+	}
+
+	var cfg *Config
+	switch len(cfgFiles) {
+	case 0:
+	case 1:
+		c, err := ReadConfigFile(cfgFiles[0])
+		if err != nil {
+			return nil, err
+		}
+		cfg = c
+	default:
+		c, err := ReadConfigFiles(cfgFiles...)
 		if err != nil {
 			return nil, err
 		}
@@ -173,11 +264,40 @@ func NewLinter(out io.Writer, opts *LinterOptions) (*Linter, error) {
 		cwd = d
 	}
 
+	if cfg != nil {
+		// Glob patterns in the config file given via command line are relative to the working directory
+		if err := cfg.loadSelfHostedRunnerLabelsFiles(cwd); err != nil {
+			return nil, fmt.Errorf("could not load config file %s: %w", quotes(cfgFiles), err)
+		}
+	}
+
+	jobs := opts.Jobs
+	if jobs < 0 {
+		return nil, fmt.Errorf("number of jobs must be positive but got %d", jobs)
+	}
+	if jobs == 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	if t := opts.Target; t != "" && t != PlatformGitHub && t != PlatformGHES {
+		return nil, fmt.Errorf("invalid target platform %q. it must be %q or %q", t, PlatformGitHub, PlatformGHES)
+	}
+
 	stdin := "<stdin>"
 	if opts.StdinFileName != "" {
 		stdin = opts.StdinFileName
 	}
 
+	var cache *shellcheckCache
+	if opts.Shellcheck != "" && opts.ShellcheckCache {
+		cache = newShellcheckCache("")
+	}
+
+	var timings *lintTimings
+	if opts.Timing {
+		timings = newLintTimings()
+	}
+
 	l := &Linter{
 		NewProjects(),
 		out,
@@ -192,6 +312,16 @@ func NewLinter(out io.Writer, opts *LinterOptions) (*Linter, error) {
 		formatter,
 		cwd,
 		opts.OnRulesCreated,
+		opts.Fix,
+		cache,
+		opts.Template,
+		opts.Action,
+		opts.Since,
+		jobs,
+		timings,
+		opts.Target,
+		opts.CheckDependabot,
+		opts.Stats,
 	}
 
 	l.debug("Create a Linter instance with option %#v", opts)
@@ -214,6 +344,34 @@ func (l *Linter) debug(format string, args ...interface{}) {
 	fmt.Fprintf(l.logOut, format, args...)
 }
 
+func (l *Linter) debugProcessMemo(proc *concurrentProcess) {
+	if l.logLevel < LogLevelDebug || proc.memo == nil {
+		return
+	}
+	hits, misses := proc.memo.stats()
+	l.debug("Memoized results of external commands: %d hits, %d misses", hits, misses)
+}
+
+func (l *Linter) newProcess() *concurrentProcess {
+	proc := newConcurrentProcess(l.jobs)
+	proc.timings = l.timings
+	return proc
+}
+
+func (l *Linter) printTimings() {
+	if l.timings == nil {
+		return
+	}
+	l.timings.print(l.logOut)
+}
+
+func (l *Linter) printStats(errs []*Error, checked int) {
+	if !l.stats {
+		return
+	}
+	printLintStats(l.logOut, errs, checked)
+}
+
 func (l *Linter) debugWriter() io.Writer {
 	if l.logLevel < LogLevelDebug {
 		return nil
@@ -276,12 +434,177 @@ func (l *Linter) LintRepository(dir string) ([]*Error, error) {
 	}
 
 	l.log("Detected project:", p.RootDir())
-	wd := p.WorkflowsDir()
-	return l.LintDir(wd, p)
+	files, err := findYAMLFiles(p.WorkflowsDir())
+	if err != nil {
+		return nil, err
+	}
+	if l.checkDependabot {
+		if f := findDependabotConfigFile(p); f != "" {
+			l.log("Found Dependabot configuration file:", f)
+			files = append(files, f)
+		}
+	}
+	files, err = l.filterIgnoredFiles(files, p)
+	if err != nil {
+		return nil, err
+	}
+	l.log("Collected", len(files), "YAML files")
+	if l.since != "" {
+		files = l.filterChangedFiles(files, p)
+		if len(files) == 0 {
+			l.log("No workflow file was changed since", l.since)
+			return []*Error{}, nil
+		}
+	}
+	return l.LintFiles(files, p)
+}
+
+// filterIgnoredFiles removes the workflow files excluded by ".actionlintignore" files in the
+// project from the list. When the project is nil, the project is detected from each file path.
+// Files not in any project are never excluded.
+func (l *Linter) filterIgnoredFiles(files []string, project *Project) ([]string, error) {
+	ret := make([]string, 0, len(files))
+	for _, f := range files {
+		p := project
+		if p == nil {
+			var err error
+			if p, err = l.projects.At(f); err != nil {
+				return nil, err
+			}
+		}
+		if p != nil {
+			ignored, err := p.IsIgnored(f)
+			if err != nil {
+				return nil, err
+			}
+			if ignored {
+				l.log("Skipped workflow file ignored by", ignoreFileName+":", f)
+				continue
+			}
+		}
+		ret = append(ret, f)
+	}
+	return ret, nil
+}
+
+// filterChangedFiles filters the workflow files with files changed since the Git ref given by
+// `-since` option. When the changed files cannot be retrieved, all the workflow files are returned.
+func (l *Linter) filterChangedFiles(files []string, p *Project) []string {
+	changed, err := p.ChangedFilesSince(l.since)
+	if err != nil {
+		fmt.Fprintf(l.logOut, "could not get files changed since %q. all workflow files are linted: %s\n", l.since, err)
+		return files
+	}
+	l.log("Found", len(changed), "files changed since", l.since)
+
+	set := make(map[string]struct{}, len(changed))
+	for _, f := range changed {
+		set[f] = struct{}{}
+	}
+	ret := []string{}
+	for _, f := range files {
+		if r, err := filepath.Rel(p.RootDir(), f); err == nil {
+			if _, ok := set[filepath.ToSlash(r)]; ok {
+				ret = append(ret, f)
+			}
+		}
+	}
+	l.log("Collected", len(ret), "changed workflow files")
+	return ret
+}
+
+// LintGitRevision lints YAML workflow files in ".github/workflows" directory at the Git revision
+// such as a commit SHA. The files are read from the Git object database with `git` command run in
+// the current working directory, so a working tree is not necessary. This is useful for validating
+// workflows in server-side Git hooks such as pre-receive hook in a bare repository. File paths in
+// the errors are relative to the root of the repository. When no config file is given, the config
+// file ".github/actionlint.yaml" at the revision is used. Local actions and local reusable
+// workflows are not checked since they are read from the file system.
+func (l *Linter) LintGitRevision(rev string) ([]*Error, error) {
+	l.log("Linting workflow files at Git revision", rev)
+
+	g, err := newGitRevision(l.cwd, rev)
+	if err != nil {
+		return nil, err
+	}
+	l.log("Resolved revision", rev, "to commit", g.commit)
+
+	files, err := g.workflowFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		l.log("No workflow file was found at revision", rev)
+		l.printStats(nil, 0)
+		return []*Error{}, nil
+	}
+	l.log("Collected", len(files), "YAML files at revision", rev)
+
+	if l.defaultConfig == nil {
+		c, err := g.config()
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			// Use the config file at the revision in the same way as the config file given via
+			// command line. Copy the linter not to modify the state of the receiver.
+			lc := *l
+			lc.defaultConfig = c
+			l = &lc
+		}
+	}
+
+	return l.lintFiles(files, nil, g)
 }
 
 // LintDir lints all YAML workflow files in the given directory recursively.
 func (l *Linter) LintDir(dir string, project *Project) ([]*Error, error) {
+	files, err := findYAMLFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	l.log("Collected", len(files), "YAML files")
+	return l.LintFiles(files, project)
+}
+
+// findWorkflowFiles finds all YAML files in ".github/workflows" directories under the directory
+// recursively. This is useful for monorepos which have ".github/workflows" directory per subproject.
+// The returned file paths are sorted. It is an error when no workflow file is found.
+func findWorkflowFiles(dir string) ([]string, error) {
+	files := []string{}
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if n := d.Name(); n == ".git" || n == "node_modules" {
+			return filepath.SkipDir
+		}
+		if d.Name() != "workflows" || filepath.Base(filepath.Dir(path)) != ".github" {
+			return nil
+		}
+		if ys, err := findYAMLFiles(path); err == nil {
+			files = append(files, ys...)
+		}
+		return filepath.SkipDir
+	}); err != nil {
+		return nil, fmt.Errorf("could not read files in %q: %w", dir, err)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YAML file was found in \".github/workflows\" directories under %q", dir)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// findYAMLFiles finds all YAML files in the directory recursively. The returned file paths are
+// sorted. It is an error when no YAML file is found.
+func findYAMLFiles(dir string) ([]string, error) {
 	files := []string{}
 	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -301,12 +624,11 @@ func (l *Linter) LintDir(dir string, project *Project) ([]*Error, error) {
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no YAML file was found in %q", dir)
 	}
-	l.log("Collected", len(files), "YAML files")
 
 	// To make output deterministic, sort order of file paths
 	sort.Strings(files)
 
-	return l.LintFiles(files, project)
+	return files, nil
 }
 
 // LintFiles lints YAML workflow files and outputs the errors to given writer. It applies lint
@@ -316,17 +638,24 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 	n := len(filepaths)
 	switch n {
 	case 0:
+		l.printStats(nil, 0)
 		return []*Error{}, nil
 	case 1:
 		return l.LintFile(filepaths[0], project)
 	}
+	return l.lintFiles(filepaths, project, nil)
+}
 
+// lintFiles lints the files in parallel and outputs the errors. When the Git revision is not nil,
+// the files are read from the revision instead of the file system. In the case, projects are not
+// detected from the file paths and the files are never fixed.
+func (l *Linter) lintFiles(filepaths []string, project *Project, rev *gitRevision) ([]*Error, error) {
+	n := len(filepaths)
 	l.log("Linting", n, "files")
 
 	cwd := l.cwd
-	cpus := runtime.NumCPU()
-	proc := newConcurrentProcess(cpus)
-	sema := semaphore.NewWeighted(int64(cpus))
+	proc := l.newProcess()
+	sema := semaphore.NewWeighted(int64(l.jobs))
 	ctx := context.Background()
 	dbg := l.debugWriter()
 	acf := NewLocalActionsCacheFactory(dbg)
@@ -344,11 +673,12 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 	}
 
 	eg := errgroup.Group{}
+	eg.SetLimit(l.jobs)
 	for i := range ws {
 		// Each element of ws is accessed by single goroutine so mutex is unnecessary
 		w := &ws[i]
 		proj := project
-		if proj == nil {
+		if proj == nil && rev == nil {
 			// This method modifies state of l.projects so it cannot be called in parallel.
 			// Before entering goroutine, resolve project instance.
 			p, err := l.projects.At(w.path)
@@ -363,21 +693,41 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 		eg.Go(func() error {
 			// Bound concurrency on reading files to avoid "too many files to open" error (issue #3)
 			sema.Acquire(ctx, 1)
-			src, err := os.ReadFile(w.path)
+			var src []byte
+			var err error
+			if rev != nil {
+				src, err = rev.readFile(w.path)
+			} else {
+				src, err = os.ReadFile(w.path)
+				if err != nil {
+					err = fmt.Errorf("could not read %q: %w", w.path, err)
+				}
+			}
 			sema.Release(1)
 			if err != nil {
-				return fmt.Errorf("could not read %q: %w", w.path, err)
+				return err
 			}
 
-			if cwd != "" {
+			path := w.path
+			if cwd != "" && rev == nil {
 				if r, err := filepath.Rel(cwd, w.path); err == nil {
 					w.path = r // Use relative path if possible
 				}
 			}
-			errs, err := l.check(w.path, src, proj, proc, ac, rwc)
+			var fixer *sourceFixer
+			if l.fix && rev == nil {
+				fixer = newSourceFixer(src)
+			}
+			errs, err := l.check(w.path, src, proj, proc, ac, rwc, fixer)
 			if err != nil {
 				return fmt.Errorf("fatal error while checking %s: %w", w.path, err)
 			}
+			if fixer != nil && fixer.fixed > 0 {
+				src = fixer.source()
+				if err := l.writeFixedFile(path, w.path, src, fixer.fixed); err != nil {
+					return err
+				}
+			}
 			w.src = src
 			w.errs = errs
 			return nil
@@ -395,6 +745,14 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 	// After traversing all workflows, `proc.run()` is no longer called so `proc.wait()` can be
 	// called safely.
 	proc.wait()
+	l.debugProcessMemo(proc)
+	l.printTimings()
+
+	// Workers finish in random order. Sort the results by file path to make the output deterministic.
+	// Errors in each file are already sorted by their positions.
+	sort.SliceStable(ws, func(i, j int) bool {
+		return ws[i].path < ws[j].path
+	})
 
 	total := 0
 	for i := range ws {
@@ -423,6 +781,7 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 	}
 
 	l.log("Found", total, "errors in", n, "files")
+	l.printStats(all, n)
 
 	return all, nil
 }
@@ -443,27 +802,43 @@ func (l *Linter) LintFile(path string, project *Project) ([]*Error, error) {
 		return nil, fmt.Errorf("could not read %q: %w", path, err)
 	}
 
+	file := path
 	if l.cwd != "" {
 		if r, err := filepath.Rel(l.cwd, path); err == nil {
 			path = r
 		}
 	}
 
-	proc := newConcurrentProcess(runtime.NumCPU())
+	var fixer *sourceFixer
+	if l.fix {
+		fixer = newSourceFixer(src)
+	}
+
+	proc := l.newProcess()
 	dbg := l.debugWriter()
 	localActions := NewLocalActionsCache(project, dbg)
 	localReusableWorkflows := NewLocalReusableWorkflowCache(project, l.cwd, dbg)
-	errs, err := l.check(path, src, project, proc, localActions, localReusableWorkflows)
+	errs, err := l.check(path, src, project, proc, localActions, localReusableWorkflows, fixer)
 	proc.wait()
+	l.debugProcessMemo(proc)
+	l.printTimings()
 	if err != nil {
 		return nil, err
 	}
 
+	if fixer != nil && fixer.fixed > 0 {
+		src = fixer.source()
+		if err := l.writeFixedFile(file, path, src, fixer.fixed); err != nil {
+			return nil, err
+		}
+	}
+
 	if l.errFmt != nil {
 		l.errFmt.PrintErrors(l.out, errs, src)
 	} else {
 		l.printErrors(errs, src)
 	}
+	l.printStats(errs, 1)
 	return errs, err
 }
 
@@ -479,12 +854,26 @@ func (l *Linter) LintStdin(stdin io.Reader) ([]*Error, error) {
 	return l.Lint(l.stdin, b, nil)
 }
 
+// LintReader lints YAML workflow file content read from the given reader. This is useful when the
+// content is not saved to a file yet, such as a buffer in an editor. The path parameter is used as file
+// path where the content came from and it is set to the Filepath field of the returned errors.
+// When nil is passed to the project parameter, it tries to find the project from the path parameter.
+func (l *Linter) LintReader(path string, r io.Reader, project *Project) ([]*Error, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %q: %w", path, err)
+	}
+	return l.Lint(path, b, project)
+}
+
 // Lint lints YAML workflow file content given as byte slice. The path parameter is used as file
 // path where the content came from.
 // When nil is passed to the project parameter, it tries to find the project from the path parameter.
 func (l *Linter) Lint(path string, content []byte, project *Project) ([]*Error, error) {
 	if project == nil && path != "<stdin>" {
-		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		// The file may not exist yet when the content is not saved (e.g. input from stdin given by
+		// an editor). Find the project from the directory of the file in the case.
+		if _, err := os.Stat(filepath.Dir(path)); !errors.Is(err, fs.ErrNotExist) {
 			p, err := l.projects.At(path)
 			if err != nil {
 				return nil, err
@@ -492,12 +881,14 @@ func (l *Linter) Lint(path string, content []byte, project *Project) ([]*Error,
 			project = p
 		}
 	}
-	proc := newConcurrentProcess(runtime.NumCPU())
+	proc := l.newProcess()
 	dbg := l.debugWriter()
 	localActions := NewLocalActionsCache(project, dbg)
 	localReusableWorkflows := NewLocalReusableWorkflowCache(project, l.cwd, dbg)
-	errs, err := l.check(path, content, project, proc, localActions, localReusableWorkflows)
+	errs, err := l.check(path, content, project, proc, localActions, localReusableWorkflows, nil)
 	proc.wait()
+	l.debugProcessMemo(proc)
+	l.printTimings()
 	if err != nil {
 		return nil, err
 	}
@@ -506,9 +897,93 @@ func (l *Linter) Lint(path string, content []byte, project *Project) ([]*Error,
 	} else {
 		l.printErrors(errs, content)
 	}
+	l.printStats(errs, 1)
 	return errs, nil
 }
 
+// findDependabotConfigFile returns the path to Dependabot configuration file in the project. Empty
+// string is returned when the file does not exist.
+func findDependabotConfigFile(p *Project) string {
+	for _, n := range []string{"dependabot.yml", "dependabot.yaml"} {
+		f := filepath.Join(p.RootDir(), ".github", n)
+		if s, err := os.Stat(f); err == nil && !s.IsDir() {
+			return f
+		}
+	}
+	return ""
+}
+
+// isActionMetadataPath returns true when the file path is an action metadata file such as
+// action.yml. Files in "workflows" directory are not action metadata even if they are named
+// action.yml since they are workflow files.
+func isActionMetadataPath(path string) bool {
+	b := filepath.Base(path)
+	return (b == "action.yml" || b == "action.yaml") && filepath.Base(filepath.Dir(path)) != "workflows"
+}
+
+// newRules creates instances of all built-in rules to check the workflow file at the path. Rules
+// depending on external commands (shellcheck and pyflakes) are not included since they are enabled
+// only when the commands are available.
+func newRules(
+	path string,
+	project *Project,
+	localActions *LocalActionsCache,
+	localReusableWorkflows *LocalReusableWorkflowCache,
+	fixer *sourceFixer,
+	template bool,
+) []Rule {
+	deprecatedCommands := NewRuleDeprecatedCommands()
+	deprecatedCommands.fixer = fixer
+	glob := NewRuleGlob()
+	glob.project = project
+	events := NewRuleEvents()
+	events.template = template
+	action := NewRuleAction(localActions)
+	action.dir = filepath.Dir(path)
+	expression := NewRuleExpression(localActions, localReusableWorkflows)
+	expression.workflowPath = path
+	workingDirectory := NewRuleWorkingDirectory()
+	workingDirectory.project = project
+
+	return []Rule{
+		NewRuleMatrix(),
+		NewRuleCredentials(),
+		NewRuleShellName(),
+		NewRuleRunnerLabel(),
+		events,
+		NewRuleJobNeeds(),
+		action,
+		NewRuleEnvVar(),
+		NewRuleID(),
+		glob,
+		NewRulePermissions(),
+		NewRuleWorkflowCall(path, localReusableWorkflows),
+		expression,
+		deprecatedCommands,
+		NewRuleIfCond(),
+		NewRuleUnusedInputs(),
+		NewRuleUnusedStepOutputs(),
+		NewRuleJobOutputs(),
+		NewRuleSecretPrint(),
+		NewRuleMultilineEnv(),
+		NewRuleReservedEnv(),
+		NewRuleGatingJobs(),
+		NewRuleJobTimeout(),
+		NewRuleShallowCheckout(),
+		NewRuleUntrustedCheckout(),
+		NewRuleNodeRuntime(localActions),
+		NewRuleCacheKey(),
+		NewRuleEnvironment(),
+		NewRuleConcurrency(),
+		NewRuleArtifact(),
+		NewRuleActionPermissions(),
+		NewRuleBroadPermissions(),
+		NewRuleServices(),
+		NewRuleUnreachableSteps(),
+		workingDirectory,
+	}
+}
+
 func (l *Linter) check(
 	path string,
 	content []byte,
@@ -516,6 +991,7 @@ func (l *Linter) check(
 	proc *concurrentProcess,
 	localActions *LocalActionsCache,
 	localReusableWorkflows *LocalReusableWorkflowCache,
+	fixer *sourceFixer,
 ) ([]*Error, error) {
 	// Note: This method is called to check multiple files in parallel.
 	// It must be thread safe assuming fields of Linter are not modified while running.
@@ -535,7 +1011,24 @@ func (l *Linter) check(
 		// `-config-file` option has higher priority than repository config file
 		cfg = l.defaultConfig
 	} else if project != nil {
-		cfg = project.Config()
+		p := path
+		if !filepath.IsAbs(p) && l.cwd != "" {
+			p = filepath.Join(l.cwd, p)
+		}
+		c, err := project.ConfigAt(p)
+		if err != nil {
+			return nil, err
+		}
+		cfg = c
+	}
+	// `-target` option has higher priority than "platform" in config files
+	if l.target != "" && cfg.isGHES() != (l.target == PlatformGHES) {
+		c := Config{}
+		if cfg != nil {
+			c = *cfg
+		}
+		c.Platform = l.target
+		cfg = &c
 	}
 	if cfg != nil {
 		l.debug("Config: %#v", cfg)
@@ -543,36 +1036,40 @@ func (l *Linter) check(
 		l.debug("No config was found")
 	}
 
-	w, all := Parse(content)
+	var w *Workflow
+	var a *Action
+	var all []*Error
+	if l.checkDependabot && isDependabotConfigPath(path) {
+		l.log("Linting", path, "as Dependabot configuration")
+		all = checkDependabotConfig(content)
+	} else if l.action || isActionMetadataPath(path) {
+		l.log("Linting", path, "as action metadata")
+		a, all = ParseAction(content)
+	} else {
+		w, all = Parse(content)
+	}
+
+	if l.errFmt != nil {
+		l.errFmt.RegisterFile(path)
+	}
 
 	if l.logLevel >= LogLevelVerbose {
 		elapsed := time.Since(start)
 		l.log("Found", len(all), "parse errors in", elapsed.Milliseconds(), "ms for", path)
 	}
 
-	if w != nil {
+	if w != nil || a != nil {
 		dbg := l.debugWriter()
 
-		rules := []Rule{
-			NewRuleMatrix(),
-			NewRuleCredentials(),
-			NewRuleShellName(),
-			NewRuleRunnerLabel(),
-			NewRuleEvents(),
-			NewRuleJobNeeds(),
-			NewRuleAction(localActions),
-			NewRuleEnvVar(),
-			NewRuleID(),
-			NewRuleGlob(),
-			NewRulePermissions(),
-			NewRuleWorkflowCall(path, localReusableWorkflows),
-			NewRuleExpression(localActions, localReusableWorkflows),
-			NewRuleDeprecatedCommands(),
-			NewRuleIfCond(),
+		template := l.template || isWorkflowTemplatePath(path)
+		if template {
+			l.log("Linting", path, "as workflow template")
 		}
+		rules := newRules(path, project, localActions, localReusableWorkflows, fixer, template)
 		if l.shellcheck != "" {
 			r, err := NewRuleShellcheck(l.shellcheck, proc)
 			if err == nil {
+				r.cache = l.shellcheckCache
 				rules = append(rules, r)
 			} else {
 				l.log("Rule \"shellcheck\" was disabled:", err)
@@ -595,7 +1092,14 @@ func (l *Linter) check(
 		}
 
 		v := NewVisitor()
+		var timed []*timedPass
 		for _, rule := range rules {
+			if l.timings != nil {
+				t, p := newTimedPass(rule)
+				timed = append(timed, t)
+				v.AddPass(p)
+				continue
+			}
 			v.AddPass(rule)
 		}
 		if dbg != nil {
@@ -610,10 +1114,19 @@ func (l *Linter) check(
 			}
 		}
 
-		if err := v.Visit(w); err != nil {
-			l.debug("Error occurred while visiting workflow syntax tree: %v", err)
+		var err error
+		if a != nil {
+			err = v.VisitAction(a)
+		} else {
+			err = v.Visit(w)
+		}
+		if err != nil {
+			l.debug("Error occurred while visiting syntax tree: %v", err)
 			return nil, err
 		}
+		if l.timings != nil {
+			l.timings.addPasses(timed)
+		}
 
 		for _, rule := range rules {
 			errs := rule.Errs()
@@ -632,10 +1145,17 @@ func (l *Linter) check(
 
 	for _, err := range all {
 		err.Filepath = path // Populate filename in the error
+		err.Offset = err.offsetIn(content)
+		err.Severity = cfg.RuleSeverity(err.Kind)
+		err.Category = RuleCategory(err.Kind)
 	}
 
 	sort.Stable(ByErrorPosition(all))
 
+	if l.timings != nil {
+		l.timings.addFile(len(all))
+	}
+
 	if l.logLevel >= LogLevelVerbose {
 		elapsed := time.Since(start)
 		l.log("Found total", len(all), "errors in", elapsed.Milliseconds(), "ms for", path)
@@ -644,6 +1164,15 @@ func (l *Linter) check(
 	return all, nil
 }
 
+func (l *Linter) writeFixedFile(path, display string, src []byte, fixed int) error {
+	// Permission of the existing file is not changed by os.WriteFile
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		return fmt.Errorf("could not write the fixed workflow file %q: %w", display, err)
+	}
+	l.log("Fixed", fixed, "error(s) in", display)
+	return nil
+}
+
 func (l *Linter) filterErrors(errs []*Error, cfgs []PathConfig) []*Error {
 	if len(l.ignorePats) == 0 && len(cfgs) == 0 {
 		return errs
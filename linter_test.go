@@ -268,6 +268,125 @@ CheckFiles:
 	}
 }
 
+func TestLinterLintFilesSortedDeterministically(t *testing.T) {
+	_, files, err := testFindAllWorkflowsInDir("err")
+	if err != nil {
+		panic(err)
+	}
+	// Reverse the order of the input files. The output should be sorted regardless of the order.
+	for i, j := 0, len(files)-1; i < j; i, j = i+1, j-1 {
+		files[i], files[j] = files[j], files[i]
+	}
+	proj := &Project{root: filepath.Join("testdata", "err")}
+
+	var prev string
+	for _, jobs := range []int{1, 4, 0} {
+		var b strings.Builder
+		o := LinterOptions{Jobs: jobs, Oneline: true}
+		l, err := NewLinter(&b, &o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l.defaultConfig = &Config{}
+
+		errs, err := l.LintFiles(files, proj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sort.IsSorted(ByErrorPosition(errs)) {
+			t.Fatalf("errors are not sorted with %d jobs: %v", jobs, errs)
+		}
+
+		out := b.String()
+		if prev != "" && out != prev {
+			t.Fatalf("output with %d jobs is different from the previous output:\n%s", jobs, cmp.Diff(prev, out))
+		}
+		prev = out
+	}
+}
+
+func TestLinterInvalidJobs(t *testing.T) {
+	_, err := NewLinter(io.Discard, &LinterOptions{Jobs: -1})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if !strings.Contains(err.Error(), "number of jobs must be positive") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestLinterTargetOption(t *testing.T) {
+	src := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello
+`
+	for _, tc := range []struct {
+		target   string
+		platform string
+		errs     int
+	}{
+		{"", "", 0},
+		{PlatformGitHub, "", 0},
+		{PlatformGHES, "", 1},
+		{"", PlatformGHES, 1},
+		{PlatformGitHub, PlatformGHES, 0},
+		{PlatformGHES, PlatformGitHub, 1},
+	} {
+		t.Run(fmt.Sprintf("target=%q,platform=%q", tc.target, tc.platform), func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{Target: tc.target})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.platform != "" {
+				l.defaultConfig = &Config{Platform: tc.platform}
+			}
+			errs, err := l.Lint("test.yaml", []byte(src), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(errs) != tc.errs {
+				t.Fatalf("wanted %d errors but got %v", tc.errs, errs)
+			}
+			for _, e := range errs {
+				if e.Kind != "runner-label" {
+					t.Fatalf("unexpected error: %s", e)
+				}
+			}
+		})
+	}
+}
+
+func TestLinterInvalidTarget(t *testing.T) {
+	_, err := NewLinter(io.Discard, &LinterOptions{Target: "enterprise"})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if !strings.Contains(err.Error(), `invalid target platform "enterprise"`) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestLinterShellcheckCacheOptIn(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "shellcheck"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.shellcheckCache != nil {
+		t.Fatal("shellcheck cache should be disabled by default")
+	}
+
+	l, err = NewLinter(io.Discard, &LinterOptions{Shellcheck: "shellcheck", ShellcheckCache: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.shellcheckCache == nil {
+		t.Fatal("shellcheck cache should be enabled by ShellcheckCache option")
+	}
+}
+
 func TestLintFindProjectFromPath(t *testing.T) {
 	d := filepath.Join("testdata", "find_project")
 	f := filepath.Join(d, ".github", "workflows", "test.yaml")
@@ -433,81 +552,661 @@ func TestLinterFormatErrorMessageInSARIF(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(errs) == 0 {
-		t.Fatal("no error")
+	if len(errs) == 0 {
+		t.Fatal("no error")
+	}
+
+	out := b.String()
+	// Fix path separators on Windows
+	if runtime.GOOS == "windows" {
+		slash := filepath.ToSlash(file)
+		escaped := strings.ReplaceAll(file, `\`, `\\`)
+		out = strings.ReplaceAll(out, escaped, slash)
+	}
+
+	var have interface{}
+	if err := json.Unmarshal([]byte(out), &have); err != nil {
+		t.Fatalf("output is not JSON: %v: %q", err, out)
+	}
+
+	bytes, err = os.ReadFile(filepath.Join(dir, "test.sarif"))
+	if err != nil {
+		panic(err)
+	}
+	var want interface{}
+	if err := json.Unmarshal(bytes, &want); err != nil {
+		panic(err)
+	}
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterFormatErrorMessageInBuiltinSARIF(t *testing.T) {
+	dir := filepath.Join("testdata", "format")
+	proj := &Project{root: dir}
+	file := filepath.Join(dir, "test.yaml")
+
+	opts := LinterOptions{Format: "sarif"}
+	var b strings.Builder
+	l, err := NewLinter(&b, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.defaultConfig = &Config{}
+	errs, err := l.LintFile(file, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("no error")
+	}
+
+	var have map[string]interface{}
+	if err := json.Unmarshal([]byte(b.String()), &have); err != nil {
+		t.Fatalf("output is not JSON: %v: %q", err, b.String())
+	}
+
+	// Version depends on how the test binary was built
+	driver := have["runs"].([]interface{})[0].(map[string]interface{})["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+	if v := driver["version"]; v != getCommandVersion() {
+		t.Errorf("version in tool.driver is unexpected: %v", v)
+	}
+	driver["version"] = ""
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "test_builtin.sarif"))
+	if err != nil {
+		panic(err)
+	}
+	var want map[string]interface{}
+	if err := json.Unmarshal(bytes, &want); err != nil {
+		panic(err)
+	}
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterFormatErrorMessageInBuiltinJSON(t *testing.T) {
+	dir := filepath.Join("testdata", "format")
+	proj := &Project{root: dir}
+	file := filepath.Join(dir, "test.yaml")
+
+	opts := LinterOptions{Format: "json"}
+	var b strings.Builder
+	l, err := NewLinter(&b, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.defaultConfig = &Config{}
+	errs, err := l.LintFile(file, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("no error")
+	}
+
+	out := b.String()
+	// Fix path separators on Windows
+	if runtime.GOOS == "windows" {
+		slash := filepath.ToSlash(file)
+		escaped := strings.ReplaceAll(file, `\`, `\\`)
+		out = strings.ReplaceAll(out, escaped, slash)
+	}
+
+	var have interface{}
+	if err := json.Unmarshal([]byte(out), &have); err != nil {
+		t.Fatalf("output is not JSON: %v: %q", err, out)
+	}
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "test_builtin.json"))
+	if err != nil {
+		panic(err)
+	}
+	var want interface{}
+	if err := json.Unmarshal(bytes, &want); err != nil {
+		panic(err)
+	}
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterFormatErrorMessageInBuiltinCheckstyle(t *testing.T) {
+	dir := filepath.Join("testdata", "format")
+	proj := &Project{root: dir}
+	file := filepath.Join(dir, "test.yaml")
+
+	opts := LinterOptions{Format: "checkstyle"}
+	var b strings.Builder
+	l, err := NewLinter(&b, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.defaultConfig = &Config{}
+	errs, err := l.LintFile(file, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("no error")
+	}
+
+	have := b.String()
+	// Fix path separators on Windows
+	if runtime.GOOS == "windows" {
+		have = strings.ReplaceAll(have, file, filepath.ToSlash(file))
+	}
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "test_checkstyle.xml"))
+	if err != nil {
+		panic(err)
+	}
+	want := string(bytes)
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterFormatErrorMessageInBuiltinGCC(t *testing.T) {
+	dir := filepath.Join("testdata", "format")
+	proj := &Project{root: dir}
+	file := filepath.Join(dir, "test.yaml")
+
+	opts := LinterOptions{Format: "gcc"}
+	var b strings.Builder
+	l, err := NewLinter(&b, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.defaultConfig = &Config{}
+	errs, err := l.LintFile(file, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("no error")
+	}
+
+	have := b.String()
+	// Fix path separators on Windows
+	if runtime.GOOS == "windows" {
+		have = strings.ReplaceAll(have, file, filepath.ToSlash(file))
+	}
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "test_gcc.txt"))
+	if err != nil {
+		panic(err)
+	}
+	want := string(bytes)
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterFormatErrorMessageInBuiltinGitHub(t *testing.T) {
+	dir := filepath.Join("testdata", "format")
+	proj := &Project{root: dir}
+	file := filepath.Join(dir, "test.yaml")
+
+	opts := LinterOptions{Format: "github"}
+	var b strings.Builder
+	l, err := NewLinter(&b, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.defaultConfig = &Config{}
+	errs, err := l.LintFile(file, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("no error")
+	}
+
+	have := b.String()
+	// Fix path separators on Windows
+	if runtime.GOOS == "windows" {
+		have = strings.ReplaceAll(have, file, filepath.ToSlash(file))
+	}
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "test_github.txt"))
+	if err != nil {
+		panic(err)
+	}
+	want := string(bytes)
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterFormatErrorMessageInBuiltinRDJSONL(t *testing.T) {
+	dir := filepath.Join("testdata", "format")
+	proj := &Project{root: dir}
+	file := filepath.Join(dir, "test.yaml")
+
+	opts := LinterOptions{Format: "rdjsonl"}
+	var b strings.Builder
+	l, err := NewLinter(&b, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.defaultConfig = &Config{}
+	errs, err := l.LintFile(file, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("no error")
+	}
+
+	have := b.String()
+	// Fix path separators on Windows
+	if runtime.GOOS == "windows" {
+		have = strings.ReplaceAll(have, file, filepath.ToSlash(file))
+	}
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "test.rdjsonl"))
+	if err != nil {
+		panic(err)
+	}
+	want := string(bytes)
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterLintStdinOK(t *testing.T) {
+	for _, f := range []string{"", "foo.yaml"} {
+		l, err := NewLinter(io.Discard, &LinterOptions{StdinFileName: f})
+		if err != nil {
+			t.Fatalf("creating Linter object with stdin file name %q caused error: %v", f, err)
+		}
+
+		in := []byte(`on: push
+jobs:
+  job:
+    runs-on: foo
+	steps:
+	  - run: echo`)
+		errs, err := l.LintStdin(bytes.NewReader(in))
+		if err != nil {
+			t.Fatalf("linting input with stdin file name %q caused error: %v", f, err)
+		}
+		if len(errs) != 1 {
+			t.Fatalf("unexpected number of errors with stdin file name %q: %v", f, errs)
+		}
+
+		want := f
+		if want == "" {
+			want = "<stdin>"
+		}
+		if errs[0].Filepath != want {
+			t.Fatalf("file path in the error with stdin file name %q should be %q but got %q", f, want, errs[0].Filepath)
+		}
+	}
+}
+
+func TestLinterLintStdinWithFileNameNotExist(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{".git", filepath.Join(".github", "workflows")} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := []byte("self-hosted-runner:\n  labels: [my-runner]\n")
+	if err := os.WriteFile(filepath.Join(root, ".github", "actionlint.yaml"), cfg, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file does not exist but the project should be detected from its directory
+	f := filepath.Join(root, ".github", "workflows", "unsaved.yaml")
+	l, err := NewLinter(io.Discard, &LinterOptions{StdinFileName: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := `on: push
+jobs:
+  job:
+    runs-on: my-runner
+    steps:
+      - run: echo`
+	errs, err := l.LintStdin(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("config file in the project was not used: %v", errs)
+	}
+}
+
+func TestLinterLintReaderOK(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := `on: push
+jobs:
+  job:
+    runs-on: foo
+	steps:
+	  - run: echo`
+	path := filepath.Join("path", "to", "unsaved.yaml")
+	errs, err := l.LintReader(path, strings.NewReader(in), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("unexpected number of errors: %v", errs)
+	}
+	if errs[0].Filepath != path {
+		t.Fatalf("file path in the error should be %q but got %q", path, errs[0].Filepath)
+	}
+}
+
+func TestLinterLintWorkflowTemplate(t *testing.T) {
+	in := `on:
+  push:
+    branches: [$default-branch]
+  pull_request:
+    branches: [$default-branch, $protected-branches]
+  schedule:
+    - cron: $cron-weekly
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo hello
+`
+
+	for _, tc := range []struct {
+		what     string
+		path     string
+		template bool
+		errs     int
+	}{
+		{"normal workflow", filepath.Join(".github", "workflows", "ci.yaml"), false, 4},
+		{"in workflow-templates directory", filepath.Join(".github", "workflow-templates", "ci.yaml"), false, 0},
+		{"with template option", filepath.Join(".github", "workflows", "ci.yaml"), true, 0},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{Template: tc.template})
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.LintReader(tc.path, strings.NewReader(in), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(errs) != tc.errs {
+				t.Fatalf("wanted %d errors but got %d errors: %v", tc.errs, len(errs), errs)
+			}
+			for _, e := range errs {
+				if !strings.Contains(e.Message, "is only available in workflow templates") {
+					t.Error("unexpected error:", e)
+				}
+			}
+		})
+	}
+}
+
+func TestLinterLintActionMetadata(t *testing.T) {
+	root := filepath.Join("testdata", "actions")
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, info := range entries {
+		if !info.IsDir() {
+			continue
+		}
+
+		name := info.Name()
+		t.Run(name, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.LintFile(filepath.Join(root, name, "action.yml"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkErrors(t, filepath.Join(root, name+".out"), errs)
+		})
+	}
+}
+
+func TestLinterLintActionMetadataDetection(t *testing.T) {
+	in := `name: My action
+description: My action
+runs:
+  using: composite
+  steps:
+    - run: echo hello
+      shell: bash
+`
+
+	for _, tc := range []struct {
+		what   string
+		path   string
+		action bool
+		errs   int
+	}{
+		{"action.yml", filepath.Join(".github", "actions", "my-action", "action.yml"), false, 0},
+		{"action.yaml", filepath.Join("action.yaml"), false, 0},
+		{"action.yml in workflows directory", filepath.Join(".github", "workflows", "action.yml"), false, 4},
+		{"with action option", filepath.Join(".github", "actions", "my-action", "metadata.yml"), true, 0},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{Action: tc.action})
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.LintReader(tc.path, strings.NewReader(in), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(errs) != tc.errs {
+				t.Fatalf("wanted %d errors but got %d errors: %v", tc.errs, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestLinterLintReaderReadError(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = l.LintReader("test.yaml", testErrorReader{}, nil)
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	want := `could not read "test.yaml": dummy read error`
+	have := err.Error()
+	if want != have {
+		t.Fatalf("wanted error message %q but have %q", want, have)
+	}
+}
+
+func TestLinterLintStdinReadError(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = l.LintStdin(testErrorReader{})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	want := "could not read stdin: dummy read error"
+	have := err.Error()
+	if want != have {
+		t.Fatalf("wanted error message %q but have %q", want, have)
 	}
+}
 
-	out := b.String()
-	// Fix path separators on Windows
-	if runtime.GOOS == "windows" {
-		slash := filepath.ToSlash(file)
-		escaped := strings.ReplaceAll(file, `\`, `\\`)
-		out = strings.ReplaceAll(out, escaped, slash)
+func TestLinterLintRepositorySince(t *testing.T) {
+	if _, err := execabs.LookPath("git"); err != nil {
+		t.Skip("git command is not available")
 	}
 
-	var have interface{}
-	if err := json.Unmarshal([]byte(out), &have); err != nil {
-		t.Fatalf("output is not JSON: %v: %q", err, out)
+	root := t.TempDir()
+	git := func(args ...string) {
+		args = append([]string{"-C", root, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		if out, err := execabs.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+		}
+	}
+	dir := filepath.Join(root, ".github", "workflows")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	src := []byte("on: push\njobs:\n  test:\n    runs-on: linux-latest\n    steps:\n      - run: echo\n")
+	for _, f := range []string{"unchanged.yaml", "changed.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, f), src, 0644); err != nil {
+			t.Fatal(err)
+		}
 	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
 
-	bytes, err = os.ReadFile(filepath.Join(dir, "test.sarif"))
-	if err != nil {
-		panic(err)
+	// Modify a tracked file and add an untracked file
+	changed := append([]byte("name: changed\n"), src...)
+	if err := os.WriteFile(filepath.Join(dir, "changed.yaml"), changed, 0644); err != nil {
+		t.Fatal(err)
 	}
-	var want interface{}
-	if err := json.Unmarshal(bytes, &want); err != nil {
-		panic(err)
+	if err := os.WriteFile(filepath.Join(dir, "new.yaml"), src, 0644); err != nil {
+		t.Fatal(err)
 	}
 
-	if diff := cmp.Diff(want, have); diff != "" {
-		t.Fatal(diff)
+	for _, tc := range []struct {
+		what  string
+		since string
+		want  []string
+		log   string
+	}{
+		{"changed files", "HEAD", []string{"changed.yaml", "new.yaml"}, ""},
+		{"invalid ref", "this-ref-does-not-exist", []string{"changed.yaml", "new.yaml", "unchanged.yaml"}, "could not get files changed since"},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			var log bytes.Buffer
+			l, err := NewLinter(io.Discard, &LinterOptions{Since: tc.since, LogWriter: &log, WorkingDir: root})
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.LintRepository(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have := []string{}
+			for _, e := range errs {
+				have = append(have, filepath.Base(e.Filepath))
+			}
+			sort.Strings(have)
+			if !cmp.Equal(tc.want, have) {
+				t.Fatalf("wanted errors in %v but got errors in %v", tc.want, have)
+			}
+			if tc.log == "" && log.Len() > 0 {
+				t.Fatalf("unexpected log output: %q", log.String())
+			}
+			if !strings.Contains(log.String(), tc.log) {
+				t.Fatalf("log output %q does not contain %q", log.String(), tc.log)
+			}
+		})
 	}
 }
 
-func TestLinterLintStdinOK(t *testing.T) {
-	for _, f := range []string{"", "foo.yaml"} {
-		l, err := NewLinter(io.Discard, &LinterOptions{StdinFileName: f})
-		if err != nil {
-			t.Fatalf("creating Linter object with stdin file name %q caused error: %v", f, err)
-		}
-
-		in := []byte(`on: push
-jobs:
-  job:
-    runs-on: foo
-	steps:
-	  - run: echo`)
-		errs, err := l.LintStdin(bytes.NewReader(in))
-		if err != nil {
-			t.Fatalf("linting input with stdin file name %q caused error: %v", f, err)
-		}
-		if len(errs) != 1 {
-			t.Fatalf("unexpected number of errors with stdin file name %q: %v", f, errs)
-		}
+func TestLinterLintGitRevision(t *testing.T) {
+	if _, err := execabs.LookPath("git"); err != nil {
+		t.Skip("git command is not available")
+	}
 
-		want := f
-		if want == "" {
-			want = "<stdin>"
-		}
-		if errs[0].Filepath != want {
-			t.Fatalf("file path in the error with stdin file name %q should be %q but got %q", f, want, errs[0].Filepath)
+	root := t.TempDir()
+	git := func(args ...string) {
+		args = append([]string{"-C", root, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		if out, err := execabs.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
 		}
 	}
-}
+	dir := filepath.Join(root, ".github", "workflows")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	src := []byte("on: push\njobs:\n  test:\n    runs-on: linux-latest\n    steps:\n      - run: echo\n")
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "add workflow")
 
-func TestLinterLintStdinReadError(t *testing.T) {
-	l, err := NewLinter(io.Discard, &LinterOptions{})
-	if err != nil {
+	// The config file at the revision allows the label
+	cfg := []byte("self-hosted-runner:\n  labels: [linux-latest]\n")
+	if err := os.WriteFile(filepath.Join(root, ".github", "actionlint.yaml"), cfg, 0644); err != nil {
 		t.Fatal(err)
 	}
-	_, err = l.LintStdin(testErrorReader{})
-	if err == nil {
-		t.Fatal("error did not occur")
+	git("add", "-A")
+	git("commit", "-q", "-m", "add config")
+
+	// Files are read from the Git object database so the working tree is not necessary
+	if err := os.RemoveAll(filepath.Join(root, ".github")); err != nil {
+		t.Fatal(err)
 	}
-	want := "could not read stdin: dummy read error"
-	have := err.Error()
-	if want != have {
-		t.Fatalf("wanted error message %q but have %q", want, have)
+
+	for _, tc := range []struct {
+		what string
+		rev  string
+		want []string
+		err  string
+	}{
+		{"workflow without config", "HEAD~1", []string{".github/workflows/test.yaml:4:14"}, ""},
+		{"workflow with config", "HEAD", []string{}, ""},
+		{"invalid revision", "this-rev-does-not-exist", nil, "could not resolve revision \"this-rev-does-not-exist\" to a commit"},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{WorkingDir: root})
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.LintGitRevision(tc.rev)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatalf("wanted error %q but no error occurred", tc.err)
+				}
+				if !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("wanted error %q but got %q", tc.err, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			have := []string{}
+			for _, e := range errs {
+				have = append(have, fmt.Sprintf("%s:%d:%d", e.Filepath, e.Line, e.Column))
+			}
+			if !cmp.Equal(tc.want, have) {
+				t.Fatalf("wanted errors at %v but got errors at %v", tc.want, have)
+			}
+		})
 	}
 }
 
@@ -641,6 +1340,91 @@ func TestLinterRemoveRuleOnRulesCreatedHook(t *testing.T) {
 	}
 }
 
+type visitOrderRuleForTest struct {
+	RuleBase
+	calls *[]string
+}
+
+func (r *visitOrderRuleForTest) record(what string) {
+	*r.calls = append(*r.calls, r.Name()+":"+what)
+}
+
+func (r *visitOrderRuleForTest) VisitWorkflowPre(n *Workflow) error {
+	r.record("WorkflowPre")
+	return nil
+}
+
+func (r *visitOrderRuleForTest) VisitJobPre(n *Job) error {
+	r.record("JobPre")
+	return nil
+}
+
+func (r *visitOrderRuleForTest) VisitStep(n *Step) error {
+	r.record("Step " + n.Name.Value)
+	return nil
+}
+
+func (r *visitOrderRuleForTest) VisitJobPost(n *Job) error {
+	r.record("JobPost")
+	return nil
+}
+
+func (r *visitOrderRuleForTest) VisitWorkflowPost(n *Workflow) error {
+	r.record("WorkflowPost")
+	return nil
+}
+
+func TestLinterCustomRulesVisitOrder(t *testing.T) {
+	calls := []string{}
+	o := &LinterOptions{
+		OnRulesCreated: func(rules []Rule) []Rule {
+			return append(
+				rules,
+				&visitOrderRuleForTest{NewRuleBase("rule1", ""), &calls},
+				&visitOrderRuleForTest{NewRuleBase("rule2", ""), &calls},
+			)
+		},
+	}
+
+	l, err := NewLinter(io.Discard, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	w := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - name: a
+        run: echo
+      - name: b
+        run: echo
+`
+	if _, err := l.Lint("test.yaml", []byte(w), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"rule1:WorkflowPre",
+		"rule2:WorkflowPre",
+		"rule1:JobPre",
+		"rule2:JobPre",
+		"rule1:Step a",
+		"rule2:Step a",
+		"rule1:Step b",
+		"rule2:Step b",
+		"rule1:JobPost",
+		"rule2:JobPost",
+		"rule1:WorkflowPost",
+		"rule2:WorkflowPost",
+	}
+	if !cmp.Equal(want, calls) {
+		t.Fatal(cmp.Diff(want, calls))
+	}
+}
+
 func TestLinterGenerateDefaultConfigAlreadyExists(t *testing.T) {
 	l, err := NewLinter(io.Discard, &LinterOptions{})
 	if err != nil {
@@ -663,6 +1447,128 @@ func TestLinterGenerateDefaultConfigAlreadyExists(t *testing.T) {
 	}
 }
 
+func TestLinterFixDeprecatedCommands(t *testing.T) {
+	src := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "::set-output name=foo::bar"
+          echo "::set-env name=foo::bar"
+`
+	want := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "foo=bar" >> "$GITHUB_OUTPUT"
+          echo "::set-env name=foo::bar"
+`
+
+	d := t.TempDir()
+	files := []string{filepath.Join(d, "a.yaml"), filepath.Join(d, "b.yaml")}
+	for _, f := range files {
+		if err := os.WriteFile(f, []byte(src), 0644); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		what  string
+		files []string
+	}{
+		{"single file", files[:1]},
+		{"multiple files", files},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{Fix: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			l.defaultConfig = &Config{}
+
+			errs, err := l.LintFiles(tc.files, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(errs) != len(tc.files) {
+				t.Fatal("only set-env command should be reported but got", errs)
+			}
+			for _, e := range errs {
+				if !strings.Contains(e.Message, `"set-env"`) {
+					t.Error("unexpected error:", e)
+				}
+			}
+
+			for _, f := range tc.files {
+				b, err := os.ReadFile(f)
+				if err != nil {
+					panic(err)
+				}
+				if diff := cmp.Diff(want, string(b)); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestLinterPrintTimings(t *testing.T) {
+	var log bytes.Buffer
+	l, err := NewLinter(io.Discard, &LinterOptions{Timing: true, LogWriter: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	files := []string{
+		filepath.Join("testdata", "ok", "minimal.yaml"),
+		filepath.Join("testdata", "err", "invalid_runner_labels.yaml"),
+	}
+	errs, err := l.LintFiles(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := log.String()
+	for _, want := range []string{
+		fmt.Sprintf("Timing: 2 files, %d errors\n", len(errs)),
+		"  Rules:\n",
+		"    expression ",
+		"    runner-label ",
+		"    (total) ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("timing output does not contain %q: %q", want, out)
+		}
+	}
+}
+
+func TestLinterPrintStats(t *testing.T) {
+	var log bytes.Buffer
+	l, err := NewLinter(io.Discard, &LinterOptions{Stats: true, LogWriter: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	files := []string{
+		filepath.Join("testdata", "ok", "minimal.yaml"),
+		filepath.Join("testdata", "err", "invalid_runner_labels.yaml"),
+	}
+	errs, err := l.LintFiles(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf("%d errors in 1 file (2 files checked)\n  runner-label  %d\n", len(errs), len(errs))
+	if have := log.String(); have != want {
+		t.Fatalf("wanted %q but have %q", want, have)
+	}
+}
+
 func BenchmarkLintWorkflowFiles(b *testing.B) {
 	large := filepath.Join("testdata", "bench", "many_scripts.yaml")
 	small := filepath.Join("testdata", "bench", "small.yaml")
@@ -900,3 +1806,90 @@ func BenchmarkLintRepository(b *testing.B) {
 		}
 	}
 }
+
+func TestLinterLintRepositoryIgnoreFile(t *testing.T) {
+	root := t.TempDir()
+	testEnsureDotGitDir(root)
+	dir := filepath.Join(root, ".github", "workflows")
+	if err := os.MkdirAll(filepath.Join(dir, "legacy"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	src := []byte("on: push\njobs:\n  test:\n    runs-on: linux-latest\n    steps:\n      - run: echo\n")
+	for _, f := range []string{"ci.yaml", "old.yaml", "legacy/a.yaml", "legacy/b.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(f)), src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(root, ".actionlintignore"):          "# Legacy workflows\nold.yaml\nlegacy/\n",
+		filepath.Join(dir, "legacy", ".actionlintignore"): "!b.yaml\n",
+	}
+	for p, c := range files {
+		if err := os.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{WorkingDir: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintRepository(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := []string{}
+	for _, e := range errs {
+		have = append(have, filepath.ToSlash(e.Filepath))
+	}
+	sort.Strings(have)
+	want := []string{".github/workflows/ci.yaml", ".github/workflows/legacy/b.yaml"}
+	if !cmp.Equal(want, have) {
+		t.Fatalf("wanted errors in %v but got errors in %v", want, have)
+	}
+}
+
+func TestLinterLintRepositoryDependabot(t *testing.T) {
+	root := t.TempDir()
+	testEnsureDotGitDir(root)
+	dir := filepath.Join(root, ".github", "workflows")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(dir, "ci.yaml"):                    "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+		filepath.Join(root, ".github", "dependabot.yml"): "version: 2\nupdates:\n  - package-ecosystem: npm\n    schedule:\n      interval: cron\n      cronjob: '0 9 * * 8'\n",
+	}
+	for p, c := range files {
+		if err := os.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, enabled := range []bool{true, false} {
+		l, err := NewLinter(io.Discard, &LinterOptions{WorkingDir: root, CheckDependabot: enabled})
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs, err := l.LintRepository(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !enabled {
+			if len(errs) > 0 {
+				t.Fatal("Dependabot configuration was checked without the option:", errs)
+			}
+			continue
+		}
+		if len(errs) != 1 {
+			t.Fatal("wanted one error but got", errs)
+		}
+		e := errs[0]
+		if f := filepath.ToSlash(e.Filepath); f != ".github/dependabot.yml" {
+			t.Fatalf("unexpected file path %q", f)
+		}
+		if !strings.Contains(e.Message, "invalid CRON format") || e.Line != 6 || e.Kind != "syntax-check" {
+			t.Fatalf("unexpected error: %s", e)
+		}
+	}
+}
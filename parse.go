@@ -46,6 +46,8 @@ type workflowKeyVal struct {
 	id  string
 	key *String
 	val *yaml.Node
+	// comment is a line comment at the key or the value. Empty when the line has no comment.
+	comment string
 }
 
 type parser struct {
@@ -53,11 +55,11 @@ type parser struct {
 }
 
 func (p *parser) error(n *yaml.Node, m string) {
-	p.errors = append(p.errors, &Error{m, "", n.Line, n.Column, "syntax-check"})
+	p.errors = append(p.errors, &Error{m, "", n.Line, n.Column, -1, "syntax-check", "", ""})
 }
 
 func (p *parser) errorAt(pos *Pos, m string) {
-	p.errors = append(p.errors, &Error{m, "", pos.Line, pos.Col, "syntax-check"})
+	p.errors = append(p.errors, &Error{m, "", pos.Line, pos.Col, -1, "syntax-check", "", ""})
 }
 
 func (p *parser) errorfAt(pos *Pos, format string, args ...interface{}) {
@@ -287,7 +289,12 @@ func (p *parser) parseMapping(what string, n *yaml.Node, allowEmpty, caseSensiti
 			p.errorfAt(k.Pos, "key %q is duplicated in %s. previously defined at %s%s", k.Value, what, pos.String(), note)
 			continue
 		}
-		m = append(m, workflowKeyVal{id, k, n.Content[i+1]})
+		v := n.Content[i+1]
+		c := n.Content[i].LineComment
+		if c == "" {
+			c = v.LineComment
+		}
+		m = append(m, workflowKeyVal{id, k, v, c})
 		keys[id] = k.Pos
 	}
 
@@ -384,6 +391,7 @@ func (p *parser) parseWorkflowDispatchEvent(pos *Pos, n *yaml.Node) *WorkflowDis
 				Default:     def,
 				Type:        ty,
 				Options:     opts,
+				Comment:     input.comment,
 			}
 		}
 	}
@@ -509,6 +517,10 @@ func (p *parser) parseWorkflowCallEvent(pos *Pos, n *yaml.Node) *WorkflowCallEve
 				ret.Inputs = append(ret.Inputs, input)
 			}
 		case "secrets":
+			if kv.val.Kind == yaml.ScalarNode && kv.val.Value == "inherit" {
+				p.error(kv.val, "\"inherit\" is not available at \"secrets\" of workflow_call event. it is only available at \"secrets\" of a job calling a reusable workflow to pass all secrets of the caller")
+				continue
+			}
 			secrets := p.parseSectionMapping("secrets", kv.val, true, false)
 			ret.Secrets = make(map[string]*WorkflowCallEventSecret, len(secrets))
 			for _, kv := range secrets {
@@ -670,7 +682,7 @@ func (p *parser) parseEnv(n *yaml.Node) *Env {
 		}
 	}
 
-	m := p.parseMapping("env", n, false, false)
+	m := p.parseMapping("\"env\" section", n, false, false)
 	vars := make(map[string]*EnvVar, len(m))
 
 	for _, kv := range m {
@@ -714,8 +726,8 @@ func (p *parser) parseDefaults(pos *Pos, n *yaml.Node) *Defaults {
 }
 
 // https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#jobsjob_idconcurrency
-func (p *parser) parseConcurrency(pos *Pos, n *yaml.Node) *Concurrency {
-	ret := &Concurrency{Pos: pos}
+func (p *parser) parseConcurrency(pos *Pos, n *yaml.Node, comment string) *Concurrency {
+	ret := &Concurrency{Comment: comment, Pos: pos}
 
 	if n.Kind == yaml.ScalarNode {
 		ret.Group = p.parseString(n, false)
@@ -860,10 +872,35 @@ func (p *parser) parseMatrix(pos *Pos, n *yaml.Node) *Matrix {
 		case "exclude":
 			ret.Exclude = p.parseMatrixCombinations("exclude", kv.val)
 		default:
-			if kv.val.Kind == yaml.ScalarNode {
-				ret.Rows[kv.id] = &MatrixRow{
-					Expression: p.parseExpression(kv.val, "array value for matrix variations"),
+			switch kv.val.Kind {
+			case yaml.ScalarNode:
+				if isExprAssigned(kv.val.Value) {
+					ret.Rows[kv.id] = &MatrixRow{
+						Name:       kv.key,
+						Expression: newString(kv.val),
+					}
+					continue
 				}
+				// e.g. `os: ubuntu-latest` instead of `os: [ubuntu-latest]`
+				p.errorf(
+					kv.val,
+					"value of matrix variable %q must be an array of variations or a single ${{...}} expression but found scalar value %q. put the value in an array like \"%s: [%s]\" if this variable has only one variation",
+					kv.key.Value,
+					kv.val.Value,
+					kv.key.Value,
+					kv.val.Value,
+				)
+				// Keep the variable to avoid cascading errors on `matrix` context
+				ret.Rows[kv.id] = &MatrixRow{Name: kv.key}
+				continue
+			case yaml.MappingNode:
+				p.errorf(
+					kv.val,
+					"value of matrix variable %q must be an array of variations or a single ${{...}} expression but found mapping. put the mapping in an array like \"%s: [{...}]\" if this variable has only one variation",
+					kv.key.Value,
+					kv.key.Value,
+				)
+				ret.Rows[kv.id] = &MatrixRow{Name: kv.key}
 				continue
 			}
 
@@ -930,8 +967,12 @@ func (p *parser) parseContainer(sec string, pos *Pos, n *yaml.Node) *Container {
 			case "image":
 				ret.Image = p.parseString(kv.val, false)
 			case "credentials":
+				cs := p.parseSectionMapping("credentials", kv.val, false, true)
+				if len(cs) == 0 {
+					continue // Empty mapping was already reported
+				}
 				cred := &Credentials{Pos: kv.key.Pos}
-				for _, c := range p.parseSectionMapping("credentials", kv.val, false, true) {
+				for _, c := range cs {
 					switch c.id {
 					case "username":
 						cred.Username = p.parseString(c.val, false)
@@ -951,7 +992,7 @@ func (p *parser) parseContainer(sec string, pos *Pos, n *yaml.Node) *Container {
 			case "ports":
 				ret.Ports = p.parseStringSequence("ports", kv.val, true, false)
 			case "volumes":
-				ret.Ports = p.parseStringSequence("volumes", kv.val, true, false)
+				ret.Volumes = p.parseStringSequence("volumes", kv.val, true, false)
 			case "options":
 				ret.Options = p.parseString(kv.val, true)
 			default:
@@ -1001,6 +1042,7 @@ func (p *parser) parseTimeoutMinutes(n *yaml.Node) *Float {
 func (p *parser) parseStep(n *yaml.Node) *Step {
 	ret := &Step{Pos: posAt(n)}
 	var workDir *String
+	var usesKey, runKey *Pos
 
 	for _, kv := range p.parseMapping("element of \"steps\" section", n, false, true) {
 		switch kv.id {
@@ -1022,12 +1064,17 @@ func (p *parser) parseStep(n *yaml.Node) *Step {
 				exec = &ExecAction{}
 			} else if e, ok := ret.Exec.(*ExecAction); ok {
 				exec = e
+			} else if kv.id == "uses" && runKey != nil {
+				p.errorfAt(kv.key.Pos, "step cannot have both \"uses\" and \"run\" keys. \"run\" key is also at %s. split this step into a step to run action and a step to run shell command", runKey)
+				continue
 			} else {
 				p.errorfAt(kv.key.Pos, "this step is for running shell command since it contains at least one of \"run\", \"shell\" keys, but also contains %q key which is used for running action", kv.key.Value)
 				continue
 			}
 			if kv.id == "uses" {
+				usesKey = kv.key.Pos
 				exec.Uses = p.parseString(kv.val, false)
+				exec.UsesComment = kv.comment
 			} else {
 				// kv.key == "with"
 				with := p.parseSectionMapping("with", kv.val, false, false)
@@ -1052,14 +1099,19 @@ func (p *parser) parseStep(n *yaml.Node) *Step {
 				exec = &ExecRun{}
 			} else if e, ok := ret.Exec.(*ExecRun); ok {
 				exec = e
+			} else if kv.id == "run" && usesKey != nil {
+				p.errorfAt(kv.key.Pos, "step cannot have both \"uses\" and \"run\" keys. \"uses\" key is also at %s. split this step into a step to run action and a step to run shell command", usesKey)
+				continue
 			} else {
 				p.errorfAt(kv.key.Pos, "this step is for running action since it contains at least one of \"uses\", \"with\" keys, but also contains %q key which is used for running shell command", kv.key.Value)
 				continue
 			}
 			switch kv.id {
 			case "run":
+				runKey = kv.key.Pos
 				exec.Run = p.parseString(kv.val, false)
 				exec.RunPos = kv.key.Pos
+				exec.RunComment = kv.comment
 			case "shell":
 				exec.Shell = p.parseString(kv.val, false)
 			}
@@ -1100,7 +1152,7 @@ func (p *parser) parseStep(n *yaml.Node) *Step {
 			p.error(n, "\"run\" is required to run script in step")
 		}
 	default:
-		p.error(n, "step must run script with \"run\" section or run action with \"uses\" section")
+		p.error(n, "step must have either \"uses\" key to run action or \"run\" key to run shell command")
 	}
 
 	return ret
@@ -1171,7 +1223,7 @@ func (p *parser) parseJob(id *String, n *yaml.Node) *Job {
 	//   - jobs.<job_id>.permissions
 
 	// https://docs.github.com/en/actions/using-workflows/reusing-workflows#supported-keywords-for-jobs-that-call-a-reusable-workflow
-	var stepsOnlyKey *String
+	stepsOnlyKeys := []*String{}
 	var callOnlyKey *String
 
 	for _, kv := range p.parseMapping(fmt.Sprintf("%q job", id.Value), n, false, true) {
@@ -1189,41 +1241,42 @@ func (p *parser) parseJob(id *String, n *yaml.Node) *Job {
 			}
 		case "runs-on":
 			ret.RunsOn = p.parseRunsOn(v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "permissions":
 			ret.Permissions = p.parsePermissions(k.Pos, v)
 		case "environment":
 			ret.Environment = p.parseEnvironment(k.Pos, v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "concurrency":
-			ret.Concurrency = p.parseConcurrency(k.Pos, v)
+			ret.Concurrency = p.parseConcurrency(k.Pos, v, kv.comment)
 		case "outputs":
 			ret.Outputs = p.parseOutputs(v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "env":
 			ret.Env = p.parseEnv(v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "defaults":
 			ret.Defaults = p.parseDefaults(k.Pos, v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "if":
 			ret.If = p.parseString(v, false)
 		case "steps":
 			ret.Steps = p.parseSteps(v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "timeout-minutes":
 			ret.TimeoutMinutes = p.parseTimeoutMinutes(v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "strategy":
 			ret.Strategy = p.parseStrategy(k.Pos, v)
 		case "continue-on-error":
 			ret.ContinueOnError = p.parseBool(v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "container":
 			ret.Container = p.parseContainer("container", k.Pos, v)
-			stepsOnlyKey = k
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "services":
 			ret.Services = p.parseServices(v)
+			stepsOnlyKeys = append(stepsOnlyKeys, k)
 		case "uses":
 			call.Uses = p.parseString(v, false)
 			callOnlyKey = k
@@ -1283,14 +1336,15 @@ func (p *parser) parseJob(id *String, n *yaml.Node) *Job {
 	}
 
 	if call.Uses != nil {
-		if stepsOnlyKey != nil {
+		for _, k := range stepsOnlyKeys {
 			p.errorfAt(
-				stepsOnlyKey.Pos,
-				"when a reusable workflow is called with \"uses\", %q is not available. only following keys are allowed: \"name\", \"uses\", \"with\", \"secrets\", \"needs\", \"if\", and \"permissions\" in job %q",
-				stepsOnlyKey.Value,
+				k.Pos,
+				"when a reusable workflow is called with \"uses\", %q is not available. a job calling a reusable workflow cannot define its own steps or execution environment. only following keys are allowed: \"name\", \"uses\", \"with\", \"secrets\", \"needs\", \"if\", \"permissions\", \"strategy\", and \"concurrency\" in job %q",
+				k.Value,
 				id.Value,
 			)
-		} else {
+		}
+		if len(stepsOnlyKeys) == 0 {
 			ret.WorkflowCall = call
 		}
 	} else {
@@ -1354,7 +1408,7 @@ func (p *parser) parse(n *yaml.Node) *Workflow {
 		case "defaults":
 			w.Defaults = p.parseDefaults(k.Pos, v)
 		case "concurrency":
-			w.Concurrency = p.parseConcurrency(k.Pos, v)
+			w.Concurrency = p.parseConcurrency(k.Pos, v, kv.comment)
 		case "jobs":
 			w.Jobs = p.parseJobs(v)
 		case "run-name":
@@ -1383,6 +1437,185 @@ func (p *parser) parse(n *yaml.Node) *Workflow {
 	return w
 }
 
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#inputs
+func (p *parser) parseActionInputs(n *yaml.Node) map[string]*ActionInput {
+	inputs := p.parseSectionMapping("inputs", n, true, false)
+	ret := make(map[string]*ActionInput, len(inputs))
+	for _, kv := range inputs {
+		input := &ActionInput{Name: kv.key}
+		for _, attr := range p.parseMapping("input of action", kv.val, true, true) {
+			switch attr.id {
+			case "description":
+				input.Description = p.parseString(attr.val, true)
+			case "required":
+				input.Required = p.parseBool(attr.val)
+			case "default":
+				input.Default = p.parseString(attr.val, true)
+			case "deprecationMessage":
+				input.DeprecationMessage = p.parseString(attr.val, true)
+			default:
+				p.unexpectedKey(attr.key, "inputs", []string{"description", "required", "default", "deprecationMessage"})
+			}
+		}
+		ret[kv.id] = input
+	}
+	return ret
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#outputs-for-docker-container-and-javascript-actions
+func (p *parser) parseActionOutputs(n *yaml.Node) map[string]*ActionOutput {
+	outputs := p.parseSectionMapping("outputs", n, true, false)
+	ret := make(map[string]*ActionOutput, len(outputs))
+	for _, kv := range outputs {
+		output := &ActionOutput{Name: kv.key}
+		for _, attr := range p.parseMapping("output of action", kv.val, true, true) {
+			switch attr.id {
+			case "description":
+				output.Description = p.parseString(attr.val, true)
+			case "value":
+				output.Value = p.parseString(attr.val, false)
+			default:
+				p.unexpectedKey(attr.key, "outputs", []string{"description", "value"})
+			}
+		}
+		ret[kv.id] = output
+	}
+	return ret
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs
+func (p *parser) parseActionRuns(pos *Pos, n *yaml.Node) *ActionRuns {
+	ret := &ActionRuns{Pos: pos}
+
+	for _, kv := range p.parseSectionMapping("runs", n, false, true) {
+		switch kv.id {
+		case "using":
+			ret.Using = p.parseString(kv.val, false)
+		case "main":
+			ret.Main = p.parseString(kv.val, false)
+		case "pre":
+			ret.Pre = p.parseString(kv.val, false)
+		case "pre-if":
+			ret.PreIf = p.parseString(kv.val, false)
+		case "post":
+			ret.Post = p.parseString(kv.val, false)
+		case "post-if":
+			ret.PostIf = p.parseString(kv.val, false)
+		case "steps":
+			ret.Steps = p.parseSteps(kv.val)
+		case "image":
+			ret.Image = p.parseString(kv.val, false)
+		case "pre-entrypoint":
+			ret.PreEntrypoint = p.parseString(kv.val, false)
+		case "entrypoint":
+			ret.Entrypoint = p.parseString(kv.val, false)
+		case "post-entrypoint":
+			ret.PostEntrypoint = p.parseString(kv.val, false)
+		case "args":
+			ret.Args = p.parseStringSequence("args", kv.val, true, true)
+		case "env":
+			ret.Env = p.parseEnv(kv.val)
+		default:
+			p.unexpectedKey(kv.key, "runs", []string{
+				"using",
+				"main",
+				"pre",
+				"pre-if",
+				"post",
+				"post-if",
+				"steps",
+				"image",
+				"pre-entrypoint",
+				"entrypoint",
+				"post-entrypoint",
+				"args",
+				"env",
+			})
+		}
+	}
+
+	if ret.Using == nil {
+		p.errorAt(pos, "\"using\" is required in \"runs\" section of action metadata")
+	}
+
+	return ret
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#branding
+func (p *parser) parseActionBranding(pos *Pos, n *yaml.Node) *ActionBranding {
+	ret := &ActionBranding{Pos: pos}
+	for _, kv := range p.parseSectionMapping("branding", n, true, true) {
+		switch kv.id {
+		case "icon":
+			ret.Icon = p.parseString(kv.val, false)
+		case "color":
+			ret.Color = p.parseString(kv.val, false)
+		default:
+			p.unexpectedKey(kv.key, "branding", []string{"icon", "color"})
+		}
+	}
+	return ret
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions
+func (p *parser) parseAction(n *yaml.Node) *Action {
+	a := &Action{}
+
+	if n.Line == 0 {
+		n.Line = 1
+	}
+	if n.Column == 0 {
+		n.Column = 1
+	}
+
+	if len(n.Content) == 0 {
+		p.error(n, "action metadata is empty")
+		return a
+	}
+
+	for _, kv := range p.parseMapping("action metadata", n.Content[0], false, true) {
+		k, v := kv.key, kv.val
+		switch kv.id {
+		case "name":
+			a.Name = p.parseString(v, false)
+		case "author":
+			a.Author = p.parseString(v, true)
+		case "description":
+			a.Description = p.parseString(v, false)
+		case "inputs":
+			a.Inputs = p.parseActionInputs(v)
+		case "outputs":
+			a.Outputs = p.parseActionOutputs(v)
+		case "runs":
+			a.Runs = p.parseActionRuns(k.Pos, v)
+		case "branding":
+			a.Branding = p.parseActionBranding(k.Pos, v)
+		default:
+			p.unexpectedKey(k, "action metadata", []string{
+				"name",
+				"author",
+				"description",
+				"inputs",
+				"outputs",
+				"runs",
+				"branding",
+			})
+		}
+	}
+
+	if a.Name == nil {
+		p.error(n, "\"name\" section is missing in action metadata")
+	}
+	if a.Description == nil {
+		p.error(n, "\"description\" section is missing in action metadata")
+	}
+	if a.Runs == nil {
+		p.error(n, "\"runs\" section is missing in action metadata")
+	}
+
+	return a
+}
+
 // func dumpYAML(n *yaml.Node, level int) {
 // 	fmt.Printf("%s%s (%s, %d,%d): %q\n", strings.Repeat(". ", level), nodeKindName(n.Kind), n.Tag, n.Line, n.Column, n.Value)
 // 	for _, c := range n.Content {
@@ -1399,7 +1632,7 @@ func handleYAMLError(err error) []*Error {
 			l, _ = strconv.Atoi(ss[1])
 		}
 		msg = fmt.Sprintf("could not parse as YAML: %s", msg)
-		return &Error{msg, "", l, 0, "syntax-check"}
+		return &Error{msg, "", l, 0, -1, "syntax-check", "", ""}
 	}
 
 	if te, ok := err.(*yaml.TypeError); ok {
@@ -1431,3 +1664,20 @@ func Parse(b []byte) (*Workflow, []*Error) {
 
 	return w, p.errors
 }
+
+// ParseAction parses given source as byte sequence into action metadata syntax tree. The source is
+// a content of action.yml or action.yaml. Like Parse, it returns all errors detected while parsing
+// the input.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions
+func ParseAction(b []byte) (*Action, []*Error) {
+	var n yaml.Node
+
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return nil, handleYAMLError(err)
+	}
+
+	p := &parser{}
+	a := p.parseAction(&n)
+
+	return a, p.errors
+}
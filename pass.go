@@ -20,6 +20,17 @@ type Pass interface {
 	VisitWorkflowPost(node *Workflow) error
 }
 
+// ActionPass is an interface to traverse an action metadata syntax tree. Passes which also check
+// action metadata files such as action.yml implement this interface in addition to Pass. Only
+// VisitStep of Pass is called for the steps of composite action.
+type ActionPass interface {
+	Pass
+	// VisitActionPre is callback when visiting Action node before visiting its children. It returns internal error when it cannot continue the process
+	VisitActionPre(node *Action) error
+	// VisitActionPost is callback when visiting Action node after visiting its children. It returns internal error when it cannot continue the process
+	VisitActionPost(node *Action) error
+}
+
 // Visitor visits syntax tree from root in depth-first order
 type Visitor struct {
 	passes []Pass
@@ -46,7 +57,17 @@ func (v *Visitor) reportElapsedTime(what string, start time.Time) {
 	fmt.Fprintf(v.dbg, "[Visitor] %s took %vms\n", what, time.Since(start).Milliseconds())
 }
 
-// Visit visits given syntax tree in depth-first order
+// Visit visits given syntax tree in depth-first order. Callbacks of passes are called in the
+// following order:
+//
+//  1. VisitWorkflowPre of all passes
+//  2. VisitJobPre of all passes for each job
+//  3. VisitStep of all passes for each step of the job in the order of appearance
+//  4. VisitJobPost of all passes for the job (2. to 4. are repeated for each job)
+//  5. VisitWorkflowPost of all passes
+//
+// At each node, passes are called in the order they were added by AddPass. Note that the order of
+// visiting jobs is not defined since jobs are stored in a map.
 func (v *Visitor) Visit(n *Workflow) error {
 	var t time.Time
 	if v.dbg != nil {
@@ -147,3 +168,57 @@ func (v *Visitor) visitStep(n *Step) error {
 
 	return nil
 }
+
+// VisitAction visits given action metadata syntax tree in depth-first order. Only passes which
+// implement ActionPass are called. Other passes are skipped since they assume a workflow.
+func (v *Visitor) VisitAction(n *Action) error {
+	var t time.Time
+	if v.dbg != nil {
+		t = time.Now()
+	}
+
+	passes := make([]ActionPass, 0, len(v.passes))
+	for _, p := range v.passes {
+		if p, ok := p.(ActionPass); ok {
+			passes = append(passes, p)
+		}
+	}
+
+	for _, p := range passes {
+		if err := p.VisitActionPre(n); err != nil {
+			return err
+		}
+	}
+
+	if v.dbg != nil {
+		v.reportElapsedTime("VisitActionPre", t)
+		t = time.Now()
+	}
+
+	if n.Runs != nil {
+		for _, s := range n.Runs.Steps {
+			for _, p := range passes {
+				if err := p.VisitStep(s); err != nil {
+					return err
+				}
+			}
+		}
+
+		if v.dbg != nil {
+			v.reportElapsedTime(fmt.Sprintf("Visiting %d steps in action", len(n.Runs.Steps)), t)
+			t = time.Now()
+		}
+	}
+
+	for _, p := range passes {
+		if err := p.VisitActionPost(n); err != nil {
+			return err
+		}
+	}
+
+	if v.dbg != nil {
+		v.reportElapsedTime("VisitActionPost", t)
+	}
+
+	return nil
+}
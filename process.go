@@ -2,10 +2,12 @@ package actionlint
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os/exec"
 	"sync"
+	"time"
 
 	"github.com/mattn/go-shellwords"
 	"golang.org/x/sync/errgroup"
@@ -77,6 +79,9 @@ type concurrentProcess struct {
 	ctx  context.Context
 	sema *semaphore.Weighted
 	wg   sync.WaitGroup
+	memo *processMemo
+	// timings collects time spent in processes when it is not nil.
+	timings *lintTimings
 }
 
 // newConcurrentProcess creates a new ConcurrentProcess instance. The `par` argument represents how
@@ -86,6 +91,7 @@ func newConcurrentProcess(par int) *concurrentProcess {
 	return &concurrentProcess{
 		ctx:  context.Background(),
 		sema: semaphore.NewWeighted(int64(par)),
+		memo: newProcessMemo(),
 	}
 }
 
@@ -96,12 +102,46 @@ func (proc *concurrentProcess) run(eg *errgroup.Group, exec *cmdExecution, callb
 		if err := proc.sema.Acquire(proc.ctx, 1); err != nil {
 			return fmt.Errorf("could not acquire semaphore to run %q: %w", exec.cmd, err)
 		}
+		var start time.Time
+		if proc.timings != nil {
+			start = time.Now()
+		}
 		stdout, err := exec.run()
+		if proc.timings != nil {
+			proc.timings.addCommand(exec.cmd, time.Since(start))
+		}
 		proc.sema.Release(1)
 		return callback(stdout, err)
 	})
 }
 
+// runMemoized runs the command execution like run method, but the process runs only once for the
+// same command line and stdin. The other executions wait for the first process and the callback
+// is called with its result.
+func (proc *concurrentProcess) runMemoized(eg *errgroup.Group, exec *cmdExecution, callback func([]byte, error) error) {
+	if proc.memo == nil {
+		proc.run(eg, exec, callback)
+		return
+	}
+
+	e, first := proc.memo.start(exec)
+	if !first {
+		proc.wg.Add(1)
+		eg.Go(func() error {
+			defer proc.wg.Done()
+			<-e.done
+			return callback(e.stdout, e.err)
+		})
+		return
+	}
+
+	proc.run(eg, exec, func(stdout []byte, err error) error {
+		e.stdout, e.err = stdout, err
+		close(e.done)
+		return callback(stdout, err)
+	})
+}
+
 // wait waits all goroutines started by this concurrentProcess instance finish.
 func (proc *concurrentProcess) wait() {
 	proc.wg.Wait() // Wait for all goroutines completing to shutdown
@@ -140,6 +180,61 @@ func resolveExternalCommand(exe string) (string, []string, error) {
 	return "", nil, err
 }
 
+// processMemo memoizes results of processes in memory while linting workflows. When the same
+// script is checked repeatedly (e.g. the same `run:` in many jobs or workflows), the external
+// command like shellcheck runs only once. Unlike shellcheckCache, the results are not persisted and
+// it is used even if the on-disk cache is disabled.
+type processMemo struct {
+	mu      sync.Mutex
+	entries map[string]*processMemoEntry
+	hits    int
+	misses  int
+}
+
+type processMemoEntry struct {
+	// done is closed when the process finishes. stdout and err are available after that.
+	done   chan struct{}
+	stdout []byte
+	err    error
+}
+
+func newProcessMemo() *processMemo {
+	return &processMemo{entries: map[string]*processMemoEntry{}}
+}
+
+// start returns the entry for the command execution. The second return value is true when the
+// execution is the first one and the caller must run the process and complete the entry.
+func (m *processMemo) start(exec *cmdExecution) (*processMemoEntry, bool) {
+	h := sha256.New()
+	h.Write([]byte(exec.cmd))
+	for _, a := range exec.args {
+		h.Write([]byte{0})
+		h.Write([]byte(a))
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(exec.stdin))
+	key := string(h.Sum(nil))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.hits++
+		return e, false
+	}
+	m.misses++
+	e := &processMemoEntry{done: make(chan struct{})}
+	m.entries[key] = e
+	return e, true
+}
+
+// stats returns the number of executions which reused the memoized results and the number of
+// executions which actually ran processes.
+func (m *processMemo) stats() (int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.hits, m.misses
+}
+
 // externalCommand is struct to run specific command concurrently with concurrentProcess bounding
 // number of processes at the same time. This type manages fatal errors while running the command
 // by using errgroup.Group. The wait() method must be called at the end for checking if some fatal
@@ -163,7 +258,7 @@ func (cmd *externalCommand) run(args []string, stdin string, callback func([]byt
 		args = allArgs
 	}
 	exec := &cmdExecution{cmd.exe, args, stdin, cmd.combineOutput}
-	cmd.proc.run(&cmd.eg, exec, callback)
+	cmd.proc.runMemoized(&cmd.eg, exec, callback)
 }
 
 // wait waits until all goroutines for this command finish. Note that it does not wait for
@@ -176,6 +176,41 @@ func TestProcessInputStdin(t *testing.T) {
 	}
 }
 
+func TestProcessRunMemoized(t *testing.T) {
+	p := newConcurrentProcess(2)
+	cat := testSkipIfNoCommand(t, p, "cat")
+
+	inputs := []string{"foo", "bar", "foo", "foo", "bar"}
+	outs := make([]string, len(inputs))
+	for i, in := range inputs {
+		i := i
+		cat.run([]string{}, in, func(b []byte, err error) error {
+			if err != nil {
+				t.Error(err)
+				return err
+			}
+			outs[i] = string(b)
+			return nil
+		})
+	}
+
+	if err := cat.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+
+	for i, in := range inputs {
+		if outs[i] != in {
+			t.Errorf("output of %dth execution should be %q but got %q", i, in, outs[i])
+		}
+	}
+
+	hits, misses := p.memo.stats()
+	if hits != 3 || misses != 2 {
+		t.Fatalf("wanted 3 hits and 2 misses but got %d hits and %d misses", hits, misses)
+	}
+}
+
 func TestProcessErrorCommandNotFound(t *testing.T) {
 	p := newConcurrentProcess(1)
 	c := &externalCommand{
@@ -1,15 +1,35 @@
 package actionlint
 
 import (
+	"bytes"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+
+	"golang.org/x/sys/execabs"
 )
 
 // Project represents one GitHub project. One Git repository corresponds to one project.
 type Project struct {
 	root   string
 	config *Config
+
+	// configs caches config files in subdirectories of the project. The keys are absolute paths of
+	// the directories and nil values mean no config file is in the directories.
+	configsMu sync.Mutex
+	configs   map[string]*Config
+
+	// ignores caches .actionlintignore files in the directories of the project. The keys are absolute
+	// paths of the directories and nil values mean no ignore file is in the directories.
+	ignoresMu sync.Mutex
+	ignores   map[string]*ignoreFile
+
+	filesOnce sync.Once
+	files     []string
+	filesErr  error
 }
 
 func absPath(path string) string {
@@ -20,11 +40,16 @@ func absPath(path string) string {
 }
 
 // findProject creates new Project instance by finding a project which the given path belongs to.
-// A project must be a Git repository and have ".github/workflows" directory.
+// A project must be a Git repository and have ".github/workflows" directory. In a monorepo, the
+// ".github/workflows" directory may be put in a subdirectory of the repository.
 func findProject(path string) (*Project, error) {
 	d := absPath(path)
+	workflows := false
 	for {
 		if s, err := os.Stat(filepath.Join(d, ".github", "workflows")); err == nil && s.IsDir() {
+			workflows = true
+		}
+		if workflows {
 			if _, err := os.Stat(filepath.Join(d, ".git")); err == nil { // Note: .git may be a file
 				return NewProject(d)
 			}
@@ -45,7 +70,7 @@ func NewProject(root string) (*Project, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Project{root, c}, nil
+	return &Project{root: root, config: c}, nil
 }
 
 // RootDir returns a root directory path of the GitHub project repository.
@@ -74,6 +99,158 @@ func (p *Project) Config() *Config {
 	return p.config
 }
 
+// ConfigAt returns config object applied to the file at the given path. When some directory between
+// the file and the root directory of the project has ".github/actionlint.yaml" or
+// ".github/actionlint.yml" (e.g. a subproject in a monorepo), the nearest config file is used.
+// Otherwise this method returns the same config object as Config method.
+func (p *Project) ConfigAt(path string) (*Config, error) {
+	// Note: Calling this method must be thread safe since workflows are checked in parallel
+	p.configsMu.Lock()
+	defer p.configsMu.Unlock()
+
+	if p.configs == nil {
+		p.configs = map[string]*Config{}
+	}
+
+	d := filepath.Dir(absPath(path))
+	for d != p.root && strings.HasPrefix(d, p.root) {
+		c, ok := p.configs[d]
+		if !ok {
+			var err error
+			c, err = loadRepoConfig(d)
+			if err != nil {
+				return nil, err
+			}
+			p.configs[d] = c
+		}
+		if c != nil {
+			return c, nil
+		}
+		d = filepath.Dir(d)
+	}
+
+	return p.config, nil
+}
+
+// IsIgnored returns true when the file at the given path is excluded from linting by
+// ".actionlintignore" files. The ignore files are looked up in the directories from the root
+// directory of the project to the directory of the file. Patterns in the ignore file are relative
+// to the directory of the file and patterns in deeper ignore files take precedence.
+func (p *Project) IsIgnored(path string) (bool, error) {
+	// Note: Calling this method must be thread safe
+	p.ignoresMu.Lock()
+	defer p.ignoresMu.Unlock()
+
+	if p.ignores == nil {
+		p.ignores = map[string]*ignoreFile{}
+	}
+
+	path = absPath(path)
+	if !strings.HasPrefix(path, p.root) {
+		return false, nil
+	}
+
+	dirs := []string{}
+	for d := filepath.Dir(path); strings.HasPrefix(d, p.root); d = filepath.Dir(d) {
+		dirs = append(dirs, d)
+		if d == p.root {
+			break
+		}
+	}
+
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		d := dirs[i]
+		f, ok := p.ignores[d]
+		if !ok {
+			var err error
+			f, err = readIgnoreFile(d)
+			if err != nil {
+				return false, err
+			}
+			p.ignores[d] = f
+		}
+		if f == nil {
+			continue
+		}
+		if m, ign := f.match(path); m {
+			ignored = ign
+		}
+	}
+	return ignored, nil
+}
+
+// Files returns relative paths of all files in the project. The path separator is always '/'.
+// Files in ".git" directory are not included. The files are listed only once and the result is
+// cached.
+func (p *Project) Files() ([]string, error) {
+	// Note: Calling this method must be thread safe since workflows are checked in parallel
+	p.filesOnce.Do(func() {
+		p.files, p.filesErr = listFilesInDir(p.root)
+	})
+	return p.files, p.filesErr
+}
+
+// ChangedFilesSince returns relative paths of files which were changed since the given Git ref.
+// The path separator is always '/'. Files modified in the working tree and untracked files are also
+// included. Deleted files are not included. This method runs `git` command in the project.
+func (p *Project) ChangedFilesSince(ref string) ([]string, error) {
+	diff, err := p.git("diff", "--name-only", "--diff-filter=d", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := p.git("ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	return append(diff, untracked...), nil
+}
+
+func (p *Project) git(args ...string) ([]string, error) {
+	var stderr bytes.Buffer
+	cmd := execabs.Command("git", append([]string{"-C", p.root, "-c", "core.quotePath=false"}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("`git %s` failed in %q: %s", strings.Join(args, " "), p.root, msg)
+		}
+		return nil, fmt.Errorf("`git %s` failed in %q: %w", strings.Join(args, " "), p.root, err)
+	}
+	files := []string{}
+	for _, l := range strings.Split(string(out), "\n") {
+		if l != "" {
+			files = append(files, l)
+		}
+	}
+	return files, nil
+}
+
+func listFilesInDir(root string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		r, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(r))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list files in project %q: %w", root, err)
+	}
+	return files, nil
+}
+
 // Projects represents set of projects. It caches Project instances which was created previously
 // and reuses them.
 type Projects struct {
@@ -99,6 +99,50 @@ func TestProjectsDoesNotFindProjectFromOutside(t *testing.T) {
 	}
 }
 
+func TestProjectsFindMonorepoProject(t *testing.T) {
+	d := filepath.Join("testdata", "monorepo")
+	abs, err := filepath.Abs(d)
+	if err != nil {
+		panic(err)
+	}
+	testEnsureDotGitDir(d)
+
+	api := filepath.Join(d, "services", "api", ".github", "workflows", "test.yaml")
+	web := filepath.Join(d, "services", "web", ".github", "workflows", "test.yaml")
+
+	ps := NewProjects()
+	p, err := ps.At(api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p == nil {
+		t.Fatal("project was not found at", api)
+	}
+	if r := p.RootDir(); r != abs {
+		t.Fatalf("root directory of project should be %q but got %q", abs, r)
+	}
+
+	for _, tc := range []struct {
+		path  string
+		label string
+	}{
+		{api, "api-runner"},
+		{web, "shared-runner"},
+		{filepath.Join(d, "README.md"), "shared-runner"},
+	} {
+		c, err := p.ConfigAt(tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c == nil {
+			t.Fatal("config was not found for", tc.path)
+		}
+		if l := c.SelfHostedRunner.Labels; len(l) != 1 || l[0] != tc.label {
+			t.Fatalf("nearest config for %q should have label %q but got %q", tc.path, tc.label, l)
+		}
+	}
+}
+
 func TestProjectsLoadProjectConfig(t *testing.T) {
 	for _, n := range []string{"ok", "yml"} {
 		d := filepath.Join("testdata", "config", "projects", n)
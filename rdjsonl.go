@@ -0,0 +1,78 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Types for Reviewdog Diagnostic Format. Only the fields used by actionlint are defined.
+// https://github.com/reviewdog/reviewdog/tree/master/proto/rdf
+
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition `json:"start"`
+	End   rdjsonPosition `json:"end"`
+}
+
+type rdjsonLocation struct {
+	Path  string      `json:"path"`
+	Range rdjsonRange `json:"range"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type rdjsonDiagnostic struct {
+	Message  string         `json:"message"`
+	Location rdjsonLocation `json:"location"`
+	Severity string         `json:"severity"`
+	Source   rdjsonSource   `json:"source"`
+	Code     rdjsonCode     `json:"code"`
+}
+
+// printErrorsInRDJSONL prints the errors in reviewdog's RDJSONL format, which is one diagnostic in
+// Reviewdog Diagnostic Format per line. The end position of the range is exclusive so it is the
+// next column of the end of the error indicator (^~~~~~~). The code of each diagnostic is the rule
+// name with the link to the document of the rule.
+func printErrorsInRDJSONL(out io.Writer, errs []*ErrorTemplateFields, rules []*ruleTemplateFields, files []string) error {
+	enc := json.NewEncoder(out)
+	for _, e := range errs {
+		sev := "ERROR"
+		if e.Severity == SeverityWarning {
+			sev = "WARNING"
+		}
+		url := ""
+		if x, ok := ruleExplanations[e.Kind]; ok {
+			url = checksDocumentURL + "#" + x.anchor
+		}
+		d := &rdjsonDiagnostic{
+			Message: e.Message,
+			Location: rdjsonLocation{
+				Path: e.Filepath,
+				Range: rdjsonRange{
+					Start: rdjsonPosition{e.Line, e.Column},
+					End:   rdjsonPosition{e.Line, e.EndColumn + 1},
+				},
+			},
+			Severity: sev,
+			Source:   rdjsonSource{"actionlint", "https://github.com/rhysd/actionlint"},
+			Code:     rdjsonCode{e.Kind, url},
+		}
+		if err := enc.Encode(d); err != nil {
+			return fmt.Errorf("could not encode errors into RDJSONL: %w", err)
+		}
+	}
+	return nil
+}
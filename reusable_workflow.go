@@ -172,6 +172,10 @@ type LocalReusableWorkflowCache struct {
 	cache map[string]*ReusableWorkflowMetadata
 	cwd   string
 	dbg   io.Writer
+	// inherited is a set of local reusable workflow specs which are called with `secrets: inherit`
+	// in the project. It is collected only once on the first call of SecretsInherited.
+	inherited     map[string]struct{}
+	inheritedOnce sync.Once
 }
 
 func (c *LocalReusableWorkflowCache) debug(format string, args ...interface{}) {
@@ -320,6 +324,69 @@ func (c *LocalReusableWorkflowCache) WriteWorkflowCallEvent(wpath string, event
 	c.debug("Workflow call metadata from workflow %s: %v", wpath, m)
 }
 
+// SecretsInherited returns true when the reusable workflow at the 'wpath' path is called with
+// `secrets: inherit` by some workflow in the project. The 'wpath' parameter is a path to the workflow
+// file, which is relative to the current working directory or an absolute path. Workflow files in
+// the project are scanned only once and the result is cached.
+// This method is thread safe.
+func (c *LocalReusableWorkflowCache) SecretsInherited(wpath string) bool {
+	spec, ok := c.convWorkflowPathToSpec(wpath)
+	if !ok {
+		return false
+	}
+	c.inheritedOnce.Do(func() {
+		c.inherited = c.collectSecretsInherited()
+	})
+	_, ok = c.inherited[spec]
+	return ok
+}
+
+func (c *LocalReusableWorkflowCache) collectSecretsInherited() map[string]struct{} {
+	type job struct {
+		Uses    string    `yaml:"uses"`
+		Secrets yaml.Node `yaml:"secrets"`
+	}
+	type workflow struct {
+		Jobs map[string]job `yaml:"jobs"`
+	}
+
+	ret := map[string]struct{}{}
+
+	files, err := c.proj.Files()
+	if err != nil {
+		c.debug("Could not list files to find workflows inheriting secrets: %s", err)
+		return ret
+	}
+
+	for _, f := range files {
+		if ext := filepath.Ext(f); ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+		if filepath.Base(filepath.Dir(filepath.FromSlash(f))) != "workflows" {
+			continue
+		}
+
+		src, err := os.ReadFile(filepath.Join(c.proj.RootDir(), filepath.FromSlash(f)))
+		if err != nil {
+			continue
+		}
+		var w workflow
+		if err := yaml.Unmarshal(src, &w); err != nil {
+			continue // Broken workflow is reported when linting the file
+		}
+
+		for _, j := range w.Jobs {
+			if !strings.HasPrefix(j.Uses, "./") || j.Secrets.Kind != yaml.ScalarNode || j.Secrets.Value != "inherit" {
+				continue
+			}
+			ret[j.Uses] = struct{}{}
+			c.debug("Reusable workflow %s is called with secrets: inherit in %s", j.Uses, f)
+		}
+	}
+
+	return ret
+}
+
 func parseReusableWorkflowMetadata(src []byte) (*ReusableWorkflowMetadata, error) {
 	type workflow struct {
 		On yaml.Node `yaml:"on"`
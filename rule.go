@@ -3,6 +3,7 @@ package actionlint
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
 // RuleBase is a struct to be a base of rule structs. Embed this struct to define default methods
@@ -96,6 +97,42 @@ func (r *RuleBase) Config() *Config {
 	return r.config
 }
 
+// isIgnoredByComment returns whether the comment suppresses errors of this rule. See ignoreComment
+// for the format of the comment.
+func (r *RuleBase) isIgnoredByComment(comment string) bool {
+	return hasIgnoreComment(comment, r.name)
+}
+
+// ignoreCommentPrefix is a prefix of comments to suppress errors of rules.
+const ignoreCommentPrefix = "actionlint-ignore-"
+
+// ignoreComment returns the comment to suppress errors of the rule like "actionlint-ignore-secret-print".
+// Some rules accept the comment at the line of the section where the error is reported such as
+// "run:" or "uses:".
+func ignoreComment(rule string) string {
+	return ignoreCommentPrefix + rule
+}
+
+// hasIgnoreComment returns whether the comment contains the comment to suppress errors of the rule.
+// The rule name must not be followed by other characters of rule names so that a comment for
+// "action-permissions" rule does not suppress errors of "action" rule.
+func hasIgnoreComment(comment, rule string) bool {
+	c := ignoreComment(rule)
+	for {
+		i := strings.Index(comment, c)
+		if i < 0 {
+			return false
+		}
+		comment = comment[i+len(c):]
+		if comment == "" {
+			return true
+		}
+		if b := comment[0]; b != '-' && !('a' <= b && b <= 'z') && !('0' <= b && b <= '9') {
+			return true
+		}
+	}
+}
+
 // Rule is an interface which all rule structs must meet.
 type Rule interface {
 	Pass
@@ -6,10 +6,15 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
+var fullCommitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
 // BrandingColors is a set of colors allowed at branding.color in action.yaml.
 // https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingcolor
 var BrandingColors = map[string]struct{}{
@@ -300,6 +305,9 @@ func isImageOnDockerRegistry(image string) bool {
 type RuleAction struct {
 	RuleBase
 	cache *LocalActionsCache
+	// dir is a directory of the action metadata file being linted. Files referred from "runs"
+	// section are resolved from this directory.
+	dir string
 }
 
 // NewRuleAction creates new RuleAction instance.
@@ -328,12 +336,14 @@ func (rule *RuleAction) VisitStep(n *Step) error {
 	spec := e.Uses.Value
 
 	if strings.HasPrefix(spec, "./") {
-		// Relative to repository root
+		// Relative to repository root. Local actions are not restricted by "allowed-actions" and
+		// "denied-actions" since they are maintained in the same repository as the workflow
 		rule.checkLocalAction(spec, e)
 		return nil
 	}
 
 	if strings.HasPrefix(spec, "docker://") {
+		rule.checkActionPolicy(dockerActionImage(spec), e)
 		rule.checkDockerAction(spec, e)
 		return nil
 	}
@@ -365,10 +375,18 @@ func (rule *RuleAction) checkRepoAction(spec string, exec *ExecAction) {
 	repo := s
 	if idx := strings.IndexRune(s, '/'); idx >= 0 {
 		repo = s[:idx]
+		if why := invalidActionPath(s[idx+1:]); why != "" {
+			rule.invalidActionFormat(exec.Uses.Pos, spec, why)
+			return
+		}
 	}
 
 	if owner == "" || repo == "" || ref == "" {
 		rule.invalidActionFormat(exec.Uses.Pos, spec, "owner and repo and ref should not be empty")
+	} else {
+		rule.checkActionPolicy(owner+"/"+repo, exec)
+		rule.checkPinnedAction(owner, repo, ref, exec)
+		rule.checkInputTypes(strings.ToLower(spec[:len(spec)-len(ref)-1]), exec)
 	}
 
 	meta, ok := PopularActions[spec]
@@ -390,6 +408,232 @@ func (rule *RuleAction) checkRepoAction(spec string, exec *ExecAction) {
 	})
 }
 
+// builtinActionInputTypes is a table of types of inputs of well-known actions. The keys are
+// "{owner}/{repo}" or "{owner}/{repo}/{path}" in lower case and the input names are in lower case.
+// Inputs of string type are not listed since any value is valid for them.
+var builtinActionInputTypes = map[string]map[string]string{
+	"actions/cache": {
+		"enablecrossosarchive": "boolean",
+		"fail-on-cache-miss":   "boolean",
+		"lookup-only":          "boolean",
+	},
+	"actions/cache/restore": {
+		"enablecrossosarchive": "boolean",
+		"fail-on-cache-miss":   "boolean",
+		"lookup-only":          "boolean",
+	},
+	"actions/checkout": {
+		"clean":               "boolean",
+		"fetch-depth":         "number",
+		"fetch-tags":          "boolean",
+		"lfs":                 "boolean",
+		"persist-credentials": "boolean",
+		"set-safe-directory":  "boolean",
+		"show-progress":       "boolean",
+	},
+	"actions/download-artifact": {
+		"merge-multiple": "boolean",
+	},
+	"actions/github-script": {
+		"debug":   "boolean",
+		"retries": "number",
+	},
+	"actions/setup-go": {
+		"cache":        "boolean",
+		"check-latest": "boolean",
+	},
+	"actions/setup-java": {
+		"check-latest":       "boolean",
+		"overwrite-settings": "boolean",
+	},
+	"actions/setup-node": {
+		"check-latest": "boolean",
+	},
+	"actions/setup-python": {
+		"allow-prereleases":  "boolean",
+		"check-latest":       "boolean",
+		"update-environment": "boolean",
+	},
+	"actions/upload-artifact": {
+		"compression-level":    "number",
+		"include-hidden-files": "boolean",
+		"overwrite":            "boolean",
+		"retention-days":       "number",
+	},
+}
+
+// checkInputTypes checks the literal values at `with:` match to the types of the inputs of the
+// action when "check-action-input-types" is enabled in the config. The types are looked up from the
+// built-in table and "action-input-types" in the config. Boolean inputs are usually parsed with
+// core.getBooleanInput() of @actions/core which accepts only the boolean values in YAML 1.2 core schema.
+func (rule *RuleAction) checkInputTypes(action string, exec *ExecAction) {
+	cfg := rule.Config()
+	if cfg == nil || !cfg.CheckActionInputTypes {
+		return
+	}
+	for id, i := range exec.Inputs {
+		if i.Value == nil || i.Value.ContainsExpression() {
+			continue
+		}
+		v := i.Value.Value
+		switch cfg.actionInputType(action, id) {
+		case "boolean":
+			switch v {
+			case "true", "True", "TRUE", "false", "False", "FALSE":
+			default:
+				rule.Errorf(
+					i.Value.Pos,
+					"input %q of action %q is boolean but its value %q is not a boolean value. set \"true\" or \"false\"",
+					i.Name.Value,
+					exec.Uses.Value,
+					v,
+				)
+			}
+		case "number":
+			if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+				rule.Errorf(
+					i.Value.Pos,
+					"input %q of action %q is number but its value %q is not a number",
+					i.Name.Value,
+					exec.Uses.Value,
+					v,
+				)
+			}
+		}
+	}
+}
+
+// checkPinnedAction checks the action is pinned to a full length commit SHA when "require-pinned-actions"
+// is enabled in the config. Tags and branches can be moved to other commits by the owner of the action.
+// https://docs.github.com/en/actions/security-guides/security-hardening-for-github-actions#using-third-party-actions
+func (rule *RuleAction) checkPinnedAction(owner, repo, ref string, exec *ExecAction) {
+	cfg := rule.Config()
+	if cfg == nil || !cfg.RequirePinnedActions {
+		return
+	}
+	if fullCommitSHAPattern.MatchString(ref) {
+		return
+	}
+	// Actions owned by GitHub are trusted
+	if strings.EqualFold(owner, "actions") || strings.EqualFold(owner, "github") {
+		return
+	}
+	name := owner + "/" + repo
+	for _, p := range cfg.TrustedActions {
+		if matchActionPattern(p, name) {
+			return
+		}
+	}
+	if rule.isIgnoredByComment(exec.UsesComment) {
+		return
+	}
+	path, _, _ := strings.Cut(exec.Uses.Value, "@")
+	rule.Errorf(
+		exec.Uses.Pos,
+		"action %q is not pinned to a full length commit SHA. ref %q is a tag or a branch which can be moved to another commit. pin the action like \"%s@<commit SHA>\" or add it to \"trusted-actions\" in the config file",
+		exec.Uses.Value,
+		ref,
+		path,
+	)
+}
+
+// checkActionPolicy checks the action is allowed by "allowed-actions" and is not denied by
+// "denied-actions" in the config. The name is "{owner}/{repo}" or "docker://{image}".
+func (rule *RuleAction) checkActionPolicy(name string, exec *ExecAction) {
+	cfg := rule.Config()
+	if cfg == nil {
+		return
+	}
+	for _, p := range cfg.DeniedActions {
+		if matchActionPattern(p, name) {
+			rule.Errorf(
+				exec.Uses.Pos,
+				"action %q is not allowed to be used since %q matches to pattern %q in \"denied-actions\" in the config file",
+				exec.Uses.Value,
+				name,
+				p,
+			)
+			return
+		}
+	}
+	if len(cfg.AllowedActions) == 0 {
+		return
+	}
+	for _, p := range cfg.AllowedActions {
+		if matchActionPattern(p, name) {
+			return
+		}
+	}
+	rule.Errorf(
+		exec.Uses.Pos,
+		"action %q is not allowed to be used since %q matches to none of patterns in \"allowed-actions\" in the config file. allowed patterns are %s",
+		exec.Uses.Value,
+		name,
+		quotes(cfg.AllowedActions),
+	)
+}
+
+// matchActionPattern returns true when the action name such as "{owner}/{repo}" matches to the glob
+// pattern in "trusted-actions", "allowed-actions", or "denied-actions" in the config. They are compared
+// case-insensitively since owners and repositories on GitHub are so.
+func matchActionPattern(pattern, name string) bool {
+	// Glob patterns were validated in `ParseConfig()`
+	return doublestar.MatchUnvalidated(strings.ToLower(pattern), strings.ToLower(name))
+}
+
+// dockerImageRef is a reference to a Docker image like "ghcr.io/owner/image:tag@sha256:...".
+type dockerImageRef struct {
+	// name is the image name including the registry like "ghcr.io/owner/image".
+	name string
+	// tag is the tag of the image. It is empty when the tag is omitted or empty.
+	tag string
+	// digest is the digest of the image like "sha256:...". It is empty when the digest is omitted or empty.
+	digest string
+	// hasTag is true when the reference has ':' separator for tag even if the tag is empty.
+	hasTag bool
+	// hasDigest is true when the reference has '@' separator for digest even if the digest is empty.
+	hasDigest bool
+}
+
+// parseDockerImageRef parses the image reference in the form of "[registry[:port]/]name[:tag][@digest]".
+// Note that ':' in the registry part is a port separator, not a tag separator.
+// https://github.com/distribution/reference
+func parseDockerImageRef(ref string) *dockerImageRef {
+	r := &dockerImageRef{}
+	if i := strings.IndexRune(ref, '@'); i >= 0 {
+		r.digest = ref[i+1:]
+		r.hasDigest = true
+		ref = ref[:i]
+	}
+	if i := strings.LastIndexByte(ref, ':'); i > strings.LastIndexByte(ref, '/') {
+		r.tag = ref[i+1:]
+		r.hasTag = true
+		ref = ref[:i]
+	}
+	r.name = ref
+	return r
+}
+
+// dockerActionImage returns "docker://{image}" without tag and digest from the spec of Docker action
+// like "docker://ghcr.io/owner/image:tag".
+func dockerActionImage(spec string) string {
+	return "docker://" + parseDockerImageRef(strings.TrimPrefix(spec, "docker://")).name
+}
+
+// invalidActionPath returns the reason why the {path} part of "{owner}/{repo}/{path}@{ref}" is invalid.
+// An empty string is returned when the path is valid.
+func invalidActionPath(path string) string {
+	for _, s := range strings.Split(path, "/") {
+		switch s {
+		case "":
+			return fmt.Sprintf("path %q in the repository should not contain empty segment", path)
+		case ".", "..":
+			return fmt.Sprintf("path %q in the repository should not contain %q segment", path, s)
+		}
+	}
+	return ""
+}
+
 func (rule *RuleAction) invalidActionFormat(pos *Pos, spec string, why string) {
 	rule.Errorf(pos, "specifying action %q in invalid format because %s. available formats are \"{owner}/{repo}@{ref}\" or \"{owner}/{repo}/{path}@{ref}\"", spec, why)
 }
@@ -452,6 +696,63 @@ func (rule *RuleAction) checkLocalCompositeActionRuns(r *ActionMetadataRuns, dir
 		rule.missingRunsProp(pos, "steps", "Composite", name, dir)
 	}
 	rule.checkInvalidRunsProps(pos, r, "Composite", name, dir, []string{"main", "pre", "pre-if", "post", "post-if", "image", "pre-entrypoint", "entrypoint", "post-entrypoint", "args", "env"})
+	rule.checkCompositeStepIDs(r.Steps, dir, name, pos)
+	rule.checkCompositeStepShells(r.Steps, dir, name, pos)
+}
+
+// checkCompositeStepIDs checks step IDs in the composite action are unique. Steps in a composite
+// action are in a different scope from steps in the workflow using the action.
+func (rule *RuleAction) checkCompositeStepIDs(steps []any, dir, name string, pos *Pos) {
+	seen := map[string]int{}
+	for i, s := range steps {
+		m, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		id, ok := m["id"].(string)
+		if !ok || id == "" || ContainsExpression(id) {
+			continue
+		}
+		k := strings.ToLower(id)
+		if j, ok := seen[k]; ok {
+			rule.Errorf(
+				pos,
+				"step ID %q of %s step duplicates the ID of %s step in \"runs.steps\" section in %q action. step ID must be unique within a composite action. note that step ID is case insensitive. the action is defined at %q",
+				id,
+				ordinal(i+1),
+				ordinal(j+1),
+				name,
+				dir,
+			)
+			continue
+		}
+		seen[k] = i
+	}
+}
+
+// checkCompositeStepShells checks "shell" is set at all "run" steps in the composite action. Unlike
+// steps in workflows, default shell is not available in composite actions.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runsstepsshell
+func (rule *RuleAction) checkCompositeStepShells(steps []any, dir, name string, pos *Pos) {
+	for i, s := range steps {
+		m, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, ok := m["run"]; !ok {
+			continue
+		}
+		if _, ok := m["shell"]; ok {
+			continue
+		}
+		rule.Errorf(
+			pos,
+			"\"shell\" is missing at %s step in \"runs.steps\" section in %q action. \"shell\" is required for \"run\" step in composite action since default shell is not available. set \"shell: bash\" for example. the action is defined at %q",
+			ordinal(i+1),
+			name,
+			dir,
+		)
+	}
 }
 
 // https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-javascript-actions
@@ -484,7 +785,8 @@ func (rule *RuleAction) checkLocalActionRuns(meta *ActionMetadata, pos *Pos) {
 		rule.checkLocalDockerActionRuns(r, meta.Dir(), meta.Name, pos)
 	case "composite":
 		rule.checkLocalCompositeActionRuns(r, meta.Dir(), meta.Name, pos)
-	case "node20":
+	case "node20", "node16", "node12":
+		// Deprecated runtimes are reported by "node-runtime" rule
 		rule.checkLocalJavaScriptActionRuns(r, meta.Dir(), meta.Name, pos)
 	default:
 		rule.Errorf(pos, `invalid runner name %q at runs.using in %q action defined at %q. valid runners are "composite", "docker", and "node20". see https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs`, r.Using, meta.Name, meta.Dir())
@@ -497,17 +799,9 @@ func (rule *RuleAction) checkLocalActionRuns(meta *ActionMetadata, pos *Pos) {
 }
 
 // https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#example-using-the-github-packages-container-registry
-func (rule *RuleAction) checkDockerAction(uri string, exec *ExecAction) {
-	tag := ""
-	tagExists := false
-	if idx := strings.IndexRune(uri[len("docker://"):], ':'); idx != -1 {
-		idx += len("docker://")
-		if idx < len(uri) {
-			tag = uri[idx+1:]
-			uri = uri[:idx]
-			tagExists = true
-		}
-	}
+func (rule *RuleAction) checkDockerAction(spec string, exec *ExecAction) {
+	ref := parseDockerImageRef(spec[len("docker://"):])
+	uri := "docker://" + ref.name
 
 	if _, err := url.Parse(uri); err != nil {
 		rule.Errorf(
@@ -515,12 +809,38 @@ func (rule *RuleAction) checkDockerAction(uri string, exec *ExecAction) {
 			"URI for Docker container %q is invalid: %s (tag=%s)",
 			uri,
 			err.Error(),
-			tag,
+			ref.tag,
 		)
 	}
 
-	if tagExists && tag == "" {
+	if ref.hasTag && ref.tag == "" {
 		rule.Errorf(exec.Uses.Pos, "tag of Docker action should not be empty: %q", uri)
+		return
+	}
+	if ref.hasDigest && ref.digest == "" {
+		rule.Errorf(exec.Uses.Pos, "digest of Docker action should not be empty: %q", uri)
+		return
+	}
+
+	if !ref.hasTag && !ref.hasDigest {
+		rule.Errorf(
+			exec.Uses.Pos,
+			"Docker image of action %q has neither tag nor digest. \"latest\" tag is implicitly used and the image may change at any time. pin the image with tag like \"%s:{tag}\" or with digest like \"%s@sha256:{digest}\"",
+			spec,
+			uri,
+			uri,
+		)
+		return
+	}
+
+	if cfg := rule.Config(); cfg != nil && cfg.RequireDockerActionDigest && !ref.hasDigest {
+		rule.Errorf(
+			exec.Uses.Pos,
+			"Docker image of action %q is not pinned to a digest. tag %q can be moved to another image. pin the image with digest like \"%s@sha256:{digest}\" since \"require-docker-action-digest\" is enabled in the config file",
+			spec,
+			ref.tag,
+			uri,
+		)
 	}
 }
 
@@ -565,6 +885,9 @@ func (rule *RuleAction) checkLocalAction(spec string, action *ExecAction) {
 		return
 	}
 	if meta == nil {
+		if err := rule.cache.checkLocalActionPath(spec); err != nil {
+			rule.Error(action.Uses.Pos, err.Error())
+		}
 		return
 	}
 
@@ -617,3 +940,159 @@ func (rule *RuleAction) checkAction(meta *ActionMetadata, exec *ExecAction, desc
 		}
 	}
 }
+
+// VisitActionPre is callback when visiting Action node before visiting its children. It checks the
+// action metadata file such as action.yml linted directly.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions
+func (rule *RuleAction) VisitActionPre(n *Action) error {
+	if n.Branding != nil {
+		if i := n.Branding.Icon; i != nil && !i.ContainsExpression() {
+			if _, ok := BrandingIcons[strings.ToLower(i.Value)]; !ok {
+				rule.Errorf(i.Pos, "incorrect icon name %q at branding.icon. see the official document to know the exhaustive list of supported icons: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingicon", i.Value)
+			}
+		}
+		if c := n.Branding.Color; c != nil && !c.ContainsExpression() {
+			if _, ok := BrandingColors[strings.ToLower(c.Value)]; !ok {
+				rule.Errorf(c.Pos, "incorrect color %q at branding.color. see the official document to know the exhaustive list of supported colors: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingcolor", c.Value)
+			}
+		}
+	}
+
+	r := n.Runs
+	if r == nil || r.Using == nil {
+		return nil // Missing "runs" or "runs.using" was reported by parser
+	}
+
+	composite := false
+	switch r.Using.Value {
+	case "docker":
+		rule.checkDockerActionRuns(r)
+	case "composite":
+		rule.checkCompositeActionRuns(r)
+		composite = true
+	case "node20", "node16", "node12":
+		// Deprecated runtimes are reported by "node-runtime" rule
+		rule.checkJavaScriptActionRuns(r)
+	default:
+		rule.Errorf(r.Using.Pos, `invalid runner name %q at runs.using. valid runners are "composite", "docker", and "node20". see https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs`, r.Using.Value)
+		// Probably invalid version of Node.js runner. Assume it is JavaScript action to find as many errors as possible
+		if strings.HasPrefix(r.Using.Value, "node") {
+			rule.checkJavaScriptActionRuns(r)
+		}
+	}
+
+	for _, o := range n.Outputs {
+		if composite && o.Value == nil {
+			rule.Errorf(o.Name.Pos, "\"value\" is required at %q output of composite action", o.Name.Value)
+		}
+		if !composite && o.Value != nil {
+			rule.Errorf(o.Value.Pos, "\"value\" is not available at %q output because the action is not a composite action. outputs of JavaScript and Docker actions are set by the action at runtime", o.Name.Value)
+		}
+	}
+
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleAction) VisitActionPost(n *Action) error {
+	return nil
+}
+
+func (rule *RuleAction) checkActionRunsFileExists(s *String, prop string) {
+	if s == nil || s.Value == "" || s.ContainsExpression() {
+		return
+	}
+	f := filepath.FromSlash(s.Value)
+	if _, err := os.Stat(filepath.Join(rule.dir, f)); errors.Is(err, os.ErrNotExist) {
+		rule.Errorf(s.Pos, "file %q does not exist in %q. it is specified at %q key in \"runs\" section", f, rule.dir, prop)
+	}
+}
+
+func (rule *RuleAction) checkInvalidActionRunsProps(r *ActionRuns, ty string, props []string) {
+	for _, prop := range props {
+		var pos *Pos
+		switch prop {
+		case "main":
+			pos = posOfString(r.Main)
+		case "pre":
+			pos = posOfString(r.Pre)
+		case "pre-if":
+			pos = posOfString(r.PreIf)
+		case "post":
+			pos = posOfString(r.Post)
+		case "post-if":
+			pos = posOfString(r.PostIf)
+		case "image":
+			pos = posOfString(r.Image)
+		case "pre-entrypoint":
+			pos = posOfString(r.PreEntrypoint)
+		case "entrypoint":
+			pos = posOfString(r.Entrypoint)
+		case "post-entrypoint":
+			pos = posOfString(r.PostEntrypoint)
+		case "steps":
+			if r.Steps != nil {
+				pos = r.Pos
+			}
+		case "args":
+			if r.Args != nil {
+				pos = r.Pos
+			}
+		case "env":
+			if r.Env != nil {
+				pos = r.Pos
+			}
+		}
+		if pos != nil {
+			rule.Errorf(pos, "%q is not allowed in \"runs\" section because the action is a %s action", prop, ty)
+		}
+	}
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-docker-container-actions
+func (rule *RuleAction) checkDockerActionRuns(r *ActionRuns) {
+	if r.Image == nil {
+		rule.Errorf(r.Pos, "\"image\" is required in \"runs\" section because the action is a Docker action")
+	} else if !r.Image.ContainsExpression() && !isImageOnDockerRegistry(r.Image.Value) {
+		rule.checkActionRunsFileExists(r.Image, "image")
+		if filepath.Base(filepath.FromSlash(r.Image.Value)) != "Dockerfile" {
+			rule.Errorf(r.Image.Pos, "the local file %q referenced from \"image\" key must be named \"Dockerfile\"", r.Image.Value)
+		}
+	}
+	rule.checkActionRunsFileExists(r.PreEntrypoint, "pre-entrypoint")
+	rule.checkActionRunsFileExists(r.Entrypoint, "entrypoint")
+	rule.checkActionRunsFileExists(r.PostEntrypoint, "post-entrypoint")
+	rule.checkInvalidActionRunsProps(r, "Docker", []string{"main", "pre", "pre-if", "post", "post-if", "steps"})
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-composite-actions
+func (rule *RuleAction) checkCompositeActionRuns(r *ActionRuns) {
+	if r.Steps == nil {
+		rule.Errorf(r.Pos, "\"steps\" is required in \"runs\" section because the action is a composite action")
+	}
+	rule.checkInvalidActionRunsProps(r, "composite", []string{"main", "pre", "pre-if", "post", "post-if", "image", "pre-entrypoint", "entrypoint", "post-entrypoint", "args", "env"})
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-javascript-actions
+func (rule *RuleAction) checkJavaScriptActionRuns(r *ActionRuns) {
+	if r.Main == nil {
+		rule.Errorf(r.Pos, "\"main\" is required in \"runs\" section because the action is a JavaScript action")
+	}
+	rule.checkActionRunsFileExists(r.Main, "main")
+	rule.checkActionRunsFileExists(r.Pre, "pre")
+	rule.checkActionRunsFileExists(r.Post, "post")
+	if r.Pre == nil && r.PreIf != nil {
+		rule.Errorf(r.PreIf.Pos, "\"pre\" is required when \"pre-if\" is specified in \"runs\" section")
+	}
+	if r.Post == nil && r.PostIf != nil {
+		rule.Errorf(r.PostIf.Pos, "\"post\" is required when \"post-if\" is specified in \"runs\" section")
+	}
+	rule.checkInvalidActionRunsProps(r, "JavaScript", []string{"steps", "image", "pre-entrypoint", "entrypoint", "post-entrypoint", "args", "env"})
+}
+
+func posOfString(s *String) *Pos {
+	if s == nil {
+		return nil
+	}
+	return s.Pos
+}
@@ -0,0 +1,162 @@
+package actionlint
+
+import (
+	"sort"
+	"strings"
+)
+
+// builtinActionPermissions is a table of well-known actions and the permission scopes of
+// GITHUB_TOKEN they require. The keys are "{owner}/{repo}" or "{owner}/{repo}/{path}" in lower case.
+// The values map the permission scopes to "read" or "write".
+var builtinActionPermissions = map[string]map[string]string{
+	"actions/attest-build-provenance":        {"attestations": "write", "id-token": "write"},
+	"actions/deploy-pages":                   {"id-token": "write", "pages": "write"},
+	"actions/labeler":                        {"contents": "read", "pull-requests": "write"},
+	"actions/stale":                          {"issues": "write", "pull-requests": "write"},
+	"github/codeql-action/upload-sarif":      {"security-events": "write"},
+	"marocchino/sticky-pull-request-comment": {"pull-requests": "write"},
+	"ncipollo/release-action":                {"contents": "write"},
+	"peter-evans/create-pull-request":        {"contents": "write", "pull-requests": "write"},
+	"softprops/action-gh-release":            {"contents": "write"},
+}
+
+// RuleActionPermissions is a rule to check the permissions of GITHUB_TOKEN are sufficient for the
+// actions used in the job. The required permissions of actions are looked up from the built-in
+// table and "action-permissions" in the config file. This rule is enabled by
+// "check-action-permissions" in the config file. When `permissions:` is set at neither workflow
+// nor job, the permissions depend on the repository settings so they are not checked.
+// https://docs.github.com/en/actions/security-for-github-actions/security-guides/automatic-token-authentication#permissions-for-the-github_token
+type RuleActionPermissions struct {
+	RuleBase
+	workflowPerms *Permissions
+	jobPerms      *Permissions
+	jobID         string
+}
+
+// NewRuleActionPermissions creates new RuleActionPermissions instance.
+func NewRuleActionPermissions() *RuleActionPermissions {
+	return &RuleActionPermissions{
+		RuleBase: RuleBase{
+			name: "action-permissions",
+			desc: "Checks for permissions of GITHUB_TOKEN insufficient for actions used in jobs when \"check-action-permissions\" is enabled",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleActionPermissions) VisitWorkflowPre(n *Workflow) error {
+	rule.workflowPerms = n.Permissions
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleActionPermissions) VisitJobPre(n *Job) error {
+	rule.jobPerms = n.Permissions
+	if n.ID != nil {
+		rule.jobID = n.ID.Value
+	}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleActionPermissions) VisitJobPost(n *Job) error {
+	rule.jobPerms = nil
+	rule.jobID = ""
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleActionPermissions) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecAction)
+	if !ok || e.Uses == nil || e.Uses.ContainsExpression() {
+		return nil
+	}
+
+	cfg := rule.Config()
+	if cfg == nil || !cfg.CheckActionPermissions {
+		return nil
+	}
+
+	// Effective permissions. Job-level permissions override workflow-level permissions.
+	perms, where := rule.jobPerms, "job \""+rule.jobID+"\""
+	if perms == nil {
+		perms, where = rule.workflowPerms, "workflow"
+	}
+	if perms == nil {
+		return nil
+	}
+
+	spec := e.Uses.Value
+	if i := strings.IndexByte(spec, '@'); i >= 0 {
+		spec = spec[:i]
+	}
+	required := cfg.actionPermissions(strings.ToLower(spec))
+	if len(required) == 0 {
+		return nil
+	}
+
+	scopes := make([]string, 0, len(required))
+	for s := range required {
+		scopes = append(scopes, s)
+	}
+	sort.Strings(scopes)
+
+	for _, scope := range scopes {
+		want := required[scope]
+		have, ok := permissionOf(perms, scope)
+		if !ok || permissionSatisfies(have, want) {
+			continue
+		}
+		rule.Errorf(
+			e.Uses.Pos,
+			"action %q requires %q permission but it is %q in \"permissions:\" of %s at %s. the action will fail due to insufficient permission of GITHUB_TOKEN. add \"%s: %s\" to the \"permissions:\"",
+			e.Uses.Value,
+			scope+": "+want,
+			have,
+			where,
+			perms.Pos,
+			scope,
+			want,
+		)
+	}
+
+	return nil
+}
+
+// permissionOf returns the permission value of the scope. When the permission scope is not listed
+// in the permissions, "none" is returned since unlisted scopes have no access. It returns false
+// when the permission is unknown because it is set by ${{ }} or invalid.
+func permissionOf(p *Permissions, scope string) (string, bool) {
+	if p.All != nil {
+		switch p.All.Value {
+		case "write-all":
+			return "write", true
+		case "read-all":
+			return "read", true
+		default:
+			return "", false // ${{ }} or invalid value which is reported by RulePermissions
+		}
+	}
+	s, ok := p.Scopes[scope]
+	if !ok || s.Value == nil {
+		return "none", true
+	}
+	switch v := s.Value.Value; v {
+	case "read", "write", "none":
+		return v, true
+	default:
+		return "", false // ${{ }} or invalid value which is reported by RulePermissions
+	}
+}
+
+// permissionSatisfies returns true when the permission value has the required access.
+func permissionSatisfies(have, want string) bool {
+	switch have {
+	case "write":
+		return true
+	case "read":
+		return want == "read"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,175 @@
+package actionlint
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// artifactAction is a step using actions/upload-artifact or actions/download-artifact.
+type artifactAction struct {
+	uses  *String
+	major int
+	name  *String
+}
+
+// incompatibleWith returns true when artifacts cannot be shared between the two versions of the
+// artifact actions. v4 or later and v3 or earlier use different backends.
+// https://github.com/actions/upload-artifact#breaking-changes
+func (a *artifactAction) incompatibleWith(other *artifactAction) bool {
+	return (a.major >= 4) != (other.major >= 4)
+}
+
+// mayShareArtifact returns true when the two actions may refer the same artifact. When the name of
+// artifact is not a literal, it is unknown so they may refer the same artifact.
+func (a *artifactAction) mayShareArtifact(other *artifactAction) bool {
+	if a.name == nil || other.name == nil || a.name.ContainsExpression() || other.name.ContainsExpression() {
+		return true
+	}
+	return a.name.Value == other.name.Value
+}
+
+// RuleArtifact is a rule to check usage of actions/upload-artifact and actions/download-artifact.
+// It detects the incompatible major versions of them in the same workflow and non-unique artifact
+// names uploaded from matrix jobs by v4 or later.
+type RuleArtifact struct {
+	RuleBase
+	job       *Job
+	uploads   []*artifactAction
+	downloads []*artifactAction
+}
+
+// NewRuleArtifact creates new RuleArtifact instance.
+func NewRuleArtifact() *RuleArtifact {
+	return &RuleArtifact{
+		RuleBase: RuleBase{
+			name: "artifact",
+			desc: "Checks for incompatible versions of actions/upload-artifact and actions/download-artifact and non-unique artifact names in matrix jobs",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleArtifact) VisitWorkflowPre(n *Workflow) error {
+	rule.uploads = nil
+	rule.downloads = nil
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleArtifact) VisitJobPre(n *Job) error {
+	rule.job = n
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleArtifact) VisitJobPost(n *Job) error {
+	rule.job = nil
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleArtifact) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecAction)
+	if !ok || e.Uses == nil {
+		return nil
+	}
+
+	spec := strings.ToLower(e.Uses.Value)
+	var upload bool
+	switch {
+	case strings.HasPrefix(spec, "actions/upload-artifact@"):
+		upload = true
+	case strings.HasPrefix(spec, "actions/download-artifact@"):
+		upload = false
+	default:
+		return nil
+	}
+
+	major, ok := parseMajorVersion(spec[strings.IndexByte(spec, '@')+1:])
+	if !ok {
+		rule.Debug("Version of %q is unknown. Skip checking the artifact action", e.Uses.Value)
+		return nil
+	}
+
+	a := &artifactAction{uses: e.Uses, major: major}
+	if i, ok := e.Inputs["name"]; ok {
+		a.name = i.Value
+	}
+
+	if !upload {
+		rule.downloads = append(rule.downloads, a)
+		return nil
+	}
+
+	rule.uploads = append(rule.uploads, a)
+	rule.checkUploadInMatrix(a, e)
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleArtifact) VisitWorkflowPost(n *Workflow) error {
+	// Jobs are visited in random order. Sort uploads to report the first incompatible one deterministically
+	sort.Slice(rule.uploads, func(i, j int) bool {
+		return rule.uploads[i].uses.Pos.IsBefore(rule.uploads[j].uses.Pos)
+	})
+	for _, d := range rule.downloads {
+		for _, u := range rule.uploads {
+			if d.incompatibleWith(u) && d.mayShareArtifact(u) {
+				rule.Errorf(
+					d.uses.Pos,
+					"%q cannot download artifacts uploaded by %q at %s. artifacts uploaded by v4 or later cannot be downloaded by v3 or earlier, and vice versa. use the same major version of actions/upload-artifact and actions/download-artifact",
+					d.uses.Value,
+					u.uses.Value,
+					u.uses.Pos,
+				)
+				break // Report once per download step
+			}
+		}
+	}
+	return nil
+}
+
+// checkUploadInMatrix checks the artifact name is unique in each job of the matrix. v4 or later
+// does not allow uploading multiple artifacts with the same name.
+// https://github.com/actions/upload-artifact/blob/main/docs/MIGRATION.md#multiple-uploads-to-the-same-named-artifact
+func (rule *RuleArtifact) checkUploadInMatrix(a *artifactAction, e *ExecAction) {
+	if a.major < 4 || rule.job == nil || rule.job.Strategy == nil || rule.job.Strategy.Matrix == nil {
+		return
+	}
+	if a.name != nil && a.name.ContainsExpression() {
+		return // The name may be unique in each job. e.g. ${{ matrix.os }}
+	}
+	if i, ok := e.Inputs["overwrite"]; ok && i.Value != nil && i.Value.Value != "false" {
+		return // Overwriting the artifact is intended
+	}
+
+	name := "artifact" // Default artifact name
+	if a.name != nil {
+		name = a.name.Value
+	}
+	rule.Errorf(
+		a.uses.Pos,
+		"artifact name %q uploaded by %q is not unique across jobs of the matrix. actions/upload-artifact v4 or later does not allow uploading multiple artifacts with the same name. include matrix values in the name like \"%s-${{ matrix.os }}\"",
+		name,
+		a.uses.Value,
+		name,
+	)
+}
+
+// parseMajorVersion parses the major version of the action ref such as "v4" or "v4.1.0". It returns
+// false when the ref is not a version such as commit SHA or branch name.
+func parseMajorVersion(ref string) (int, bool) {
+	if !strings.HasPrefix(ref, "v") {
+		return 0, false
+	}
+	s := strings.TrimPrefix(ref, "v")
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,117 @@
+package actionlint
+
+import (
+	"sort"
+)
+
+// RuleBroadPermissions is a rule to detect broad permissions of GITHUB_TOKEN at `permissions:` which
+// violate the principle of least privilege. Since the minimal set of permissions required by a job
+// cannot be known in general, this rule reports only obviously broad permissions such as
+// "write-all". This rule is enabled by "broad-permissions" in the config file.
+// https://docs.github.com/en/actions/security-for-github-actions/security-guides/automatic-token-authentication#modifying-the-permissions-for-the-github_token
+type RuleBroadPermissions struct {
+	RuleBase
+}
+
+// NewRuleBroadPermissions creates new RuleBroadPermissions instance.
+func NewRuleBroadPermissions() *RuleBroadPermissions {
+	return &RuleBroadPermissions{
+		RuleBase: RuleBase{
+			name: "broad-permissions",
+			desc: "Checks for broad permissions such as \"write-all\" at \"permissions:\" when \"broad-permissions\" is enabled",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleBroadPermissions) VisitWorkflowPre(n *Workflow) error {
+	strictness := rule.strictness()
+	if strictness == "" || n.Permissions == nil {
+		return nil
+	}
+
+	if rule.checkAll(n.Permissions, "workflow", strictness) || strictness != BroadPermissionsStrict {
+		return nil
+	}
+
+	// Jobs which have their own "permissions:" don't inherit the workflow-level permissions. When only
+	// one job inherits them, granting them at workflow-level is the same as granting them to the job
+	inherited := 0
+	for _, j := range n.Jobs {
+		if j.Permissions == nil {
+			inherited++
+		}
+	}
+	if inherited < 2 {
+		return nil
+	}
+
+	names := make([]string, 0, len(n.Permissions.Scopes))
+	for name, s := range n.Permissions.Scopes {
+		if s.Value != nil && s.Value.Value == "write" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := n.Permissions.Scopes[name]
+		rule.Errorf(
+			s.Value.Pos,
+			"\"write\" permission of %q scope at workflow-level \"permissions:\" is granted to all %d jobs which don't have their own \"permissions:\". grant it at \"permissions:\" of only the jobs which need it",
+			s.Name.Value,
+			inherited,
+		)
+	}
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleBroadPermissions) VisitJobPre(n *Job) error {
+	if s := rule.strictness(); s != "" && n.Permissions != nil {
+		rule.checkAll(n.Permissions, "job", s)
+	}
+	return nil
+}
+
+func (rule *RuleBroadPermissions) strictness() string {
+	if cfg := rule.Config(); cfg != nil {
+		return cfg.BroadPermissions
+	}
+	return ""
+}
+
+// checkAll checks "write-all" and "read-all". It returns true when the permissions are for all the
+// scopes.
+func (rule *RuleBroadPermissions) checkAll(p *Permissions, level, strictness string) bool {
+	if p.All == nil {
+		return false
+	}
+
+	var perm, example string
+	switch p.All.Value {
+	case "write-all":
+		perm, example = "write", "contents: write"
+	case "read-all":
+		if strictness != BroadPermissionsStrict {
+			return true
+		}
+		perm, example = "read", "contents: read"
+	default:
+		return true // Invalid value is reported by "permissions" rule
+	}
+
+	hint := "list only the scopes needed by the job"
+	if level == "workflow" {
+		hint = "list only the scopes needed by each job at \"permissions:\" of the job"
+	}
+	rule.Errorf(
+		p.All.Pos,
+		"%q at %s-level \"permissions:\" grants %s permission of all scopes to GITHUB_TOKEN. it violates the principle of least privilege. %s like %q",
+		p.All.Value,
+		level,
+		perm,
+		hint,
+		example,
+	)
+	return true
+}
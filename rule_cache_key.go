@@ -0,0 +1,120 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// perRunGitHubProperties is a set of properties of `github` context whose values are different on
+// every workflow run.
+var perRunGitHubProperties = map[string]struct{}{
+	"run_id":      {},
+	"run_number":  {},
+	"run_attempt": {},
+	"sha":         {},
+}
+
+// RuleCacheKey is a rule checker to detect keys of actions/cache which don't work as cache keys. A
+// constant key means the cache is saved only once and never updated. A key which varies only by
+// per-run values like github.run_id means the cache is saved on every run and never hit. This is an
+// advisory rule so its errors are warnings by default.
+// https://github.com/actions/cache#creating-a-cache-key
+type RuleCacheKey struct {
+	RuleBase
+	exprs exprCollector
+	// dynamic is set to true when the key references some value which varies for the content to be
+	// cached such as hashFiles() or runner.os.
+	dynamic bool
+	// perRun is the first per-run value referenced in the key like "github.run_id".
+	perRun string
+}
+
+// NewRuleCacheKey creates a new RuleCacheKey instance.
+func NewRuleCacheKey() *RuleCacheKey {
+	r := &RuleCacheKey{
+		RuleBase: RuleBase{
+			name: "cache-key",
+			desc: "Checks for keys of actions/cache which never change or change on every run",
+		},
+	}
+	r.exprs.visit = r.collectExpr
+	return r
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleCacheKey) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecAction)
+	if !ok || e.Uses == nil || !strings.HasPrefix(strings.ToLower(e.Uses.Value), "actions/cache@") {
+		return nil
+	}
+
+	i, ok := e.Inputs["key"]
+	if !ok || i.Value == nil {
+		// Missing input of known versions is reported by "action" rule
+		if _, ok := PopularActions[e.Uses.Value]; !ok {
+			rule.Errorf(e.Uses.Pos, "\"key\" input is required by %q action but it is not set at \"with:\"", e.Uses.Value)
+		}
+		return nil
+	}
+	key := i.Value
+	if key.Value == "" {
+		return nil
+	}
+
+	rule.dynamic = false
+	rule.perRun = ""
+	rule.exprs.collectString(key)
+	if rule.dynamic {
+		return nil
+	}
+
+	if rule.perRun != "" {
+		rule.Errorf(
+			key.Pos,
+			"key %q of %q varies only by per-run value %q. the cache is saved on every run and never hit. use values which change with the cached content such as hashFiles() of lock files",
+			key.Value,
+			e.Uses.Value,
+			rule.perRun,
+		)
+		return nil
+	}
+
+	rule.Errorf(
+		key.Pos,
+		"key %q of %q never changes. the cache is saved only once and never updated. include values which change with the cached content such as hashFiles() of lock files",
+		key.Value,
+		e.Uses.Value,
+	)
+	return nil
+}
+
+func (rule *RuleCacheKey) collectExpr(expr ExprNode, pos *Pos) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if !entering || rule.dynamic {
+			return
+		}
+
+		if f, ok := n.(*FuncCallNode); ok {
+			if strings.EqualFold(f.Callee, "hashFiles") {
+				rule.dynamic = true
+			}
+			return
+		}
+
+		if _, ok := staticPropertyAccess(n, p); ok {
+			return // Check the outermost property access like github.run_id instead of github
+		}
+		path := propertyPathOf(n)
+		if len(path) == 0 {
+			return
+		}
+		if len(path) == 2 && path[0] == "github" {
+			if _, ok := perRunGitHubProperties[path[1]]; ok {
+				if rule.perRun == "" {
+					rule.perRun = strings.Join(path, ".")
+				}
+				return
+			}
+		}
+		rule.dynamic = true
+	})
+}
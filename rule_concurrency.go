@@ -0,0 +1,97 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// RuleConcurrency is a rule to check 'concurrency' sections of workflow and jobs. Expressions in
+// the `group` value are type-checked by RuleExpression. This rule reports a `concurrency` section
+// without `cancel-in-progress` in workflows triggered by pull requests. Runs for the outdated
+// commits of the pull request are not canceled and remain running redundantly. It also reports a
+// job-level `concurrency` whose group is the same as the workflow-level one. Since these are
+// advisory, this rule reports warnings by default.
+// https://docs.github.com/en/actions/using-jobs/using-concurrency
+type RuleConcurrency struct {
+	RuleBase
+	pullRequest string
+	workflow    *Concurrency
+}
+
+// NewRuleConcurrency creates new RuleConcurrency instance.
+func NewRuleConcurrency() *RuleConcurrency {
+	return &RuleConcurrency{
+		RuleBase: RuleBase{
+			name: "concurrency",
+			desc: "Checks for \"concurrency:\" without \"cancel-in-progress:\" in workflows triggered by pull requests and job-level \"concurrency:\" redundant with workflow-level one",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleConcurrency) VisitWorkflowPre(n *Workflow) error {
+	rule.pullRequest = ""
+	for _, e := range n.On {
+		if w, ok := e.(*WebhookEvent); ok {
+			if h := w.EventName(); h == "pull_request" || h == "pull_request_target" {
+				rule.pullRequest = h
+				break
+			}
+		}
+	}
+	rule.checkCancelInProgress(n.Concurrency, "workflow")
+	rule.workflow = n.Concurrency
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleConcurrency) VisitJobPre(n *Job) error {
+	if n.ID != nil {
+		rule.checkCancelInProgress(n.Concurrency, "job \""+n.ID.Value+"\"")
+		rule.checkRedundantGroup(n.Concurrency, n.ID.Value)
+	}
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleConcurrency) VisitWorkflowPost(n *Workflow) error {
+	rule.workflow = nil
+	return nil
+}
+
+// checkRedundantGroup checks the group of the job-level concurrency is not the same as the group of
+// the workflow-level concurrency. The workflow run already holds the group while the job is waiting
+// for it so GitHub Actions detects the deadlock and cancels the job.
+func (rule *RuleConcurrency) checkRedundantGroup(c *Concurrency, job string) {
+	w := rule.workflow
+	if w == nil || w.Group == nil || c == nil || c.Group == nil {
+		return
+	}
+	g := strings.TrimSpace(c.Group.Value)
+	if g == "" || g != strings.TrimSpace(w.Group.Value) {
+		return
+	}
+	rule.Errorf(
+		c.Group.Pos,
+		"concurrency group %q of job %q is the same as the workflow-level concurrency group at %s. the workflow-level \"concurrency\" already covers all jobs and the job is canceled due to deadlock of the same group. remove the job-level \"concurrency\" or use another group",
+		g,
+		job,
+		w.Group.Pos,
+	)
+}
+
+func (rule *RuleConcurrency) checkCancelInProgress(c *Concurrency, where string) {
+	if rule.pullRequest == "" || c == nil || c.CancelInProgress != nil {
+		return
+	}
+	if rule.isIgnoredByComment(c.Comment) {
+		rule.Debug("Missing \"cancel-in-progress\" at %s is ignored by comment %q", c.Pos, c.Comment)
+		return
+	}
+	rule.Errorf(
+		c.Pos,
+		"\"cancel-in-progress\" is not set in \"concurrency\" section of %s though the workflow is triggered by %q event. runs for outdated commits of the pull request are not canceled and keep running. set \"cancel-in-progress: true\" or put comment \"# %s\" at the line of \"concurrency:\" if this is intended",
+		where,
+		rule.pullRequest,
+		ignoreComment(rule.name),
+	)
+}
@@ -2,25 +2,63 @@ package actionlint
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// knownCredential is a pattern of well-known credential format such as GitHub personal access token.
+type knownCredential struct {
+	kind string
+	re   *regexp.Regexp
+}
+
+var knownCredentials = []knownCredential{
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`)},
+	{"GitHub fine-grained personal access token", regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}\b`)},
+	{"AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"GitLab personal access token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`)},
+	{"npm access token", regexp.MustCompile(`\bnpm_[A-Za-z0-9]{36}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"Stripe secret key", regexp.MustCompile(`\bsk_live_[0-9A-Za-z]{24,}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)},
+}
+
+var (
+	reTemplateExpression = regexp.MustCompile(`\$\{\{.*?\}\}`)
+	reTokenLikeWord      = regexp.MustCompile(`[A-Za-z0-9_+=-]{32,}`)
 )
 
 // RuleCredentials is a rule to check credentials in workflows
 type RuleCredentials struct {
 	RuleBase
+	exprs exprCollector
+	// secret is set to true when the collected expression references some secret.
+	secret bool
 }
 
 // NewRuleCredentials creates new RuleCredentials instance
 func NewRuleCredentials() *RuleCredentials {
-	return &RuleCredentials{
+	r := &RuleCredentials{
 		RuleBase: RuleBase{
 			name: "credentials",
-			desc: "Checks for credentials in \"services:\" configuration",
+			desc: "Checks for credentials in \"services:\" configuration and hard-coded tokens in \"env:\" and \"with:\"",
 		},
 	}
+	r.exprs.visit = r.collectExpr
+	return r
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleCredentials) VisitWorkflowPre(n *Workflow) error {
+	rule.checkEnv(n.Env)
+	return nil
 }
 
 // VisitJobPre is callback when visiting Job node before visiting its children.
 func (rule *RuleCredentials) VisitJobPre(n *Job) error {
+	rule.checkEnv(n.Env)
 	if n.Container != nil {
 		rule.checkContainer("\"container\" section", n.Container)
 	}
@@ -29,10 +67,44 @@ func (rule *RuleCredentials) VisitJobPre(n *Job) error {
 			rule.checkContainer(fmt.Sprintf("%q service", s.Name.Value), s.Container)
 		}
 	}
+	if n.WorkflowCall != nil {
+		for _, i := range n.WorkflowCall.Inputs {
+			rule.checkHardcodedToken(i.Value, "input", i.Name)
+		}
+		for _, s := range n.WorkflowCall.Secrets {
+			rule.checkHardcodedToken(s.Value, "secret", s.Name)
+		}
+	}
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleCredentials) VisitStep(n *Step) error {
+	rule.checkEnv(n.Env)
+	if e, ok := n.Exec.(*ExecAction); ok {
+		for _, i := range e.Inputs {
+			rule.checkHardcodedToken(i.Value, "input", i.Name)
+		}
+	}
+	return nil
+}
+
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleCredentials) VisitActionPre(n *Action) error {
+	if n.Runs != nil {
+		rule.checkEnv(n.Runs.Env)
+	}
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleCredentials) VisitActionPost(n *Action) error {
 	return nil
 }
 
 func (rule *RuleCredentials) checkContainer(where string, n *Container) {
+	rule.checkEnv(n.Env)
+
 	if n.Credentials == nil || n.Credentials.Password == nil {
 		return
 	}
@@ -40,5 +112,131 @@ func (rule *RuleCredentials) checkContainer(where string, n *Container) {
 	p := n.Credentials.Password
 	if !p.IsExpressionAssigned() {
 		rule.Errorf(p.Pos, "\"password\" section in %s should be specified via secrets. do not put password value directly", where)
+		return
+	}
+
+	rule.secret = false
+	rule.exprs.collectString(p)
+	if !rule.secret {
+		rule.Errorf(
+			p.Pos,
+			"\"password\" section in %s should be specified via secrets like \"${{ secrets.PASSWORD }}\" but %q does not reference any secret. values of other contexts are not masked in logs",
+			where,
+			p.Value,
+		)
+	}
+}
+
+// collectExpr checks the expression references "secrets" context or "github.token". GITHUB_TOKEN is
+// a secret commonly used for the credentials of GitHub Container Registry.
+func (rule *RuleCredentials) collectExpr(expr ExprNode, pos *Pos) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if !entering || rule.secret {
+			return
+		}
+		switch path := propertyPathOf(n); len(path) {
+		case 0:
+		case 1:
+			rule.secret = path[0] == "secrets" // Also accept dynamic access like secrets[inputs.name]
+		default:
+			rule.secret = path[0] == "github" && path[1] == "token"
+		}
+	})
+}
+
+func (rule *RuleCredentials) checkEnv(env *Env) {
+	if env == nil {
+		return
+	}
+	for _, v := range env.Vars {
+		rule.checkHardcodedToken(v.Value, "environment variable", v.Name)
+	}
+}
+
+func (rule *RuleCredentials) checkHardcodedToken(v *String, what string, name *String) {
+	if v == nil || name == nil || v.IsExpressionAssigned() {
+		return
+	}
+
+	// Values in ${{ }} are evaluated at runtime so only the literal parts are checked
+	s := reTemplateExpression.ReplaceAllString(v.Value, " ")
+	kind, token := findHardcodedToken(s)
+	if kind == "" {
+		return
+	}
+
+	var allowed []string
+	if cfg := rule.Config(); cfg != nil {
+		allowed = cfg.AllowedCredentialPrefixes
+	}
+	for _, p := range allowed {
+		if p != "" && strings.HasPrefix(token, p) {
+			rule.Debug("Hard-coded %s %q in value of %s %q is allowed by prefix %q in \"allowed-credential-prefixes\"", kind, maskCredential(token), what, name.Value, p)
+			return
+		}
+	}
+
+	rule.Errorf(
+		v.Pos,
+		"value of %s %q contains string %q which looks like a hard-coded %s. put the value in secrets and refer to it with ${{ secrets.YOUR_SECRET_NAME }}. if this is not a credential, add its prefix to \"allowed-credential-prefixes\" in the configuration file",
+		what,
+		name.Value,
+		maskCredential(token),
+		kind,
+	)
+}
+
+// findHardcodedToken finds a string which looks like a credential in the given string. It returns
+// kind of the credential and the string. Empty strings are returned when no credential is found.
+func findHardcodedToken(s string) (string, string) {
+	for _, c := range knownCredentials {
+		if m := c.re.FindString(s); m != "" {
+			return c.kind, m
+		}
+	}
+	for _, w := range reTokenLikeWord.FindAllString(s, -1) {
+		if isHighEntropyToken(w) {
+			return "token", w
+		}
+	}
+	return "", ""
+}
+
+// isHighEntropyToken returns true when the word looks like a randomly generated token. The word
+// must contain upper case letters, lower case letters, and digits to exclude hex strings such as
+// commit SHAs and identifiers such as CONSTANT_NAME.
+func isHighEntropyToken(w string) bool {
+	upper, lower, digit := false, false, false
+	counts := map[rune]int{}
+	for _, r := range w {
+		switch {
+		case 'A' <= r && r <= 'Z':
+			upper = true
+		case 'a' <= r && r <= 'z':
+			lower = true
+		case '0' <= r && r <= '9':
+			digit = true
+		}
+		counts[r]++
+	}
+	if !upper || !lower || !digit {
+		return false
+	}
+
+	// Shannon entropy in bits per character
+	e := 0.0
+	l := float64(len(w))
+	for _, c := range counts {
+		p := float64(c) / l
+		e -= p * math.Log2(p)
+	}
+	return e >= 4.5
+}
+
+// maskCredential hides the most part of the credential not to leak it again in the error message.
+func maskCredential(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
 	}
+	return s[:6] + "..."
 }
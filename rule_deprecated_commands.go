@@ -1,16 +1,49 @@
 package actionlint
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 var deprecatedCommandsPattern = regexp.MustCompile(`(?:::(save-state|set-output|set-env)\s+name=[a-zA-Z][a-zA-Z_-]*::\S+|::(add-path)::\S+)`)
 
+// workflowCommandPattern matches a workflow command like "::warning file=app.js::message" or
+// "::endgroup::" and captures its name.
+var workflowCommandPattern = regexp.MustCompile(`::([a-zA-Z][a-zA-Z-]*)(?:[ \t][^\n]*?)?::`)
+
+// allWorkflowCommands is a set of all workflow commands. Deprecated commands are also included.
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+var allWorkflowCommands = map[string]struct{}{
+	"add-mask":       {},
+	"add-matcher":    {},
+	"add-path":       {},
+	"debug":          {},
+	"echo":           {},
+	"endgroup":       {},
+	"error":          {},
+	"group":          {},
+	"notice":         {},
+	"remove-matcher": {},
+	"save-state":     {},
+	"set-env":        {},
+	"set-output":     {},
+	"stop-commands":  {},
+	"warning":        {},
+}
+
+// deprecatedCommandEchoLinePattern matches a script line which only outputs "set-output" or "save-state"
+// workflow command with `echo`. Only such lines can be fixed automatically.
+var deprecatedCommandEchoLinePattern = regexp.MustCompile(`^(\s*)echo\s+(["']?)::(set-output|save-state)\s+name=([a-zA-Z][a-zA-Z_-]*)::(.*)$`)
+
 // RuleDeprecatedCommands is a rule checker to detect deprecated workflow commands. Currently
-// 'set-state', 'set-output', `set-env' and 'add-path' are detected as deprecated.
+// 'set-state', 'set-output', `set-env' and 'add-path' are detected as deprecated. In addition, the
+// workflow commands listed in "forbid-workflow-commands" in the config file are detected.
 //
 // - https://github.blog/changelog/2020-10-01-github-actions-deprecating-set-env-and-add-path-commands/
 // - https://github.blog/changelog/2022-10-11-github-actions-deprecating-save-state-and-set-output-commands/
 type RuleDeprecatedCommands struct {
 	RuleBase
+	fixer *sourceFixer
 }
 
 // NewRuleDeprecatedCommands creates a new RuleDeprecatedCommands instance.
@@ -18,7 +51,7 @@ func NewRuleDeprecatedCommands() *RuleDeprecatedCommands {
 	return &RuleDeprecatedCommands{
 		RuleBase: RuleBase{
 			name: "deprecated-commands",
-			desc: "Checks for deprecated \"set-output\", \"save-state\", \"set-env\", and \"add-path\" commands at \"run:\"",
+			desc: "Checks for deprecated \"set-output\", \"save-state\", \"set-env\", and \"add-path\" commands and commands forbidden by \"forbid-workflow-commands\" at \"run:\"",
 		},
 	}
 }
@@ -26,10 +59,17 @@ func NewRuleDeprecatedCommands() *RuleDeprecatedCommands {
 // VisitStep is callback when visiting Step node.
 func (rule *RuleDeprecatedCommands) VisitStep(n *Step) error {
 	if r, ok := n.Exec.(*ExecRun); ok && r.Run != nil {
-		for _, m := range deprecatedCommandsPattern.FindAllStringSubmatch(r.Run.Value, -1) {
-			c := m[1]
-			if len(c) == 0 {
-				c = m[2]
+		for _, m := range deprecatedCommandsPattern.FindAllStringSubmatchIndex(r.Run.Value, -1) {
+			var c string
+			if m[2] >= 0 {
+				c = r.Run.Value[m[2]:m[3]]
+			} else {
+				c = r.Run.Value[m[4]:m[5]]
+			}
+
+			if rule.fixer != nil && rule.fix(r.Run, m[0]) {
+				rule.Debug("Fixed deprecated workflow command %q in the script at %s", c, r.Run.Pos)
+				continue
 			}
 
 			var a string
@@ -53,6 +93,137 @@ func (rule *RuleDeprecatedCommands) VisitStep(n *Step) error {
 				a,
 			)
 		}
+		rule.checkForbiddenCommands(r.Run)
+	}
+	return nil
+}
+
+// checkForbiddenCommands reports workflow commands listed in "forbid-workflow-commands" in the
+// script. Deprecated commands are not reported here since they are always reported.
+func (rule *RuleDeprecatedCommands) checkForbiddenCommands(run *String) {
+	cfg := rule.Config()
+	if cfg == nil || len(cfg.ForbidWorkflowCommands) == 0 {
+		return
+	}
+
+	for i, l := range strings.Split(run.Value, "\n") {
+		if !strings.Contains(l, "::") {
+			continue
+		}
+		for _, m := range workflowCommandPattern.FindAllStringSubmatch(l, -1) {
+			c := strings.ToLower(m[1])
+			switch c {
+			case "set-output", "save-state", "set-env", "add-path":
+				continue
+			}
+			for _, f := range cfg.ForbidWorkflowCommands {
+				if strings.ToLower(f) == c {
+					rule.Errorf(
+						run.Pos,
+						"workflow command %q at line %d of the script is forbidden by \"forbid-workflow-commands\" in the config file",
+						c,
+						i+1,
+					)
+					break
+				}
+			}
+		}
 	}
+}
+
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleDeprecatedCommands) VisitActionPre(n *Action) error {
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleDeprecatedCommands) VisitActionPost(n *Action) error {
 	return nil
 }
+
+// fix rewrites the source line containing the deprecated command at the offset of the script. It
+// returns false when the line cannot be rewritten safely. In the case, the error should be reported
+// as usual.
+func (rule *RuleDeprecatedCommands) fix(run *String, offset int) bool {
+	s := run.Value
+	start := strings.LastIndexByte(s[:offset], '\n') + 1
+	end := len(s)
+	if i := strings.IndexByte(s[offset:], '\n'); i >= 0 {
+		end = offset + i
+	}
+	script := s[start:end]
+
+	fixed, ok := fixDeprecatedCommandLine(script)
+	if !ok {
+		return false
+	}
+
+	l, ok := rule.fixer.line(run.Pos.Line)
+	if !ok || len(l) < run.Pos.Col {
+		return false
+	}
+
+	head := l[run.Pos.Col-1:]
+	switch {
+	case strings.HasPrefix(head, "|"):
+		// Literal block scalar. The script starts from the next line and each line is indented
+		lnum := run.Pos.Line + 1 + strings.Count(s[:start], "\n")
+		l, ok := rule.fixer.line(lnum)
+		if !ok || !strings.HasSuffix(l, script) {
+			return false
+		}
+		indent := l[:len(l)-len(script)]
+		if strings.TrimLeft(indent, " ") != "" {
+			return false
+		}
+		return rule.fixer.replaceLine(lnum, l, indent+fixed)
+	case !run.Quoted && !strings.HasPrefix(head, ">") && !strings.ContainsRune(s, '\n'):
+		// Plain scalar in one line. Comment may follow the script
+		if !strings.HasPrefix(head, script) {
+			return false
+		}
+		return rule.fixer.replaceLine(run.Pos.Line, l, l[:run.Pos.Col-1]+fixed+head[len(script):])
+	default:
+		// Quoted strings and folded block scalars are not fixed since escaping and folding line
+		// breaks may change the meaning of the script
+		return false
+	}
+}
+
+// fixDeprecatedCommandLine rewrites the script line which outputs "set-output" or "save-state"
+// workflow command with `echo` into the line which writes to $GITHUB_OUTPUT or $GITHUB_STATE. It
+// returns false when the line cannot be rewritten safely.
+func fixDeprecatedCommandLine(line string) (string, bool) {
+	m := deprecatedCommandEchoLinePattern.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	indent, quote, cmd, name, value := m[1], m[2], m[3], m[4], strings.TrimRight(m[5], " \t")
+
+	if quote != "" {
+		if !strings.HasSuffix(value, quote) {
+			return "", false
+		}
+		value = value[:len(value)-1]
+		if strings.Contains(value, quote) || quote == `"` && strings.ContainsRune(value, '\\') {
+			return "", false
+		}
+	} else {
+		if strings.ContainsAny(value, " \t\"'\\;&|<>()#") {
+			return "", false
+		}
+		quote = `"`
+	}
+
+	// Multiple commands in one line cannot be rewritten
+	if strings.Contains(value, "::") {
+		return "", false
+	}
+
+	file := "GITHUB_OUTPUT"
+	if cmd == "save-state" {
+		file = "GITHUB_STATE"
+	}
+
+	return indent + "echo " + quote + name + "=" + value + quote + ` >> "$` + file + `"`, true
+}
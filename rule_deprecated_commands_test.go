@@ -123,3 +123,201 @@ func TestRuleDeprecatedCommandsDetectTargetCommands(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleDeprecatedCommandsFix(t *testing.T) {
+	tests := []struct {
+		what   string
+		src    string
+		want   string
+		errors int
+	}{
+		{
+			what: "set-output in block",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo hello
+          if true; then
+            echo "::set-output name=foo::$(cat VERSION)"
+          fi
+`,
+			want: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo hello
+          if true; then
+            echo "foo=$(cat VERSION)" >> "$GITHUB_OUTPUT"
+          fi
+`,
+		},
+		{
+			what: "save-state in block",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |-
+          echo '::save-state name=foo::bar'
+`,
+			want: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |-
+          echo 'foo=bar' >> "$GITHUB_STATE"
+`,
+		},
+		{
+			what: "plain scalar",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ::set-output name=foo::$GITHUB_SHA # comment
+`,
+			want: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "foo=$GITHUB_SHA" >> "$GITHUB_OUTPUT" # comment
+`,
+		},
+		{
+			what: "CRLF",
+			src:  "on: push\r\njobs:\r\n  test:\r\n    runs-on: ubuntu-latest\r\n    steps:\r\n      - run: |\r\n          echo \"::set-output name=foo::bar\"\r\n",
+			want: "on: push\r\njobs:\r\n  test:\r\n    runs-on: ubuntu-latest\r\n    steps:\r\n      - run: |\r\n          echo \"foo=bar\" >> \"$GITHUB_OUTPUT\"\r\n",
+		},
+		{
+			what: "multiple lines",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "::set-output name=foo::1"
+          echo "::set-output name=bar::2"
+`,
+			want: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "foo=1" >> "$GITHUB_OUTPUT"
+          echo "bar=2" >> "$GITHUB_OUTPUT"
+`,
+		},
+		{
+			what: "mixed with other command",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "::set-output name=foo::1"; echo hello
+`,
+			errors: 1,
+		},
+		{
+			what: "multiple commands in one line",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "::set-output name=foo::1 ::set-output name=bar::2"
+`,
+			errors: 2,
+		},
+		{
+			what: "unquoted value with spaces",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo ::set-output name=foo::hello world
+`,
+			errors: 1,
+		},
+		{
+			what: "set-env is not fixed",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "::set-env name=foo::1"
+`,
+			errors: 1,
+		},
+		{
+			what: "quoted scalar",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: 'echo "::set-output name=foo::1"'
+`,
+			errors: 1,
+		},
+		{
+			what: "folded block",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: >
+          echo "::set-output name=foo::1"
+`,
+			errors: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			w, errs := Parse([]byte(tc.src))
+			if len(errs) > 0 {
+				t.Fatal(errs)
+			}
+
+			f := newSourceFixer([]byte(tc.src))
+			r := NewRuleDeprecatedCommands()
+			r.fixer = f
+			v := NewVisitor()
+			v.AddPass(r)
+			if err := v.Visit(w); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(r.Errs()) != tc.errors {
+				t.Fatalf("wanted %d errors but got %v", tc.errors, r.Errs())
+			}
+
+			want := tc.want
+			if want == "" {
+				want = tc.src
+			}
+			if diff := cmp.Diff(want, string(f.source())); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
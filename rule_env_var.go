@@ -43,6 +43,19 @@ func (rule *RuleEnvVar) VisitWorkflowPre(n *Workflow) error {
 	return nil
 }
 
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleEnvVar) VisitActionPre(n *Action) error {
+	if n.Runs != nil {
+		rule.checkEnv(n.Runs.Env)
+	}
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleEnvVar) VisitActionPost(n *Action) error {
+	return nil
+}
+
 func (rule *RuleEnvVar) checkEnv(env *Env) {
 	if env == nil || env.Expression != nil {
 		return
@@ -57,6 +70,47 @@ func (rule *RuleEnvVar) checkEnv(env *Env) {
 				"environment variable name %q is invalid. '&', '=' and spaces should not be contained",
 				v.Name.Value,
 			)
+		} else if !isShellIdentifier(v.Name.Value) {
+			rule.Errorf(
+				v.Name.Pos,
+				"environment variable name %q is not a valid shell identifier. it cannot be referred as $%s in shell scripts. the name should start with a letter or '_' and consist of only letters, digits, and '_' like %q",
+				v.Name.Value,
+				v.Name.Value,
+				normalizeEnvVarName(v.Name.Value),
+			)
+		}
+	}
+}
+
+// isShellIdentifier returns true when the name matches to [A-Za-z_][A-Za-z0-9_]*.
+func isShellIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || r == '_' || i > 0 && '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeEnvVarName converts the given name into a valid shell identifier by replacing invalid
+// characters with '_'. '_' is prepended when the name starts with a digit.
+func normalizeEnvVarName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case '0' <= r && r <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
 		}
 	}
+	return b.String()
 }
@@ -22,9 +22,9 @@ func TestRuleEnvVarCheckValidName(t *testing.T) {
 	valids := []string{
 		"foo_bar",
 		"FOO_BAR",
-		"foo-bar",
 		"_",
-		"-",
+		"_1",
+		"Foo9",
 	}
 
 	for _, n := range valids {
@@ -41,6 +41,11 @@ func TestRuleEnvVarCheckInvalidName(t *testing.T) {
 		"a b",
 		"a=b",
 		"a=b",
+		"foo-bar",
+		"-",
+		"1FOO",
+		"foo.bar",
+		"ふー",
 	}
 
 	for _, n := range invalids {
@@ -52,6 +57,24 @@ func TestRuleEnvVarCheckInvalidName(t *testing.T) {
 	}
 }
 
+func TestRuleEnvVarNormalizeName(t *testing.T) {
+	testCases := map[string]string{
+		"MY-VAR":  "MY_VAR",
+		"1FOO":    "_1FOO",
+		"foo.bar": "foo_bar",
+		"-":       "_",
+		"ふー":      "__",
+	}
+
+	for name, want := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if have := normalizeEnvVarName(name); have != want {
+				t.Fatalf("wanted %q but have %q", want, have)
+			}
+		})
+	}
+}
+
 func TestRuleEnvVarSkipEdgeCaseEnv(t *testing.T) {
 	w := &Workflow{Env: nil}
 	r := NewRuleEnvVar()
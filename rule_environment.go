@@ -0,0 +1,67 @@
+package actionlint
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RuleEnvironment is a rule to check 'environment' section of job. The shape of the section is
+// checked by the parser and the expressions in the section are checked by RuleExpression.
+// https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions#jobsjob_idenvironment
+type RuleEnvironment struct {
+	RuleBase
+}
+
+// NewRuleEnvironment creates new RuleEnvironment instance.
+func NewRuleEnvironment() *RuleEnvironment {
+	return &RuleEnvironment{
+		RuleBase: RuleBase{
+			name: "environment",
+			desc: "Checks for environment names and URLs at \"environment:\"",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleEnvironment) VisitJobPre(n *Job) error {
+	if n.Environment == nil {
+		return nil
+	}
+	rule.checkName(n.Environment.Name)
+	rule.checkURL(n.Environment.URL)
+	return nil
+}
+
+func (rule *RuleEnvironment) checkName(name *String) {
+	// Note: Empty string is reported by parser
+	if name == nil || name.Value == "" || name.ContainsExpression() {
+		return
+	}
+
+	if strings.TrimSpace(name.Value) == "" {
+		rule.Errorf(name.Pos, "environment name %q must not consist of only white spaces", name.Value)
+		return
+	}
+
+	for _, r := range name.Value {
+		if r < '0' || '9' < r {
+			return
+		}
+	}
+	rule.Errorf(name.Pos, "environment name %q must not consist of only digits. use a descriptive name such as \"production\"", name.Value)
+}
+
+func (rule *RuleEnvironment) checkURL(u *String) {
+	if u == nil || u.Value == "" || u.ContainsExpression() {
+		return
+	}
+
+	p, err := url.Parse(u.Value)
+	if err != nil {
+		rule.Errorf(u.Pos, "environment URL %q is invalid: %s", u.Value, err)
+		return
+	}
+	if p.Scheme != "http" && p.Scheme != "https" || p.Host == "" {
+		rule.Errorf(u.Pos, "environment URL %q is invalid. it must be an absolute URL starting with \"http://\" or \"https://\" such as \"https://example.com\"", u.Value)
+	}
+}
@@ -1,6 +1,8 @@
 package actionlint
 
 import (
+	"errors"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +16,23 @@ import (
 // https://docs.github.com/en/actions/learn-github-actions/events-that-trigger-workflows
 type RuleEvents struct {
 	RuleBase
+	// template is true when the workflow is a workflow template. Placeholders such as $default-branch
+	// are available in workflow templates.
+	template bool
+}
+
+// Placeholders available in workflow templates. They are replaced when creating a workflow from the
+// template.
+// https://docs.github.com/en/actions/using-workflows/creating-starter-workflows-for-your-organization
+var (
+	templateBranchPlaceholders = []string{"$default-branch", "$protected-branches"}
+	templateCronPlaceholders   = []string{"$cron-daily", "$cron-weekly"}
+)
+
+// isWorkflowTemplatePath returns true when the file path is a workflow template put in
+// "workflow-templates" directory.
+func isWorkflowTemplatePath(path string) bool {
+	return filepath.Base(filepath.Dir(path)) == "workflow-templates"
 }
 
 // NewRuleEvents creates new RuleEvents instance.
@@ -55,25 +74,60 @@ func (rule *RuleEvents) checkEvent(event Event) {
 
 // https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#onschedule
 func (rule *RuleEvents) checkCron(spec *String) {
-	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	sched, err := p.Parse(spec.Value)
+	if contains(templateCronPlaceholders, spec.Value) {
+		if !rule.template {
+			rule.templatePlaceholderNotAvailable(spec)
+		}
+		return
+	}
+
+	sched, err := parseCron(spec.Value)
 	if err != nil {
 		rule.Errorf(spec.Pos, "invalid CRON format %q in schedule event: %s", spec.Value, err.Error())
 		return
 	}
 
-	start := sched.Next(time.Unix(0, 0))
-	next := sched.Next(start)
-	diff := next.Sub(start).Seconds()
+	// Find the shortest interval between two consecutive runs. Checking only the first interval is
+	// not sufficient since intervals may not be even. For example, "0,1 0 * * *" runs twice within
+	// 1 minute once a day. Intervals in one day are enough since minutes and hours are repeated daily.
+	prev := sched.Next(time.Unix(0, 0))
+	if prev.IsZero() {
+		// The schedule never matches to any date like "0 0 31 2 *" (February 31st)
+		rule.Errorf(spec.Pos, "scheduled job never runs since CRON %q matches to no date", spec.Value)
+		return
+	}
+	end := prev.Add(24 * time.Hour)
+	diff := 0.0
+	for prev.Before(end) {
+		next := sched.Next(prev)
+		if next.IsZero() || !next.After(prev) {
+			break
+		}
+		if d := next.Sub(prev).Seconds(); diff == 0 || d < diff {
+			diff = d
+		}
+		prev = next
+	}
 
 	// (#14) https://docs.github.com/en/actions/learn-github-actions/events-that-trigger-workflows#scheduled-events
 	//
 	// > The shortest interval you can run scheduled workflows is once every 5 minutes.
-	if diff < 60.0*5 {
+	if diff != 0 && diff < 60.0*5 {
 		rule.Errorf(spec.Pos, "scheduled job runs too frequently. it runs once per %g seconds. the shortest interval is once every 5 minutes", diff)
 	}
 }
 
+// parseCron parses the POSIX CRON syntax used by GitHub. It is also used for validating CRON
+// expressions in other configuration files such as dependabot.yml.
+func parseCron(spec string) (cron.Schedule, error) {
+	// robfig/cron accepts '?' as an alias of '*' but it is not a part of POSIX CRON syntax
+	if strings.ContainsRune(spec, '?') {
+		return nil, errors.New("\"?\" is not available. use \"*\" instead")
+	}
+	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	return p.Parse(spec)
+}
+
 func (rule *RuleEvents) filterNotAvailable(pos *Pos, filter, hook string, available []string) {
 	e := "events"
 	if len(available) < 2 {
@@ -93,11 +147,20 @@ func (rule *RuleEvents) checkExclusiveFilters(filter, ignore *WebhookEventFilter
 
 	if ok {
 		if !filter.IsEmpty() && !ignore.IsEmpty() {
-			p := filter.Name.Pos
-			if p.IsBefore(ignore.Name.Pos) {
-				p = ignore.Name.Pos
+			// Report the error at the latter filter with the position of the former one
+			later, former := ignore.Name, filter.Name
+			if later.Pos.IsBefore(former.Pos) {
+				later, former = former, later
 			}
-			rule.Errorf(p, "both %q and %q filters cannot be used for the same event %q. note: use '!' to negate patterns", filter.Name.Value, ignore.Name.Value, hook)
+			rule.Errorf(
+				later.Pos,
+				"both %q and %q filters cannot be used for the same event %q. %q filter is also at %s. note: use '!' to negate patterns",
+				filter.Name.Value,
+				ignore.Name.Value,
+				hook,
+				former.Value,
+				former.Pos,
+			)
 		}
 	} else {
 		if !filter.IsEmpty() {
@@ -149,6 +212,8 @@ func (rule *RuleEvents) checkWebhookEvent(event *WebhookEvent) {
 		hook,
 		[]string{"merge_group", "push", "pull_request", "pull_request_target", "workflow_run"},
 	)
+	rule.checkBranchPlaceholders(event.Branches)
+	rule.checkBranchPlaceholders(event.BranchesIgnore)
 	rule.checkExclusiveFilters(
 		event.Tags,
 		event.TagsIgnore,
@@ -157,6 +222,25 @@ func (rule *RuleEvents) checkWebhookEvent(event *WebhookEvent) {
 	)
 }
 
+func (rule *RuleEvents) checkBranchPlaceholders(filter *WebhookEventFilter) {
+	if rule.template || filter.IsEmpty() {
+		return
+	}
+	for _, v := range filter.Values {
+		if contains(templateBranchPlaceholders, v.Value) {
+			rule.templatePlaceholderNotAvailable(v)
+		}
+	}
+}
+
+func (rule *RuleEvents) templatePlaceholderNotAvailable(s *String) {
+	rule.Errorf(
+		s.Pos,
+		"placeholder %q is only available in workflow templates. put the workflow template in \"workflow-templates\" directory or use -template option to lint it as workflow template",
+		s.Value,
+	)
+}
+
 func (rule *RuleEvents) checkTypes(hook *String, types []*String, expected []string) {
 	if len(expected) == 0 && len(types) > 0 {
 		rule.Errorf(hook.Pos, "\"types\" cannot be specified for %q Webhook event", hook.Value)
@@ -3,10 +3,31 @@ package actionlint
 import (
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 //go:generate go run ./scripts/generate-availability ./availability.go
 
+// literalExprKeys is a set of workflow keys where ${{ }} placeholders are not evaluated. Values at
+// these keys are used as-is so placeholders in them remain as literal strings. Workflow keys where
+// expressions are evaluated are listed in availability.go.
+var literalExprKeys = map[string]struct{}{
+	"name":                      {},
+	"on.<event>.types":          {},
+	"on.<event>.<filter>":       {},
+	"on.schedule.cron":          {},
+	"on.workflow_run.workflows": {},
+	"on.workflow_dispatch.inputs.<input_id>.description": {},
+	"on.workflow_dispatch.inputs.<input_id>.default":     {},
+	"on.workflow_dispatch.inputs.<input_id>.options":     {},
+	"on.workflow_call.inputs.<input_id>.description":     {},
+	"on.workflow_call.secrets.<secret_id>.description":   {},
+	"on.workflow_call.outputs.<output_id>.description":   {},
+	"jobs.<job_id>.needs":                                {},
+	"jobs.<job_id>.strategy.matrix.<variable>":           {},
+	"jobs.<job_id>.steps.uses":                           {},
+}
+
 type typedExpr struct {
 	ty  ExprType
 	pos Pos
@@ -26,9 +47,17 @@ type RuleExpression struct {
 	inputsTy         *ObjectType
 	dispatchInputsTy *ObjectType
 	jobsTy           *ObjectType
+	eventNames       []string
 	workflow         *Workflow
+	action           *Action
 	localActions     *LocalActionsCache
 	localWorkflows   *LocalReusableWorkflowCache
+	// workflowPath is a path to the workflow file being checked. It is used for finding callers of
+	// the reusable workflow.
+	workflowPath string
+	// untrustedInputs is the built-in untrusted inputs with ones added by "untrusted-inputs" in the
+	// config file. It is built lazily since config is set after the rule is created.
+	untrustedInputs UntrustedInputSearchRoots
 }
 
 // NewRuleExpression creates new RuleExpression instance.
@@ -45,6 +74,7 @@ func NewRuleExpression(actionsCache *LocalActionsCache, workflowCache *LocalReus
 		inputsTy:         nil,
 		dispatchInputsTy: nil,
 		jobsTy:           nil,
+		eventNames:       nil,
 		workflow:         nil,
 		localActions:     actionsCache,
 		localWorkflows:   workflowCache,
@@ -53,28 +83,28 @@ func NewRuleExpression(actionsCache *LocalActionsCache, workflowCache *LocalReus
 
 // VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
 func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
-	rule.checkString(n.Name, "")
+	rule.checkString(n.Name, "name")
 
 	for _, e := range n.On {
 		switch e := e.(type) {
 		case *WebhookEvent:
-			rule.checkStrings(e.Types, "")
+			rule.checkStrings(e.Types, "on.<event>.types")
 			rule.checkWebhookEventFilter(e.Branches)
 			rule.checkWebhookEventFilter(e.BranchesIgnore)
 			rule.checkWebhookEventFilter(e.Tags)
 			rule.checkWebhookEventFilter(e.TagsIgnore)
 			rule.checkWebhookEventFilter(e.Paths)
 			rule.checkWebhookEventFilter(e.PathsIgnore)
-			rule.checkStrings(e.Workflows, "")
+			rule.checkStrings(e.Workflows, "on.workflow_run.workflows")
 		case *ScheduledEvent:
-			rule.checkStrings(e.Cron, "")
+			rule.checkStrings(e.Cron, "on.schedule.cron")
 		case *WorkflowDispatchEvent:
 			ity := NewEmptyStrictObjectType()
 			for id, i := range e.Inputs {
-				rule.checkString(i.Description, "")
-				rule.checkString(i.Default, "")
+				rule.checkString(i.Description, "on.workflow_dispatch.inputs.<input_id>.description")
+				rule.checkString(i.Default, "on.workflow_dispatch.inputs.<input_id>.default")
 				rule.checkBool(i.Required, "")
-				rule.checkStrings(i.Options, "")
+				rule.checkStrings(i.Options, "on.workflow_dispatch.inputs.<input_id>.options")
 
 				var ty ExprType
 				switch i.Type {
@@ -91,7 +121,7 @@ func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
 			}
 			rule.dispatchInputsTy = ity
 		case *RepositoryDispatchEvent:
-			rule.checkStrings(e.Types, "")
+			rule.checkStrings(e.Types, "on.<event>.types")
 		case *WorkflowCallEvent:
 			ity := NewEmptyStrictObjectType()
 
@@ -105,7 +135,7 @@ func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
 			rule.inputsTy = ity
 
 			for _, i := range e.Inputs {
-				rule.checkString(i.Description, "")
+				rule.checkString(i.Description, "on.workflow_call.inputs.<input_id>.description")
 				// Check default value before setting type to `ity` because referring myself should cause an error.
 				//   inputs:
 				//     recursive:
@@ -150,13 +180,19 @@ func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
 				sty := NewEmptyStrictObjectType()
 				for id, s := range e.Secrets {
 					sty.Props[id] = StringType{}
-					rule.checkString(s.Description, "")
+					rule.checkString(s.Description, "on.workflow_call.secrets.<secret_id>.description")
+				}
+				// When some caller in the project passes `secrets: inherit`, all secrets of the caller are
+				// available in addition to the declared secrets.
+				if rule.localWorkflows.SecretsInherited(rule.workflowPath) {
+					rule.Debug("Undeclared secrets are allowed since %q is called with \"secrets: inherit\"", rule.workflowPath)
+					sty.Mapped = StringType{}
 				}
 				rule.secretsTy = sty
 			}
 
 			for _, o := range e.Outputs {
-				rule.checkString(o.Description, "")
+				rule.checkString(o.Description, "on.workflow_call.outputs.<output_id>.description")
 				// o.Value will be checked in VisitWorkflowPost
 			}
 		}
@@ -181,12 +217,60 @@ func (rule *RuleExpression) VisitWorkflowPost(n *Workflow) error {
 	return nil
 }
 
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleExpression) VisitActionPre(n *Action) error {
+	rule.action = n
+
+	// Default values of inputs are evaluated as if they were passed at "with:" of the step using the action
+	ity := NewEmptyStrictObjectType()
+	for id, i := range n.Inputs {
+		rule.checkString(i.Default, "jobs.<job_id>.steps.with")
+		ity.Props[id] = StringType{} // Inputs of actions are always strings
+	}
+	rule.inputsTy = ity
+
+	// `matrix` and `needs` contexts are inherited from the job running the action. Their properties are unknown
+	rule.matrixTy = NewEmptyObjectType()
+	rule.needsTy = NewEmptyObjectType()
+	rule.stepsTy = NewEmptyStrictObjectType()
+
+	if r := n.Runs; r != nil {
+		rule.checkIfCondition(r.PreIf, "jobs.<job_id>.steps.if")
+		rule.checkIfCondition(r.PostIf, "jobs.<job_id>.steps.if")
+		rule.checkStrings(r.Args, "jobs.<job_id>.steps.with")
+		rule.checkEnv(r.Env, "jobs.<job_id>.steps.with")
+	}
+
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleExpression) VisitActionPost(n *Action) error {
+	// Outputs of composite action are evaluated after all steps are run
+	for _, o := range n.Outputs {
+		rule.checkString(o.Value, "jobs.<job_id>.outputs.<output_id>")
+	}
+
+	rule.action = nil
+	rule.inputsTy = nil
+	rule.matrixTy = nil
+	rule.needsTy = nil
+	rule.stepsTy = nil
+
+	return nil
+}
+
 // VisitJobPre is callback when visiting Job node before visiting its children.
 func (rule *RuleExpression) VisitJobPre(n *Job) error {
 	// Type of needs must be resolved before resolving type of matrix because `needs` context can
 	// be used in matrix configuration.
 	rule.needsTy = rule.calcNeedsType(n)
 
+	// Events which trigger the job can be narrowed by `github.event_name` condition at `if:`. For example,
+	// `github.event.pull_request` is not available in the following job.
+	//   if: github.event_name == 'push'
+	rule.eventNames = eventNamesGuardedByIfCond(n.If)
+
 	// Set matrix type at start of VisitJobPre() because matrix values are available in
 	// jobs.<job_id> section. For example:
 	//   jobs:
@@ -201,7 +285,7 @@ func (rule *RuleExpression) VisitJobPre(n *Job) error {
 	}
 
 	rule.checkString(n.Name, "jobs.<job_id>.name")
-	rule.checkStrings(n.Needs, "")
+	rule.checkStrings(n.Needs, "jobs.<job_id>.needs")
 
 	if n.RunsOn != nil {
 		if n.RunsOn.LabelsExpr != nil {
@@ -266,12 +350,19 @@ func (rule *RuleExpression) VisitJobPost(n *Job) error {
 	rule.matrixTy = nil
 	rule.stepsTy = nil
 	rule.needsTy = nil
+	rule.eventNames = nil
 
 	return nil
 }
 
 // VisitStep is callback when visiting Step node.
 func (rule *RuleExpression) VisitStep(n *Step) error {
+	if names := eventNamesGuardedByIfCond(n.If); names != nil {
+		saved := rule.eventNames
+		rule.eventNames = intersectEventNames(saved, names)
+		defer func() { rule.eventNames = saved }()
+	}
+
 	rule.checkString(n.Name, "jobs.<job_id>.steps.name")
 	rule.checkIfCondition(n.If, "jobs.<job_id>.steps.if")
 
@@ -282,7 +373,7 @@ func (rule *RuleExpression) VisitStep(n *Step) error {
 		rule.checkString(e.Shell, "")
 		rule.checkString(e.WorkingDirectory, "jobs.<job_id>.steps.working-directory")
 	case *ExecAction:
-		rule.checkString(e.Uses, "")
+		rule.checkString(e.Uses, "jobs.<job_id>.steps.uses")
 		for n, i := range e.Inputs {
 			if e.Uses != nil && strings.HasPrefix(e.Uses.Value, "actions/github-script@") && n == "script" {
 				rule.checkScriptString(i.Value, "jobs.<job_id>.steps.with")
@@ -576,7 +667,7 @@ func (rule *RuleExpression) checkWebhookEventFilter(f *WebhookEventFilter) {
 	if f == nil {
 		return
 	}
-	rule.checkStrings(f.Values, "")
+	rule.checkStrings(f.Values, "on.<event>.<filter>")
 }
 
 func (rule *RuleExpression) checkStrings(ss []*String, workflowKey string) {
@@ -642,6 +733,28 @@ func (rule *RuleExpression) checkIfCondition(str *String, workflowKey string) {
 	}
 }
 
+// eventNamesGuardedByIfCond returns event names narrowed by the `if:` condition such as
+// `if: github.event_name == 'push'`. It returns nil when the condition does not narrow the events.
+func eventNamesGuardedByIfCond(cond *String) []string {
+	if cond == nil {
+		return nil
+	}
+	src := cond.Value
+	if cond.ContainsExpression() {
+		if !cond.IsExpressionAssigned() {
+			return nil
+		}
+		src = strings.TrimSpace(src)[3:] // 3 means removing "${{"
+	} else {
+		src += "}}" // }} is necessary since lexer lexes it as end of tokens
+	}
+	expr, err := NewExprParser().Parse(NewExprLexer(src))
+	if err != nil {
+		return nil // The syntax error is reported by checkIfCondition
+	}
+	return eventNamesGuardedBy(expr)
+}
+
 func (rule *RuleExpression) checkTemplateEvaluatedType(ts []typedExpr) {
 	for _, t := range ts {
 		switch t.ty.(type) {
@@ -713,10 +826,16 @@ func (rule *RuleExpression) checkFloat(f *Float, workflowKey string) {
 func (rule *RuleExpression) checkExprsIn(s string, pos *Pos, quoted, checkUntrusted bool, workflowKey string) ([]typedExpr, bool) {
 	// TODO: Line number is not correct when the string contains newlines.
 
+	src := s
 	line, col := pos.Line, pos.Col
 	if quoted {
 		col++ // when the string is quoted like 'foo' or "foo", column should be incremented
 	}
+
+	if _, ok := literalExprKeys[workflowKey]; ok {
+		rule.checkLiteralExpr(s, line, col, workflowKey)
+		return nil, false
+	}
 	offset := 0
 	ts := []typedExpr{}
 	for {
@@ -728,7 +847,7 @@ func (rule *RuleExpression) checkExprsIn(s string, pos *Pos, quoted, checkUntrus
 		start := idx + 3 // 3 means removing "${{"
 		s = s[start:]
 		offset += start
-		col := col + offset
+		col := col + utf8.RuneCountInString(src[:offset]) // Column counts characters, not bytes
 
 		ty, offsetAfter, ok := rule.checkSemantics(s, line, col, checkUntrusted, workflowKey)
 		if !ok {
@@ -746,6 +865,25 @@ func (rule *RuleExpression) checkExprsIn(s string, pos *Pos, quoted, checkUntrus
 	return ts, true
 }
 
+// checkLiteralExpr reports ${{ }} placeholder in the string at the workflow key where expressions
+// are not evaluated.
+func (rule *RuleExpression) checkLiteralExpr(s string, line, col int, workflowKey string) {
+	start := strings.Index(s, "${{")
+	if start == -1 {
+		return
+	}
+	placeholder := s[start:]
+	if end := strings.Index(placeholder, "}}"); end != -1 {
+		placeholder = placeholder[:end+2]
+	}
+	rule.Errorf(
+		&Pos{Line: line, Col: col + utf8.RuneCountInString(s[:start])},
+		"expression is not available at %q. %q is not evaluated and it is treated as a literal string. see https://docs.github.com/en/actions/learn-github-actions/contexts#context-availability for the keys where expressions are available",
+		workflowKey,
+		placeholder,
+	)
+}
+
 func (rule *RuleExpression) exprError(err *ExprError, lineBase, colBase int) {
 	pos := convertExprLineColToPos(err.Line, err.Column, lineBase, colBase)
 	rule.Error(pos, err.Message)
@@ -757,6 +895,15 @@ func (rule *RuleExpression) checkSemanticsOfExprNode(expr ExprNode, line, col in
 		v = rule.config.ConfigVariables
 	}
 	c := NewExprSemanticsChecker(checkUntrusted, v)
+	if checkUntrusted && rule.config != nil && len(rule.config.UntrustedInputs) > 0 {
+		if rule.untrustedInputs == nil {
+			rule.untrustedInputs = BuiltinUntrustedInputs.Copy()
+			for _, p := range rule.config.UntrustedInputs {
+				rule.untrustedInputs.AddPath(p)
+			}
+		}
+		c.SetUntrustedInputs(rule.untrustedInputs)
+	}
 	if rule.matrixTy != nil {
 		c.UpdateMatrix(rule.matrixTy)
 	}
@@ -778,13 +925,21 @@ func (rule *RuleExpression) checkSemanticsOfExprNode(expr ExprNode, line, col in
 	if rule.jobsTy != nil {
 		c.UpdateJobs(rule.jobsTy)
 	}
+	if rule.eventNames != nil {
+		c.SetEventNames(rule.eventNames)
+	}
 	if workflowKey != "" {
 		ctx, sp := WorkflowKeyAvailability(workflowKey)
 		if len(ctx) == 0 {
 			rule.Debug("No context availability was found for workflow key %q", workflowKey)
 		}
+		if rule.action != nil {
+			ctx = contextsAvailableInAction(ctx)
+		}
 		c.SetContextAvailability(ctx)
 		c.SetSpecialFunctionAvailability(sp)
+	} else {
+		c.SetContextAvailability(nil) // No context is available at keys which don't allow expressions
 	}
 
 	ty, errs := c.Check(expr)
@@ -795,6 +950,19 @@ func (rule *RuleExpression) checkSemanticsOfExprNode(expr ExprNode, line, col in
 	return ty, len(errs) == 0
 }
 
+// contextsAvailableInAction filters the available contexts for action metadata. `secrets` context
+// is not available in actions. Secrets must be passed to actions via inputs.
+// https://docs.github.com/en/actions/creating-actions/creating-a-composite-action
+func contextsAvailableInAction(ctx []string) []string {
+	ret := make([]string, 0, len(ctx))
+	for _, c := range ctx {
+		if c != "secrets" {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
 func (rule *RuleExpression) checkSemantics(src string, line, col int, checkUntrusted bool, workflowKey string) (ExprType, int, bool) {
 	l := NewExprLexer(src)
 	p := NewExprParser()
@@ -817,7 +985,7 @@ func (rule *RuleExpression) calcNeedsType(job *Job) *ObjectType {
 func (rule *RuleExpression) populateDependantNeedsTypes(out *ObjectType, job *Job, root *Job) {
 	for _, id := range job.Needs {
 		i := strings.ToLower(id.Value) // ID is case insensitive
-		if i == root.ID.Value {
+		if i == strings.ToLower(root.ID.Value) {
 			continue // When cyclic dependency exists. This does not happen normally.
 		}
 		if _, ok := out.Props[i]; ok {
@@ -908,6 +1076,7 @@ func (rule *RuleExpression) checkMatrix(m *Matrix) *ObjectType {
 	o := NewEmptyStrictObjectType()
 
 	for n, r := range m.Rows {
+		rule.checkString(r.Name, "jobs.<job_id>.strategy.matrix.<variable>")
 		o.Props[n] = rule.checkMatrixRow(r)
 	}
 
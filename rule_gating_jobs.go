@@ -0,0 +1,48 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// RuleGatingJobs is a rule to check jobs which are meant to be required status checks do not set
+// `continue-on-error: true`. Failures of such jobs are silently ignored and do not block merging.
+// The gating jobs are configured with "gating-jobs" in the config file.
+type RuleGatingJobs struct {
+	RuleBase
+}
+
+// NewRuleGatingJobs creates new RuleGatingJobs instance.
+func NewRuleGatingJobs() *RuleGatingJobs {
+	return &RuleGatingJobs{
+		RuleBase: RuleBase{
+			name: "gating-jobs",
+			desc: "Checks for \"continue-on-error: true\" at jobs configured as gating jobs in \"gating-jobs\"",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleGatingJobs) VisitJobPre(n *Job) error {
+	c := n.ContinueOnError
+	if c == nil || c.Expression != nil || !c.Value || n.ID == nil {
+		return nil
+	}
+
+	cfg := rule.Config()
+	if cfg == nil {
+		return nil
+	}
+
+	for _, id := range cfg.GatingJobs {
+		if strings.EqualFold(id, n.ID.Value) {
+			rule.Errorf(
+				c.Pos,
+				"job %q is configured as a gating job in \"gating-jobs\" but \"continue-on-error: true\" is set. failures of the job are ignored and do not block merging even if it is a required status check",
+				n.ID.Value,
+			)
+			return nil
+		}
+	}
+
+	return nil
+}
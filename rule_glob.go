@@ -1,9 +1,17 @@
 package actionlint
 
+import (
+	"fmt"
+	"strings"
+)
+
 // RuleGlob is a rule to check glob syntax.
 // https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions#filter-pattern-cheat-sheet
 type RuleGlob struct {
 	RuleBase
+	// project is the repository which the workflow belongs to. It is used for checking path filters
+	// match to files in the repository. Nil when the workflow is not in a repository.
+	project *Project
 }
 
 // NewRuleGlob creates new RuleGlob instance.
@@ -20,12 +28,17 @@ func NewRuleGlob() *RuleGlob {
 func (rule *RuleGlob) VisitWorkflowPre(n *Workflow) error {
 	for _, e := range n.On {
 		if w, ok := e.(*WebhookEvent); ok {
+			for _, f := range []*WebhookEventFilter{w.Branches, w.BranchesIgnore, w.Tags, w.TagsIgnore, w.Paths, w.PathsIgnore} {
+				rule.checkNegationOrder(f)
+			}
 			rule.checkGitRefGlobs(w.Branches)
 			rule.checkGitRefGlobs(w.BranchesIgnore)
 			rule.checkGitRefGlobs(w.Tags)
 			rule.checkGitRefGlobs(w.TagsIgnore)
 			rule.checkFilePathGlobs(w.Paths)
 			rule.checkFilePathGlobs(w.PathsIgnore)
+			rule.checkFilePathGlobsMatch(w.Paths)
+			rule.checkFilePathGlobsMatch(w.PathsIgnore)
 		}
 	}
 	return nil
@@ -43,6 +56,34 @@ func (rule *RuleGlob) checkGitRefGlobs(filter *WebhookEventFilter) {
 	}
 }
 
+// checkNegationOrder checks negated patterns starting with '!' follow some pattern without '!'.
+// Patterns are evaluated in order and a negated pattern only excludes values matched by the
+// preceding patterns. So negated patterns before any positive pattern have no effect.
+func (rule *RuleGlob) checkNegationOrder(filter *WebhookEventFilter) {
+	if filter == nil {
+		return
+	}
+	for _, v := range filter.Values {
+		if !strings.HasPrefix(v.Value, "!") {
+			return
+		}
+		if v.Value == "!" {
+			continue // Invalid pattern is reported by glob validation
+		}
+		hint := "reorder the patterns"
+		if !strings.HasSuffix(filter.Name.Value, "-ignore") {
+			hint = fmt.Sprintf("use %q filter to only exclude some values", filter.Name.Value+"-ignore")
+		}
+		rule.Errorf(
+			v.Pos,
+			"negated pattern %q in %q filter is not preceded by any pattern without '!'. negated pattern only excludes values matched by the preceding patterns so it has no effect. %s",
+			v.Value,
+			filter.Name.Value,
+			hint,
+		)
+	}
+}
+
 func (rule *RuleGlob) checkFilePathGlobs(filter *WebhookEventFilter) {
 	if filter == nil {
 		return
@@ -55,6 +96,63 @@ func (rule *RuleGlob) checkFilePathGlobs(filter *WebhookEventFilter) {
 	}
 }
 
+// checkFilePathGlobsMatch checks each pattern of the path filter matches to at least one file in
+// the repository. Patterns are evaluated in order. A negated pattern starting with '!' should
+// exclude some file matched by the preceding patterns.
+func (rule *RuleGlob) checkFilePathGlobsMatch(filter *WebhookEventFilter) {
+	if filter == nil || rule.project == nil {
+		return
+	}
+	if cfg := rule.Config(); cfg == nil || !cfg.CheckPathGlobs {
+		return
+	}
+
+	files, err := rule.project.Files()
+	if err != nil {
+		rule.Debug("Skip checking patterns of %q filter match to files: %s", filter.Name.Value, err)
+		return
+	}
+
+	matched := map[string]struct{}{}
+	for _, v := range filter.Values {
+		pat := strings.TrimPrefix(v.Value, "!")
+		neg := len(pat) < len(v.Value)
+		if pat == "" || len(ValidatePathGlob(pat)) > 0 {
+			continue // Invalid patterns are already reported
+		}
+		re, err := compilePathGlob(pat)
+		if err != nil {
+			rule.Debug("Skip checking pattern %q matches to files: %s", v.Value, err)
+			continue
+		}
+
+		if neg {
+			excluded := false
+			for f := range matched {
+				if re.MatchString(f) {
+					delete(matched, f)
+					excluded = true
+				}
+			}
+			if !excluded {
+				rule.Errorf(v.Pos, "negated pattern %q in %q filter excludes no file matched by the preceding patterns in the repository. the pattern may be wrong", v.Value, filter.Name.Value)
+			}
+			continue
+		}
+
+		found := false
+		for _, f := range files {
+			if re.MatchString(f) {
+				matched[f] = struct{}{}
+				found = true
+			}
+		}
+		if !found {
+			rule.Errorf(v.Pos, "pattern %q in %q filter matches no file in the repository. the pattern may be wrong", v.Value, filter.Name.Value)
+		}
+	}
+}
+
 func (rule *RuleGlob) globErrors(errs []InvalidGlobPattern, pos *Pos, quoted bool) {
 	for i := range errs {
 		err := &errs[i]
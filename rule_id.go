@@ -11,6 +11,8 @@ var jobIDPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
 type RuleID struct {
 	RuleBase
 	seen map[string]*Pos
+	// scope is where step IDs must be unique. "job" or "composite action".
+	scope string
 }
 
 // NewRuleID creates a new RuleID instance.
@@ -26,6 +28,7 @@ func NewRuleID() *RuleID {
 // VisitJobPre is callback when visiting Job node before visiting its children.
 func (rule *RuleID) VisitJobPre(n *Job) error {
 	rule.seen = map[string]*Pos{}
+	rule.scope = "job"
 
 	rule.validateConvention(n.ID, "job")
 	for _, j := range n.Needs {
@@ -41,6 +44,19 @@ func (rule *RuleID) VisitJobPost(n *Job) error {
 	return nil
 }
 
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleID) VisitActionPre(n *Action) error {
+	rule.seen = map[string]*Pos{}
+	rule.scope = "composite action"
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleID) VisitActionPost(n *Action) error {
+	rule.seen = nil
+	return nil
+}
+
 // VisitStep is callback when visiting Step node.
 func (rule *RuleID) VisitStep(n *Step) error {
 	if n.ID == nil {
@@ -51,7 +67,7 @@ func (rule *RuleID) VisitStep(n *Step) error {
 
 	id := strings.ToLower(n.ID.Value)
 	if prev, ok := rule.seen[id]; ok {
-		rule.Errorf(n.ID.Pos, "step ID %q duplicates. previously defined at %s. step ID must be unique within a job. note that step ID is case insensitive", n.ID.Value, prev.String())
+		rule.Errorf(n.ID.Pos, "step ID %q duplicates. previously defined at %s. step ID must be unique within a %s. note that step ID is case insensitive", n.ID.Value, prev.String(), rule.scope)
 		return nil
 	}
 	rule.seen[id] = n.ID.Pos
@@ -1,6 +1,8 @@
 package actionlint
 
 import (
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -31,20 +33,230 @@ func (rule *RuleIfCond) VisitJobPre(n *Job) error {
 	return nil
 }
 
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleIfCond) VisitActionPre(n *Action) error {
+	if n.Runs != nil {
+		rule.checkIfCond(n.Runs.PreIf)
+		rule.checkIfCond(n.Runs.PostIf)
+	}
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleIfCond) VisitActionPost(n *Action) error {
+	return nil
+}
+
 func (rule *RuleIfCond) checkIfCond(n *String) {
 	if n == nil {
 		return
 	}
-	if !n.ContainsExpression() {
-		return
+
+	src := n.Value
+	if n.ContainsExpression() {
+		// Check extra characters around ${{ }} for conditions like `${{ false }} || ${{ true }}` or
+		// `${{ success() }} && failure()` which are always evaluated to true. The end of ${{ }} is
+		// found by the lexer since a string literal in the expression may contain "${{" or "}}".
+		whole := false
+		if strings.HasPrefix(n.Value, "${{") {
+			l := NewExprLexer(n.Value[3:]) // 3 means removing "${{"
+			if _, err := NewExprParser().Parse(l); err != nil {
+				return // Syntax error is reported by RuleExpression
+			}
+			end := 3 + l.Offset()
+			whole = end == len(n.Value)
+			src = n.Value[3 : end-2] // 2 means removing "}}"
+		}
+		if !whole {
+			rule.Errorf(
+				n.Pos,
+				"if: condition %q is always evaluated to true because extra characters are around ${{ }}",
+				n.Value,
+			)
+			return
+		}
+	}
+
+	rule.checkConstantCond(n, src)
+}
+
+// checkConstantCond reports the condition when the whole expression is folded into a constant value.
+// Conditions like `${{ true }}` or `github.event_name == 'push' || true` are often leftovers of
+// debugging. Conditions depending on any context or function call are not reported.
+func (rule *RuleIfCond) checkConstantCond(n *String, src string) {
+	p := NewExprParser()
+	expr, err := p.Parse(NewExprLexer(src + "}}")) // }} is necessary since lexer lexes it as end of tokens
+	if err != nil {
+		return // Syntax error is reported by RuleExpression
 	}
-	// Check number of ${{ }} for conditions like `${{ false }} || ${{ true }}` which are always evaluated to true
-	if strings.HasPrefix(n.Value, "${{") && strings.HasSuffix(n.Value, "}}") && strings.Count(n.Value, "${{") == 1 {
+
+	b, ok := constTruthiness(expr)
+	if !ok {
 		return
 	}
 	rule.Errorf(
 		n.Pos,
-		"if: condition %q is always evaluated to true because extra characters are around ${{ }}",
+		"if: condition %q is always evaluated to %t regardless of contexts and function calls. remove the condition or fix it if it is a leftover of debugging",
 		n.Value,
+		b,
 	)
 }
+
+// constTruthiness returns truthiness of the expression when it is statically determined. The second
+// return value is false when the truthiness depends on contexts or function calls. Note that `x || true`
+// is always truthy even if `x` is unknown.
+func constTruthiness(n ExprNode) (bool, bool) {
+	switch n := n.(type) {
+	case *NotOpNode:
+		b, ok := constTruthiness(n.Operand)
+		return !b, ok
+	case *LogicalOpNode:
+		l, lok := constTruthiness(n.Left)
+		r, rok := constTruthiness(n.Right)
+		switch n.Kind {
+		case LogicalOpNodeKindOr:
+			if lok && l || rok && r {
+				return true, true
+			}
+		case LogicalOpNodeKindAnd:
+			if lok && !l || rok && !r {
+				return false, true
+			}
+		}
+		return l, lok && rok
+	}
+	v, ok := constValue(n)
+	if !ok {
+		return false, false
+	}
+	return truthy(v), true
+}
+
+// constValue folds the expression into a constant value. The value is nil, bool, float64 or string.
+// The second return value is false when the expression cannot be folded.
+func constValue(n ExprNode) (any, bool) {
+	switch n := n.(type) {
+	case *NullNode:
+		return nil, true
+	case *BoolNode:
+		return n.Value, true
+	case *IntNode:
+		return float64(n.Value), true
+	case *FloatNode:
+		return n.Value, true
+	case *StringNode:
+		return n.Value, true
+	case *NotOpNode:
+		v, ok := constValue(n.Operand)
+		return !truthy(v), ok
+	case *LogicalOpNode:
+		l, ok := constValue(n.Left)
+		if !ok {
+			return nil, false
+		}
+		// `a || b` and `a && b` are evaluated to the value of `a` or `b` as JavaScript
+		if truthy(l) == (n.Kind == LogicalOpNodeKindOr) {
+			return l, true
+		}
+		return constValue(n.Right)
+	case *CompareOpNode:
+		l, ok := constValue(n.Left)
+		if !ok {
+			return nil, false
+		}
+		r, ok := constValue(n.Right)
+		if !ok {
+			return nil, false
+		}
+		return compareConstValues(n.Kind, l, r), true
+	default:
+		return nil, false
+	}
+}
+
+// truthy returns the value is truthy or not following the rule of GitHub Actions expressions.
+// https://docs.github.com/en/actions/learn-github-actions/expressions#operators
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	default:
+		return false
+	}
+}
+
+// constToNumber coerces the value to a number for comparing values of different types.
+func constToNumber(v any) float64 {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+		if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return float64(i)
+		}
+		return math.NaN()
+	default:
+		return 0
+	}
+}
+
+// compareConstValues compares two constant values. Strings are compared case-insensitively and
+// values of different types are coerced to numbers.
+// https://docs.github.com/en/actions/learn-github-actions/expressions#operators
+func compareConstValues(kind CompareOpNodeKind, l, r any) bool {
+	ls, lok := l.(string)
+	rs, rok := r.(string)
+	if lok && rok {
+		c := strings.Compare(strings.ToUpper(ls), strings.ToUpper(rs))
+		switch kind {
+		case CompareOpNodeKindLess:
+			return c < 0
+		case CompareOpNodeKindLessEq:
+			return c <= 0
+		case CompareOpNodeKindGreater:
+			return c > 0
+		case CompareOpNodeKindGreaterEq:
+			return c >= 0
+		case CompareOpNodeKindEq:
+			return c == 0
+		case CompareOpNodeKindNotEq:
+			return c != 0
+		default:
+			return false
+		}
+	}
+
+	lf, rf := constToNumber(l), constToNumber(r)
+	switch kind {
+	case CompareOpNodeKindLess:
+		return lf < rf
+	case CompareOpNodeKindLessEq:
+		return lf <= rf
+	case CompareOpNodeKindGreater:
+		return lf > rf
+	case CompareOpNodeKindGreaterEq:
+		return lf >= rf
+	case CompareOpNodeKindEq:
+		return lf == rf
+	case CompareOpNodeKindNotEq:
+		return lf != rf
+	default:
+		return false
+	}
+}
@@ -11,13 +11,28 @@ func TestRuleIfCond(t *testing.T) {
 		valid bool
 	}{
 		{"", true},
-		{"true", true},
-		{"true || false", true},
-		{"${{ false }}", true},
+		{"github.event_name == 'push'", true},
+		{"${{ github.event_name == 'push' }}", true},
+		{"${{ matrix.os && true }}", true},
+		{"${{ success() || false }}", true},
+		{"${{ contains('abc', 'b') }}", true},
+		{"${{ startsWith(github.head_ref, '${{') }}", true},
+		{"${{ github.head_ref != '}} ${{' }}", true},
+		{"true", false},
+		{"true || false", false},
+		{"${{ false }}", false},
+		{"${{ 'false' }}", false},
+		{"${{ !null }}", false},
+		{"${{ github.event_name == 'push' || true }}", false},
+		{"${{ false && github.event_name == 'push' }}", false},
+		{"${{ 'Foo' == 'foo' }}", false},
+		{"${{ '42' == 42 }}", false},
 		{"${{ false }}\n", false},
 		{"${{ false }} ", false},
 		{" ${{ false }}", false},
 		{"${{ true }} && ${{ true }}", false},
+		{"${{ success() }} && failure()", false},
+		{"!${{ success() }}", false},
 	}
 
 	for _, tc := range tests {
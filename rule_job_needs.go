@@ -31,17 +31,28 @@ type edge struct {
 type RuleJobNeeds struct {
 	RuleBase
 	nodes map[string]*jobNode
+	exprs exprCollector
+	// used is a set of job IDs referenced via "needs" context in the current job. Keys are in lower case.
+	used map[string]struct{}
+	// all is set to true when the entire "needs" context is referenced in the current job like
+	// toJSON(needs), needs[matrix.job], or needs.*.result.
+	all bool
+	// dynamic is the position of the string where the entire "needs" context is referenced and the
+	// reference is not checked by the type checker like toJSON(needs). Nil when no such reference exists.
+	dynamic *Pos
 }
 
 // NewRuleJobNeeds creates new RuleJobNeeds instance.
 func NewRuleJobNeeds() *RuleJobNeeds {
-	return &RuleJobNeeds{
+	r := &RuleJobNeeds{
 		RuleBase: RuleBase{
 			name: "job-needs",
-			desc: "Checks for job IDs in \"needs:\". Undefined IDs and cyclic dependencies are checked",
+			desc: "Checks for job IDs in \"needs:\". Undefined IDs, cyclic dependencies, and \"needs\" context in jobs without \"needs:\" are checked",
 		},
 		nodes: map[string]*jobNode{},
 	}
+	r.exprs.visit = r.collectExpr
+	return r
 }
 
 func contains[T comparable](heystack []T, needle T) bool {
@@ -69,6 +80,11 @@ func (rule *RuleJobNeeds) VisitJobPre(n *Job) error {
 		}
 	}
 
+	rule.used = map[string]struct{}{}
+	rule.all = false
+	rule.dynamic = nil
+	rule.exprs.collectJob(n)
+
 	id := strings.ToLower(n.ID.Value)
 	if id == "" {
 		return nil
@@ -87,6 +103,72 @@ func (rule *RuleJobNeeds) VisitJobPre(n *Job) error {
 	return nil
 }
 
+// VisitStep is callback when visiting Step node.
+func (rule *RuleJobNeeds) VisitStep(n *Step) error {
+	rule.exprs.collectStep(n)
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleJobNeeds) VisitJobPost(n *Job) error {
+	if len(n.Needs) == 0 {
+		// Accessing properties like needs.foo or needs.*.result is reported by "expression" rule
+		// since the type of "needs" context is an empty object. Here reports the references to the
+		// entire context which the type checker cannot catch.
+		if rule.dynamic != nil {
+			rule.Errorf(
+				rule.dynamic,
+				"\"needs\" context is referenced but job %q has no \"needs:\" section. the context is always empty object. add the jobs this job depends on to \"needs:\"",
+				n.ID.Value,
+			)
+		}
+	} else if cfg := rule.Config(); cfg != nil && cfg.CheckUnusedNeeds && !rule.all {
+		for _, j := range n.Needs {
+			if _, ok := rule.used[strings.ToLower(j.Value)]; ok || j.Value == "" {
+				continue
+			}
+			rule.Errorf(
+				j.Pos,
+				"job %q at \"needs:\" is never used via \"needs\" context in job %q. the job waits for %q to finish without using its outputs or result. remove it from \"needs:\" if the dependency is not necessary",
+				j.Value,
+				n.ID.Value,
+				j.Value,
+			)
+		}
+	}
+
+	rule.used = nil
+	rule.all = false
+	rule.dynamic = nil
+	return nil
+}
+
+func (rule *RuleJobNeeds) collectExpr(expr ExprNode, pos *Pos) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+
+		path := propertyPathOf(n)
+		if len(path) == 0 || path[0] != "needs" {
+			return
+		}
+
+		switch len(path) {
+		case 1:
+			if _, ok := staticPropertyAccess(n, p); ok {
+				return
+			}
+			rule.all = true // e.g. toJSON(needs), needs[matrix.job], needs.*.result
+			if _, ok := p.(*ArrayDerefNode); !ok && rule.dynamic == nil {
+				rule.dynamic = pos
+			}
+		case 2:
+			rule.used[path[1]] = struct{}{}
+		}
+	})
+}
+
 // VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
 func (rule *RuleJobNeeds) VisitWorkflowPost(n *Workflow) error {
 	// Resolve nodes
@@ -0,0 +1,107 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// RuleJobOutputs is a rule to check outputs of steps referenced at `outputs:` of jobs are actually
+// set by the steps. When the referenced output is not set, the job output is silently empty.
+// Undefined step IDs and outputs of actions are checked by "expression" rule with the types of the
+// steps. This rule checks outputs of steps at `run:` by parsing the scripts. Steps whose scripts
+// set outputs in the way the heuristic cannot parse are not checked. Since the scripts may run other
+// scripts which set outputs, this rule reports warnings by default.
+type RuleJobOutputs struct {
+	RuleBase
+	exprs exprCollector
+	// steps is a mapping from step IDs in lower case to the sorted output names set by the steps.
+	// Steps whose outputs cannot be known are not included.
+	steps map[string]*stepOutputs
+	// output is the name of the job output being checked.
+	output string
+}
+
+// NewRuleJobOutputs creates new RuleJobOutputs instance.
+func NewRuleJobOutputs() *RuleJobOutputs {
+	r := &RuleJobOutputs{
+		RuleBase: RuleBase{
+			name: "job-outputs",
+			desc: "Checks for outputs of steps referenced at \"outputs:\" of jobs which are not set in scripts of the steps",
+		},
+	}
+	r.exprs.visit = r.checkExpr
+	return r
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleJobOutputs) VisitJobPre(n *Job) error {
+	rule.steps = map[string]*stepOutputs{}
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleJobOutputs) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecRun)
+	if !ok || n.ID == nil || n.ID.ContainsExpression() || e.Run == nil {
+		return nil
+	}
+	// When the script sets no output directly, the outputs may be set by some other script it runs
+	names, complete := scriptStepOutputs(e.Run.Value)
+	if !complete || len(names) == 0 {
+		rule.Debug("Outputs of step %q at %s cannot be known from the script", n.ID.Value, n.ID.Pos)
+		return nil
+	}
+	rule.steps[strings.ToLower(n.ID.Value)] = &stepOutputs{n.ID, e, names}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleJobOutputs) VisitJobPost(n *Job) error {
+	// Outputs of jobs are evaluated after all steps are run
+	if len(rule.steps) > 0 {
+		for name, o := range n.Outputs {
+			rule.output = name
+			if o.Name != nil {
+				rule.output = o.Name.Value
+			}
+			rule.exprs.collectString(o.Value)
+		}
+	}
+	rule.steps = nil
+	return nil
+}
+
+func (rule *RuleJobOutputs) checkExpr(expr ExprNode, pos *Pos) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+		path := propertyPathOf(n)
+		if len(path) != 3 || path[0] != "steps" || path[2] != "outputs" {
+			return
+		}
+		name, ok := staticPropertyAccess(n, p)
+		if !ok {
+			return
+		}
+		s, ok := rule.steps[path[1]]
+		if !ok {
+			return
+		}
+		for _, o := range s.names {
+			if strings.EqualFold(o, name) {
+				return
+			}
+		}
+
+		rule.Errorf(
+			pos,
+			"output %q of step %q referenced at job output %q is not set in the script at \"run:\" of the step at %s so the job output will be empty.%s outputs set in the script are %s",
+			name,
+			s.id.Value,
+			rule.output,
+			s.run.Run.Pos,
+			didYouMean(name, s.names),
+			quotes(s.names),
+		)
+	})
+}
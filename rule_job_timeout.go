@@ -0,0 +1,49 @@
+package actionlint
+
+// defaultJobTimeoutMinutes is the value of `timeout-minutes` suggested in the error message.
+const defaultJobTimeoutMinutes = 30
+
+// RuleJobTimeout is a rule to check every job sets `timeout-minutes`. Jobs without the timeout can
+// run until the default limit of 360 minutes. This rule is enabled by "require-timeout" in the
+// config file.
+type RuleJobTimeout struct {
+	RuleBase
+}
+
+// NewRuleJobTimeout creates new RuleJobTimeout instance.
+func NewRuleJobTimeout() *RuleJobTimeout {
+	return &RuleJobTimeout{
+		RuleBase: RuleBase{
+			name: "job-timeout",
+			desc: "Checks for jobs missing \"timeout-minutes:\" when \"require-timeout\" is enabled",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleJobTimeout) VisitJobPre(n *Job) error {
+	if n.TimeoutMinutes != nil || n.ID == nil {
+		return nil
+	}
+
+	cfg := rule.Config()
+	if cfg == nil || !cfg.RequireTimeout {
+		return nil
+	}
+
+	// `timeout-minutes` is not available at a job calling a reusable workflow. The jobs in the called
+	// workflow should set it instead.
+	if n.WorkflowCall != nil {
+		rule.Debug("Skip checking \"timeout-minutes\" at job %q since it calls a reusable workflow", n.ID.Value)
+		return nil
+	}
+
+	rule.Errorf(
+		n.Pos,
+		"\"timeout-minutes\" is not set at job %q. the job may run until the default limit of 360 minutes. set a timeout such as \"timeout-minutes: %d\" to the job",
+		n.ID.Value,
+		defaultJobTimeoutMinutes,
+	)
+
+	return nil
+}
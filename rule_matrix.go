@@ -1,6 +1,18 @@
 package actionlint
 
-import "strings"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxMatrixJobs is the maximum number of jobs generated by a matrix per workflow run.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/running-variations-of-jobs-in-a-workflow#using-a-matrix-strategy
+const maxMatrixJobs = 256
+
+// maxMatrixEnumeration is the maximum number of combinations enumerated to count the jobs created by
+// a matrix exactly. When the matrix has more combinations, the lower bound of the jobs is estimated.
+const maxMatrixEnumeration = 65536
 
 // RuleMatrix is a rule checker to check 'matrix' field of job.
 type RuleMatrix struct {
@@ -12,7 +24,7 @@ func NewRuleMatrix() *RuleMatrix {
 	return &RuleMatrix{
 		RuleBase: RuleBase{
 			name: "matrix",
-			desc: "Checks for matrix combinations in \"matrix:\"",
+			desc: "Checks for matrix combinations in \"matrix:\" and \"max-parallel:\"",
 		},
 	}
 }
@@ -40,9 +52,240 @@ func (rule *RuleMatrix) VisitJobPre(n *Job) error {
 	//       sh: pwsh
 
 	rule.checkExclude(m)
+	rule.checkMaxParallel(n.Strategy.MaxParallel, m)
+	rule.checkNumberOfJobs(m)
 	return nil
 }
 
+// checkNumberOfJobs checks the number of jobs created by the matrix does not exceed the limit. The
+// combinations of the rows are filtered by "exclude" section and then "include" section adds new
+// combinations which cannot extend any of the remaining combinations. The check is skipped when the
+// number of values cannot be known statically due to ${{ }}.
+func (rule *RuleMatrix) checkNumberOfJobs(m *Matrix) {
+	if (m.Include != nil && m.Include.ContainsExpression()) || (m.Exclude != nil && m.Exclude.ContainsExpression()) {
+		return
+	}
+
+	names := make([]string, 0, len(m.Rows))
+	for n, r := range m.Rows {
+		if r.Expression != nil {
+			return
+		}
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var includes, excludes []*MatrixCombination
+	if m.Include != nil {
+		includes = m.Include.Combinations
+	}
+	if m.Exclude != nil {
+	Exclude:
+		for _, c := range m.Exclude.Combinations {
+			// Filter with unknown key never matches. It was already reported by checkExclude
+			for k := range c.Assigns {
+				if _, ok := m.Rows[k]; !ok {
+					continue Exclude
+				}
+			}
+			excludes = append(excludes, c)
+		}
+	}
+
+	total := 0
+	if len(names) > 0 {
+		total = 1
+		for _, n := range names {
+			total *= len(m.Rows[n].Values)
+		}
+	}
+	if total == 0 {
+		names = nil // Some row is empty. Only combinations in "include" are run
+	}
+
+	jobs := 0
+	exact := total <= maxMatrixEnumeration
+	if exact {
+		jobs = rule.countMatrixJobs(m, names, includes, excludes)
+	} else {
+		// Each "exclude" filter removes at most the combinations matching to it
+		jobs = total
+		for _, c := range excludes {
+			jobs -= rule.countMatchedCombinations(m, c)
+		}
+		for _, c := range includes {
+			if !rule.canExtendRows(m, c) {
+				jobs++
+			}
+		}
+	}
+
+	if jobs <= maxMatrixJobs {
+		return
+	}
+
+	n := fmt.Sprint(jobs)
+	if !exact {
+		n = "at least " + n
+	}
+	rule.Errorf(
+		m.Pos,
+		"this matrix creates %s jobs but a matrix can create at most %d jobs per workflow run. reduce the combinations with \"exclude\" or split the job",
+		n,
+		maxMatrixJobs,
+	)
+}
+
+// countMatrixJobs counts the jobs created by the matrix by enumerating all combinations of the rows.
+func (rule *RuleMatrix) countMatrixJobs(m *Matrix, names []string, includes, excludes []*MatrixCombination) int {
+	jobs := 0
+	extended := make([]bool, len(includes))
+	if len(names) > 0 {
+		idx := make([]int, len(names))
+		combi := make(map[string]RawYAMLValue, len(names))
+		for {
+			for i, n := range names {
+				combi[n] = m.Rows[n].Values[idx[i]]
+			}
+
+			excluded := false
+			for _, c := range excludes {
+				if matrixCombinationMatches(combi, c, isYAMLValueSubset) {
+					excluded = true
+					break
+				}
+			}
+			if !excluded {
+				jobs++
+				for i, c := range includes {
+					if !extended[i] && matrixCombinationMatches(combi, c, RawYAMLValue.Equals) {
+						extended[i] = true
+					}
+				}
+			}
+
+			// Go to the next combination
+			i := len(idx) - 1
+			for ; i >= 0; i-- {
+				idx[i]++
+				if idx[i] < len(m.Rows[names[i]].Values) {
+					break
+				}
+				idx[i] = 0
+			}
+			if i < 0 {
+				break
+			}
+		}
+	}
+
+	// Combinations in "include" which cannot extend any combination are added as new jobs
+	for _, e := range extended {
+		if !e {
+			jobs++
+		}
+	}
+	return jobs
+}
+
+// matrixCombinationMatches returns true when all values of the combination in "include" or "exclude"
+// section match to the combination of the rows. Keys which don't exist in the rows are ignored since
+// they are new keys added by "include" section.
+func matrixCombinationMatches(combi map[string]RawYAMLValue, c *MatrixCombination, match func(RawYAMLValue, RawYAMLValue) bool) bool {
+	if len(combi) == 0 {
+		return false
+	}
+	for k, a := range c.Assigns {
+		if v, ok := combi[k]; ok && !match(v, a.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// countMatchedCombinations counts the combinations of the rows which match to the combination in
+// "exclude" section.
+func (rule *RuleMatrix) countMatchedCombinations(m *Matrix, c *MatrixCombination) int {
+	count := 1
+	for n, r := range m.Rows {
+		a, ok := c.Assigns[n]
+		if !ok {
+			count *= len(r.Values)
+			continue
+		}
+		matched := 0
+		for _, v := range r.Values {
+			if isYAMLValueSubset(v, a.Value) {
+				matched++
+			}
+		}
+		count *= matched
+	}
+	return count
+}
+
+// canExtendRows returns true when the combination in "include" section may extend some combination
+// of the rows. When some value overwrites the values of the rows, it is always added as a new job.
+func (rule *RuleMatrix) canExtendRows(m *Matrix, c *MatrixCombination) bool {
+	if len(m.Rows) == 0 {
+		return false
+	}
+	for k, a := range c.Assigns {
+		r, ok := m.Rows[k]
+		if !ok {
+			continue
+		}
+		found := false
+		for _, v := range r.Values {
+			if v.Equals(a.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// checkMaxParallel checks "max-parallel" is not greater than the number of combinations of the
+// matrix. Since each combination in "include" section adds at most one job and "exclude" section
+// only removes jobs, the number of jobs is at most the product of the lengths of rows plus the
+// number of combinations in "include".
+func (rule *RuleMatrix) checkMaxParallel(p *Int, m *Matrix) {
+	if p == nil || p.Expression != nil || p.Value <= 0 {
+		return
+	}
+	if m.Include != nil && m.Include.ContainsExpression() {
+		return
+	}
+
+	jobs := 0
+	if len(m.Rows) > 0 {
+		jobs = 1
+		for _, r := range m.Rows {
+			if r.Expression != nil {
+				return
+			}
+			jobs *= len(r.Values)
+		}
+	}
+	if m.Include != nil {
+		jobs += len(m.Include.Combinations)
+	}
+	if jobs == 0 || p.Value <= jobs {
+		return
+	}
+
+	rule.Errorf(
+		p.Pos,
+		"value %d at \"max-parallel\" is greater than the number of jobs created by the matrix. the matrix creates at most %d jobs so \"max-parallel\" has no effect",
+		p.Value,
+		jobs,
+	)
+}
+
 func (rule *RuleMatrix) checkDuplicateInRow(row *MatrixRow) {
 	if row.Values == nil {
 		return // Give up when ${{ }} is specified
@@ -174,6 +417,7 @@ func (rule *RuleMatrix) checkExclude(m *Matrix) {
 	}
 
 	for _, c := range m.Exclude.Combinations {
+		valid := true
 	Exclude:
 		for k, a := range c.Assigns {
 			if _, ok := ignored[k]; ok {
@@ -191,6 +435,7 @@ func (rule *RuleMatrix) checkExclude(m *Matrix) {
 					k,
 					sortedQuotes(ss),
 				)
+				valid = false
 				continue
 			}
 
@@ -211,6 +456,95 @@ func (rule *RuleMatrix) checkExclude(m *Matrix) {
 				k,
 				strings.Join(ss, ", "), // Note: do not use quotesBuilder
 			)
+			valid = false
+		}
+
+		if valid {
+			rule.checkExcludeCombination(m, c, ignored)
+		}
+	}
+}
+
+// checkExcludeCombination checks the combination of values in the "exclude" item can match to some
+// combination of the matrix. Even if each value exists in the matrix, the combination of them may
+// not exist when some values are only added by "include" section.
+//
+//	matrix:
+//	  os: [ubuntu-latest, macos-latest]
+//	  include:
+//	    - os: windows-latest
+//	      shell: pwsh
+//	  exclude:
+//	    # This never matches since "shell" is only set with "windows-latest"
+//	    - os: ubuntu-latest
+//	      shell: pwsh
+func (rule *RuleMatrix) checkExcludeCombination(m *Matrix, c *MatrixCombination, ignored map[string]struct{}) {
+	keys := make([]string, 0, len(c.Assigns))
+	for k := range c.Assigns {
+		if _, ok := ignored[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) < 2 {
+		return // When only one value is specified, it was already checked
+	}
+	sort.Strings(keys)
+
+	// Check the value matches to one of the values in the row of the original matrix
+	matchesRow := func(k string) bool {
+		r, ok := m.Rows[k]
+		if !ok {
+			return false
+		}
+		for _, v := range r.Values {
+			if isYAMLValueSubset(v, c.Assigns[k].Value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Combinations made from the rows of the original matrix
+	all := true
+	for _, k := range keys {
+		if !matchesRow(k) {
+			all = false
+			break
+		}
+	}
+	if all {
+		return
+	}
+
+	// Combinations added or extended by "include" section
+	if m.Include != nil {
+	Include:
+		for _, inc := range m.Include.Combinations {
+			for _, k := range keys {
+				if a, ok := inc.Assigns[k]; ok {
+					if !isYAMLValueSubset(a.Value, c.Assigns[k].Value) {
+						continue Include
+					}
+				} else if !matchesRow(k) {
+					continue Include
+				}
+			}
+			return
+		}
+	}
+
+	pos := c.Assigns[keys[0]].Key.Pos
+	ss := make([]string, 0, len(keys))
+	for _, k := range keys {
+		a := c.Assigns[k]
+		if a.Key.Pos.IsBefore(pos) {
+			pos = a.Key.Pos
 		}
+		ss = append(ss, fmt.Sprintf("%q: %s", a.Key.Value, a.Value.String()))
 	}
+	rule.Errorf(
+		pos,
+		"combination {%s} in \"exclude\" section never matches to any combination of the matrix. each value exists but the combination of them is not in the matrix nor in \"include\" section",
+		strings.Join(ss, ", "),
+	)
 }
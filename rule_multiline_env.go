@@ -0,0 +1,192 @@
+package actionlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// reEnvFileWrite matches a line which writes a value to $GITHUB_ENV or $GITHUB_OUTPUT with `echo` or
+// `printf` in "{name}={value}" format like `echo "FOO=$(cat foo.txt)" >> "$GITHUB_ENV"`.
+var reEnvFileWrite = regexp.MustCompile(`\b(?:echo|printf)\s+(?:-[a-zA-Z]+\s+)*["']?([a-zA-Z_][a-zA-Z0-9_-]*)=(.*)>>?\s*["']?\$\{?(GITHUB_ENV|GITHUB_OUTPUT)\b`)
+
+// multilineOutputCommands is a set of commands whose outputs likely contain multiple lines. The values
+// are subcommands. Empty slice means any subcommand.
+var multilineOutputCommands = map[string][]string{
+	"cat":  {},
+	"curl": {},
+	"diff": {},
+	"find": {},
+	"git":  {"diff", "log", "show", "status"},
+	"ls":   {},
+	"tree": {},
+}
+
+// RuleMultilineEnv is a rule to detect values written to $GITHUB_ENV or $GITHUB_OUTPUT in
+// "{name}={value}" format where the value is a command substitution whose output likely contains
+// multiple lines. Only the first line is set to the variable and the following lines break the
+// file format. Multiline values must be written with a delimiter like "{name}<<{delimiter}". This is
+// a heuristic rule so it reports warnings by default.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/workflow-commands-for-github-actions#multiline-strings
+type RuleMultilineEnv struct {
+	RuleBase
+}
+
+// NewRuleMultilineEnv creates a new RuleMultilineEnv instance.
+func NewRuleMultilineEnv() *RuleMultilineEnv {
+	return &RuleMultilineEnv{
+		RuleBase: RuleBase{
+			name: "multiline-env",
+			desc: "Checks for values likely to be multiline which are written to $GITHUB_ENV or $GITHUB_OUTPUT without delimiter at \"run:\"",
+		},
+	}
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleMultilineEnv) VisitStep(n *Step) error {
+	r, ok := n.Exec.(*ExecRun)
+	if !ok || r.Run == nil || rule.isIgnoredByComment(r.RunComment) {
+		return nil
+	}
+	if r.Shell != nil && !r.Shell.ContainsExpression() {
+		if s := strings.Fields(r.Shell.Value); len(s) > 0 && s[0] != "bash" && s[0] != "sh" {
+			return nil
+		}
+	}
+
+	for _, l := range strings.Split(r.Run.Value, "\n") {
+		if !strings.Contains(l, "GITHUB_") {
+			continue
+		}
+		m := reEnvFileWrite.FindStringSubmatch(l)
+		if m == nil {
+			continue
+		}
+		name, value, file := m[1], m[2], m[3]
+		cmd, ok := multilineCommandSubstitution(value)
+		if !ok {
+			continue
+		}
+		rule.Errorf(
+			r.Run.Pos,
+			"value of %q written to $%s may contain multiple lines since it is output of %q command. multiline value breaks the format of the file. write it with delimiter like `{ echo '%s<<EOF'; %s; echo EOF; } >> \"$%s\"`, or put comment \"# %s\" at the line of \"run:\" if the value is always one line",
+			name,
+			file,
+			cmd,
+			name,
+			cmd,
+			file,
+			ignoreComment(rule.name),
+		)
+	}
+
+	return nil
+}
+
+// multilineCommandSubstitution finds a command substitution $(...) or `...` in the value whose output
+// likely contains multiple lines. It returns the command in the substitution.
+func multilineCommandSubstitution(value string) (string, bool) {
+	for i := 0; i < len(value); i++ {
+		var end int
+		var cmd string
+		switch {
+		case strings.HasPrefix(value[i:], "$("):
+			depth := 0
+			for end = i + 1; end < len(value); end++ {
+				if value[end] == '(' {
+					depth++
+				} else if value[end] == ')' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if end >= len(value) {
+				return "", false
+			}
+			cmd = value[i+2 : end]
+		case value[i] == '`':
+			end = strings.IndexByte(value[i+1:], '`')
+			if end < 0 {
+				return "", false
+			}
+			end += i + 1
+			cmd = value[i+1 : end]
+		default:
+			continue
+		}
+		if isMultilineCommand(cmd) {
+			return strings.TrimSpace(cmd), true
+		}
+		i = end
+	}
+	return "", false
+}
+
+// isOnelineGitLog returns true when `git log` with the arguments outputs one line like
+// `git log -1 --format=%H`.
+func isOnelineGitLog(args []string) bool {
+	one, format := false, false
+	for i, a := range args {
+		switch {
+		case a == "-1" || a == "-n1" || a == "--max-count=1" || a == "-n" && i+1 < len(args) && args[i+1] == "1":
+			one = true
+		case strings.HasPrefix(a, "--format=") || strings.HasPrefix(a, "--pretty="):
+			f := strings.Trim(a[strings.IndexByte(a, '=')+1:], `'"`)
+			format = f != "" && !strings.Contains(f, "%B") && !strings.Contains(f, "%b") && !strings.Contains(f, "%n")
+		case a == "--oneline":
+			format = true
+		}
+	}
+	return one && format
+}
+
+// isMultilineCommand returns true when the output of the command pipeline likely contains multiple
+// lines. The pipeline is considered as one line when its last command reduces the output to one line
+// like `head -n 1`, `wc -l`, or `tr -d '\n'`.
+func isMultilineCommand(cmd string) bool {
+	pipeline := strings.Split(cmd, "|")
+	first := strings.Fields(pipeline[0])
+	if len(first) == 0 {
+		return false
+	}
+	subs, ok := multilineOutputCommands[first[0]]
+	if !ok {
+		return false
+	}
+	if len(subs) > 0 && (len(first) < 2 || !contains(subs, first[1])) {
+		return false
+	}
+	if first[0] == "git" && first[1] == "log" && isOnelineGitLog(first[2:]) {
+		return false
+	}
+
+	if len(pipeline) == 1 {
+		return true
+	}
+	last := strings.Fields(pipeline[len(pipeline)-1])
+	if len(last) == 0 {
+		return false
+	}
+	switch last[0] {
+	case "head", "tail":
+		for _, a := range last[1:] {
+			if a == "-1" || a == "-n1" || a == "1" || a == "-c" || strings.HasPrefix(a, "-c") {
+				return false
+			}
+		}
+		return true
+	case "base64":
+		// base64 wraps the output at 76 columns by default
+		for i, a := range last[1:] {
+			if a == "-w0" || a == "-w" && i+2 < len(last) && last[i+2] == "0" {
+				return false
+			}
+		}
+		return true
+	case "wc", "tr", "xargs", "sha256sum", "md5sum":
+		return false
+	default:
+		return true
+	}
+}
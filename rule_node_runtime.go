@@ -0,0 +1,93 @@
+package actionlint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// nodeRuntimeDeprecationURL is the URL of GitHub's notice for the deprecation of Node.js runtimes.
+const nodeRuntimeDeprecationURL = "https://github.blog/changelog/2023-09-22-github-actions-transitioning-from-node-16-to-node-20/"
+
+// deprecatedNodeRuntimes is a set of Node.js runtimes of JavaScript actions which were deprecated
+// by GitHub.
+var deprecatedNodeRuntimes = map[string]struct{}{
+	"node12": {},
+	"node16": {},
+}
+
+// RuleNodeRuntime is a rule checker to detect JavaScript actions running on the deprecated Node.js
+// runtimes like "node16". Local actions are checked with their metadata. Remote actions are checked
+// with "action-runtimes" in the config file. Outdated popular actions are reported by "action" rule.
+type RuleNodeRuntime struct {
+	RuleBase
+	cache *LocalActionsCache
+}
+
+// NewRuleNodeRuntime creates a new RuleNodeRuntime instance.
+func NewRuleNodeRuntime(cache *LocalActionsCache) *RuleNodeRuntime {
+	return &RuleNodeRuntime{
+		RuleBase: RuleBase{
+			name: "node-runtime",
+			desc: "Checks for JavaScript actions running on deprecated Node.js runtimes such as \"node16\"",
+		},
+		cache: cache,
+	}
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleNodeRuntime) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecAction)
+	if !ok || e.Uses == nil || e.Uses.ContainsExpression() || rule.isIgnoredByComment(e.UsesComment) {
+		return nil
+	}
+
+	spec := e.Uses.Value
+	var using, what string
+	switch {
+	case strings.HasPrefix(spec, "./"):
+		meta, _, err := rule.cache.FindMetadata(spec)
+		if err != nil || meta == nil {
+			return nil // The error is reported by "action" rule
+		}
+		using = meta.Runs.Using
+		what = fmt.Sprintf("local action %q defined at %q", meta.Name, spec)
+	case strings.HasPrefix(spec, "docker://"):
+		return nil
+	default:
+		using = rule.Config().actionRuntime(spec)
+		what = fmt.Sprintf("action %q", spec)
+	}
+
+	if _, ok := deprecatedNodeRuntimes[using]; ok {
+		rule.Errorf(
+			e.Uses.Pos,
+			"%s runs on %q runtime which is deprecated by GitHub. update the action to run on \"node20\" runtime or put comment \"# %s\" at the line of \"uses:\" if this is intended. see %s",
+			what,
+			using,
+			ignoreComment(rule.name),
+			nodeRuntimeDeprecationURL,
+		)
+	}
+	return nil
+}
+
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleNodeRuntime) VisitActionPre(n *Action) error {
+	if n.Runs == nil || n.Runs.Using == nil {
+		return nil
+	}
+	if _, ok := deprecatedNodeRuntimes[n.Runs.Using.Value]; ok {
+		rule.Errorf(
+			n.Runs.Using.Pos,
+			"runtime %q at runs.using is deprecated by GitHub. use \"node20\" instead. see %s",
+			n.Runs.Using.Value,
+			nodeRuntimeDeprecationURL,
+		)
+	}
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleNodeRuntime) VisitActionPost(n *Action) error {
+	return nil
+}
@@ -1,5 +1,9 @@
 package actionlint
 
+import (
+	"sort"
+)
+
 var allPermissionScopes = map[string]struct{}{
 	"actions":             {},
 	"attestations":        {},
@@ -17,6 +21,17 @@ var allPermissionScopes = map[string]struct{}{
 	"statuses":            {},
 }
 
+// Permission scopes which are not available on GitHub Enterprise Server since the features are only
+// provided by GitHub.com.
+// https://docs.github.com/en/actions/security-for-github-actions/using-artifact-attestations/using-artifact-attestations-to-establish-provenance-for-builds
+var ghesUnavailablePermissionScopes = map[string]struct{}{
+	"attestations": {},
+}
+
+var allPermissionsValues = []string{"read-all", "write-all"}
+
+var permissionScopeValues = []string{"read", "write", "none"}
+
 // RulePermissions is a rule checker to check permission configurations in a workflow.
 // https://docs.github.com/en/actions/security-for-github-actions/security-guides/automatic-token-authentication#permissions-for-the-github_token
 type RulePermissions struct {
@@ -55,25 +70,32 @@ func (rule *RulePermissions) checkPermissions(p *Permissions) {
 		case "write-all", "read-all":
 			// OK
 		default:
-			rule.Errorf(p.All.Pos, "%q is invalid for permission for all the scopes. available values are \"read-all\" and \"write-all\"", p.All.Value)
+			rule.Errorf(p.All.Pos, "%q is invalid for permission for all the scopes.%s available values are \"read-all\" and \"write-all\"", p.All.Value, didYouMean(p.All.Value, allPermissionsValues))
 		}
 		return
 	}
 
+	ghes := rule.config.isGHES()
 	for _, p := range p.Scopes {
 		n := p.Name.Value // Permission names are case-sensitive
 		if _, ok := allPermissionScopes[n]; !ok {
 			ss := make([]string, 0, len(allPermissionScopes))
 			for s := range allPermissionScopes {
+				if _, ok := ghesUnavailablePermissionScopes[s]; ghes && ok {
+					continue
+				}
 				ss = append(ss, s)
 			}
-			rule.Errorf(p.Name.Pos, "unknown permission scope %q. all available permission scopes are %s", n, sortedQuotes(ss))
+			sort.Strings(ss)
+			rule.Errorf(p.Name.Pos, "unknown permission scope %q.%s all available permission scopes are %s", n, didYouMean(n, ss), quotes(ss))
+		} else if _, ok := ghesUnavailablePermissionScopes[n]; ghes && ok && p.Value.Value != "none" {
+			rule.Errorf(p.Name.Pos, "permission scope %q is not available on GitHub Enterprise Server. remove it from \"permissions:\"", n)
 		}
 		switch p.Value.Value {
 		case "read", "write", "none":
 			// OK
 		default:
-			rule.Errorf(p.Value.Pos, "%q is invalid for permission of scope %q. available values are \"read\", \"write\" or \"none\"", p.Value.Value, n)
+			rule.Errorf(p.Value.Pos, "%q is invalid for permission of scope %q.%s available values are \"read\", \"write\" or \"none\"", p.Value.Value, n, didYouMean(p.Value.Value, permissionScopeValues))
 		}
 	}
 }
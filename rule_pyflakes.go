@@ -87,6 +87,18 @@ func (rule *RulePyflakes) VisitWorkflowPost(n *Workflow) error {
 	return rule.cmd.wait()                                    // Wait until all processes running for this rule
 }
 
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RulePyflakes) VisitActionPre(n *Action) error {
+	rule.workflowShellIsPython = shellIsPythonKindUnspecified
+	rule.jobShellIsPython = shellIsPythonKindUnspecified
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RulePyflakes) VisitActionPost(n *Action) error {
+	return rule.cmd.wait() // Wait until all processes running for this rule
+}
+
 // VisitStep is callback when visiting Step node.
 func (rule *RulePyflakes) VisitStep(n *Step) error {
 	run, ok := n.Exec.(*ExecRun)
@@ -0,0 +1,127 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// defaultEnvVars is a set of default environment variables set by GitHub Actions runner.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/store-information-in-variables#default-environment-variables
+var defaultEnvVars = map[string]struct{}{
+	"CI":                         {},
+	"GITHUB_ACTION":              {},
+	"GITHUB_ACTION_PATH":         {},
+	"GITHUB_ACTION_REPOSITORY":   {},
+	"GITHUB_ACTIONS":             {},
+	"GITHUB_ACTOR":               {},
+	"GITHUB_ACTOR_ID":            {},
+	"GITHUB_API_URL":             {},
+	"GITHUB_BASE_REF":            {},
+	"GITHUB_ENV":                 {},
+	"GITHUB_EVENT_NAME":          {},
+	"GITHUB_EVENT_PATH":          {},
+	"GITHUB_GRAPHQL_URL":         {},
+	"GITHUB_HEAD_REF":            {},
+	"GITHUB_JOB":                 {},
+	"GITHUB_OUTPUT":              {},
+	"GITHUB_PATH":                {},
+	"GITHUB_REF":                 {},
+	"GITHUB_REF_NAME":            {},
+	"GITHUB_REF_PROTECTED":       {},
+	"GITHUB_REF_TYPE":            {},
+	"GITHUB_REPOSITORY":          {},
+	"GITHUB_REPOSITORY_ID":       {},
+	"GITHUB_REPOSITORY_OWNER":    {},
+	"GITHUB_REPOSITORY_OWNER_ID": {},
+	"GITHUB_RETENTION_DAYS":      {},
+	"GITHUB_RUN_ATTEMPT":         {},
+	"GITHUB_RUN_ID":              {},
+	"GITHUB_RUN_NUMBER":          {},
+	"GITHUB_SERVER_URL":          {},
+	"GITHUB_SHA":                 {},
+	"GITHUB_STATE":               {},
+	"GITHUB_STEP_SUMMARY":        {},
+	"GITHUB_TRIGGERING_ACTOR":    {},
+	"GITHUB_WORKFLOW":            {},
+	"GITHUB_WORKFLOW_REF":        {},
+	"GITHUB_WORKFLOW_SHA":        {},
+	"GITHUB_WORKSPACE":           {},
+	"RUNNER_ARCH":                {},
+	"RUNNER_DEBUG":               {},
+	"RUNNER_ENVIRONMENT":         {},
+	"RUNNER_NAME":                {},
+	"RUNNER_OS":                  {},
+	"RUNNER_TEMP":                {},
+	"RUNNER_TOOL_CACHE":          {},
+}
+
+// RuleReservedEnv is a rule to detect environment variables at "env:" which shadow the default
+// environment variables set by GitHub Actions such as $GITHUB_SHA or $CI. Default variables whose
+// names start with GITHUB_ or RUNNER_ cannot be overwritten. $CI can be overwritten for now, but it
+// may break actions and tools which rely on the value. Since overwriting them is sometimes intended,
+// this rule reports warnings by default.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/store-information-in-variables#default-environment-variables
+type RuleReservedEnv struct {
+	RuleBase
+}
+
+// NewRuleReservedEnv creates a new RuleReservedEnv instance.
+func NewRuleReservedEnv() *RuleReservedEnv {
+	return &RuleReservedEnv{
+		RuleBase: RuleBase{
+			name: "reserved-env",
+			desc: "Checks for environment variables at \"env:\" which shadow the default environment variables set by GitHub Actions",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleReservedEnv) VisitWorkflowPre(n *Workflow) error {
+	rule.checkEnv(n.Env, "workflow")
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleReservedEnv) VisitJobPre(n *Job) error {
+	rule.checkEnv(n.Env, "job")
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleReservedEnv) VisitStep(n *Step) error {
+	rule.checkEnv(n.Env, "step")
+	return nil
+}
+
+func (rule *RuleReservedEnv) checkEnv(env *Env, scope string) {
+	if env == nil || env.Expression != nil {
+		return
+	}
+
+	var allowed []string
+	if cfg := rule.Config(); cfg != nil {
+		allowed = cfg.AllowedReservedEnvVars
+	}
+
+	for _, v := range env.Vars {
+		name := v.Name.Value
+		if _, ok := defaultEnvVars[name]; !ok {
+			continue
+		}
+		if contains(allowed, name) {
+			rule.Debug("Environment variable %q at %s-level \"env:\" is allowed by \"allowed-reserved-env-vars\"", name, scope)
+			continue
+		}
+
+		note := "its value cannot be overwritten and the assignment is ignored"
+		if !strings.HasPrefix(name, "GITHUB_") && !strings.HasPrefix(name, "RUNNER_") {
+			note = "overwriting its value is not guaranteed to work and may break actions and tools relying on it"
+		}
+		rule.Errorf(
+			v.Name.Pos,
+			"environment variable %q at %s-level \"env:\" shadows the default environment variable set by GitHub Actions. %s. use another name, or add it to \"allowed-reserved-env-vars\" in actionlint.yaml if this is intended",
+			name,
+			scope,
+			note,
+		)
+	}
+}
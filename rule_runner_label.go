@@ -26,6 +26,11 @@ const (
 	compatWindows2025
 )
 
+const (
+	compatMacOS   = compatMacOS130 | compatMacOS130L | compatMacOS130XL | compatMacOS140 | compatMacOS140L | compatMacOS140XL | compatMacOS150 | compatMacOS150L | compatMacOS150XL
+	compatWindows = compatWindows2019 | compatWindows2022 | compatWindows2025
+)
+
 // https://docs.github.com/en/actions/using-github-hosted-runners/about-github-hosted-runners
 var allGitHubHostedRunnerLabels = []string{
 	"windows-latest",
@@ -144,6 +149,8 @@ func (rule *RuleRunnerLabel) VisitJobPre(n *Job) error {
 		m = n.Strategy.Matrix
 	}
 
+	rule.checkContainerOS(n, m)
+
 	if len(n.RunsOn.Labels) == 1 {
 		rule.checkLabel(n.RunsOn.Labels[0], m)
 		return nil
@@ -179,6 +186,54 @@ func (rule *RuleRunnerLabel) checkLabelAndConflict(l *String, m *Matrix) {
 	rule.checkCompat(comp, l)
 }
 
+// checkContainerOS checks that the job runs on Linux runner when it has "container:" or "services:".
+// Container jobs and service containers are supported only on Linux runners. Labels which cannot be
+// resolved statically are skipped.
+// https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#jobsjob_idcontainer
+func (rule *RuleRunnerLabel) checkContainerOS(n *Job, m *Matrix) {
+	var sections []string
+	if n.Container != nil {
+		sections = append(sections, "\"container:\"")
+	}
+	if n.Services != nil {
+		sections = append(sections, "\"services:\"")
+	}
+	if len(sections) == 0 {
+		return
+	}
+
+	labels := n.RunsOn.Labels
+	if n.RunsOn.LabelsExpr != nil {
+		labels = []*String{n.RunsOn.LabelsExpr}
+	}
+	for _, l := range labels {
+		ls := []*String{l}
+		if l.ContainsExpression() {
+			ls = rule.tryToGetLabelsInMatrix(l, m)
+		}
+		for _, l := range ls {
+			var kind string
+			switch c := defaultRunnerOSCompats[strings.ToLower(l.Value)]; {
+			case c == compatInvalid:
+				continue
+			case c&^compatWindows == 0:
+				kind = "Windows"
+			case c&^compatMacOS == 0:
+				kind = "macOS"
+			default:
+				continue
+			}
+			rule.Errorf(
+				l.Pos,
+				"label %q is for %s runner but the job defines %s. container jobs and service containers are supported only on Linux runners",
+				l.Value,
+				kind,
+				strings.Join(sections, " and "),
+			)
+		}
+	}
+}
+
 func (rule *RuleRunnerLabel) checkLabel(l *String, m *Matrix) {
 	if l.ContainsExpression() {
 		ss := rule.tryToGetLabelsInMatrix(l, m)
@@ -193,8 +248,22 @@ func (rule *RuleRunnerLabel) checkLabel(l *String, m *Matrix) {
 
 func (rule *RuleRunnerLabel) verifyRunnerLabel(label *String) runnerOSCompat {
 	l := label.Value
+	ghes := rule.config.isGHES()
 	if c, ok := defaultRunnerOSCompats[strings.ToLower(l)]; ok {
-		return c
+		if !ghes || isSelfHostedPresetOSLabel(l) {
+			return c
+		}
+		// GitHub-hosted runners are not available on GHES. The label is available only when it is
+		// added to self-hosted runners.
+		if rule.matchKnownLabel(label) {
+			return c
+		}
+		rule.Errorf(
+			label.Pos,
+			"label %q is for GitHub-hosted runner but GitHub-hosted runners are not available on GitHub Enterprise Server. if it is a custom label for self-hosted runner, set list of labels in actionlint.yaml config file",
+			label.Value,
+		)
+		return compatInvalid
 	}
 
 	for _, p := range selfHostedRunnerPresetOtherLabels {
@@ -203,33 +272,55 @@ func (rule *RuleRunnerLabel) verifyRunnerLabel(label *String) runnerOSCompat {
 		}
 	}
 
-	known := rule.getKnownLabels()
-	for _, k := range known {
-		m, err := path.Match(k, l)
-		if err != nil {
-			rule.Errorf(label.Pos, "label pattern %q is an invalid glob. kindly check list of labels in actionlint.yaml config file: %v", k, err)
-			return compatInvalid
-		}
-		if m {
-			return compatInvalid
-		}
+	if rule.matchKnownLabel(label) {
+		return compatInvalid
 	}
 
+	hosted := allGitHubHostedRunnerLabels
+	if ghes {
+		hosted = nil
+	}
 	rule.Errorf(
 		label.Pos,
 		"label %q is unknown. available labels are %s. if it is a custom label for self-hosted runner, set list of labels in actionlint.yaml config file",
 		label.Value,
 		quotesAll(
-			allGitHubHostedRunnerLabels,
+			hosted,
 			selfHostedRunnerPresetOtherLabels,
 			selfHostedRunnerPresetOSLabels,
-			known,
+			rule.getKnownLabels(),
 		),
 	)
 
 	return compatInvalid
 }
 
+// matchKnownLabel returns true when the label matches to one of labels of self-hosted runners in the
+// config file. It also returns true when some invalid glob pattern is found since the error was
+// already reported.
+func (rule *RuleRunnerLabel) matchKnownLabel(label *String) bool {
+	for _, k := range rule.getKnownLabels() {
+		m, err := path.Match(k, label.Value)
+		if err != nil {
+			rule.Errorf(label.Pos, "label pattern %q is an invalid glob. kindly check list of labels in actionlint.yaml config file: %v", k, err)
+			return true
+		}
+		if m {
+			return true
+		}
+	}
+	return false
+}
+
+func isSelfHostedPresetOSLabel(l string) bool {
+	for _, p := range selfHostedRunnerPresetOSLabels {
+		if strings.EqualFold(l, p) {
+			return true
+		}
+	}
+	return false
+}
+
 func (rule *RuleRunnerLabel) tryToGetLabelsInMatrix(label *String, m *Matrix) []*String {
 	if m == nil {
 		return nil
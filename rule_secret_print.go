@@ -0,0 +1,210 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// RuleSecretPrint is a rule checker to detect scripts at 'run:' which print secrets directly with
+// commands such as `echo`. Printing secrets risks leaking them to the job logs since the masking
+// does not work for transformed values. This rule is enabled by "check-secret-print" in the config
+// file.
+type RuleSecretPrint struct {
+	RuleBase
+}
+
+// NewRuleSecretPrint creates a new RuleSecretPrint instance.
+func NewRuleSecretPrint() *RuleSecretPrint {
+	return &RuleSecretPrint{
+		RuleBase: RuleBase{
+			name: "secret-print",
+			desc: "Checks for secrets printed to logs with \"echo\", \"printf\", or \"cat\" at \"run:\" when \"check-secret-print\" is enabled",
+		},
+	}
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleSecretPrint) VisitStep(n *Step) error {
+	if cfg := rule.Config(); cfg == nil || !cfg.CheckSecretPrint {
+		return nil
+	}
+
+	r, ok := n.Exec.(*ExecRun)
+	if !ok || r.Run == nil || rule.isIgnoredByComment(r.RunComment) {
+		return nil
+	}
+
+	for _, p := range findSecretPrints(r.Run.Value) {
+		rule.Errorf(
+			r.Run.Pos,
+			"secret %q is printed by %q command in the script. it may leak the secret to the logs. pass the secret via environment variable and do not print it, or put comment \"# %s\" at the line of \"run:\" if this is intended",
+			p.expr,
+			p.cmd,
+			ignoreComment(rule.name),
+		)
+	}
+
+	return nil
+}
+
+// secretPrint is a secret expression in a script which is printed by a command.
+type secretPrint struct {
+	// expr is the source of ${{ }} expression which references secrets.
+	expr string
+	// cmd is the command name which prints the secret.
+	cmd string
+}
+
+type secretExprSpan struct {
+	start int
+	end   int
+}
+
+// findSecretPrints finds ${{ }} expressions referencing secrets which are printed by `echo`,
+// `printf`, or `cat` in the script. To avoid false positives, this function only detects the
+// expressions which are arguments of the command and whose output is neither piped nor redirected.
+func findSecretPrints(src string) []secretPrint {
+	spans := []secretExprSpan{}
+	// Mask the ${{ }} expressions not to parse operators such as || in them as shell syntax
+	masked := []byte(src)
+	for offset := 0; ; {
+		idx := strings.Index(src[offset:], "${{")
+		if idx == -1 {
+			break
+		}
+		start := offset + idx
+		l := NewExprLexer(src[start+3:]) // 3 means removing "${{"
+		expr, err := NewExprParser().Parse(l)
+		if err != nil {
+			break // Parse errors are reported by "expression" rule
+		}
+		end := start + 3 + l.Offset()
+
+		secret := false
+		VisitExprNode(expr, func(n, _ ExprNode, entering bool) {
+			if v, ok := n.(*VariableNode); ok && entering && v.Name == "secrets" {
+				secret = true
+			}
+		})
+		if secret {
+			spans = append(spans, secretExprSpan{start, end})
+		}
+
+		for i := start; i < end; i++ {
+			if masked[i] != '\n' {
+				masked[i] = '_'
+			}
+		}
+		offset = end
+	}
+
+	if len(spans) == 0 {
+		return nil
+	}
+
+	ret := []secretPrint{}
+	check := func(start, end int, redirected bool) {
+		if redirected || strings.Contains(src[start:end], "::add-mask::") {
+			return
+		}
+		cmd, argsStart, ok := printCommandOf(string(masked[start:end]))
+		if !ok {
+			return
+		}
+		for _, s := range spans {
+			if start+argsStart <= s.start && s.end <= end {
+				ret = append(ret, secretPrint{src[s.start:s.end], cmd})
+			}
+		}
+	}
+
+	// Split the script into commands separated by newlines, ';', '&', '&&', and '||'
+	start, redirected := 0, false
+	var quote byte
+	for i := 0; i < len(masked); i++ {
+		c := masked[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '\\':
+			i++ // Escaped character and line continuation
+		case '#':
+			if i == start || masked[i-1] == ' ' || masked[i-1] == '\t' {
+				check(start, i, redirected)
+				for i < len(masked) && masked[i] != '\n' {
+					i++
+				}
+				start, redirected = i+1, false
+			}
+		case '>':
+			redirected = true
+		case '|':
+			if i+1 < len(masked) && masked[i+1] == '|' {
+				check(start, i, redirected)
+				i++
+				start, redirected = i+1, false
+			} else {
+				redirected = true // Output is piped to another command
+			}
+		case '\n', ';', '&':
+			check(start, i, redirected)
+			if c == '&' && i+1 < len(masked) && masked[i+1] == '&' {
+				i++
+			}
+			start, redirected = i+1, false
+		}
+	}
+	if start < len(masked) {
+		check(start, len(masked), redirected)
+	}
+
+	return ret
+}
+
+// printCommandOf returns the command name and the offset of its arguments when the command prints
+// its arguments to stdout. Leading variable assignments like `FOO=bar echo` are skipped.
+func printCommandOf(cmd string) (string, int, bool) {
+	offset := 0
+	for {
+		trimmed := strings.TrimLeft(cmd[offset:], " \t\n\\")
+		offset = len(cmd) - len(trimmed)
+		if trimmed == "" {
+			return "", 0, false
+		}
+		end := strings.IndexAny(trimmed, " \t\n")
+		if end == -1 {
+			end = len(trimmed)
+		}
+		word := trimmed[:end]
+		offset += end
+		if isShellVarAssignment(word) {
+			continue
+		}
+		switch word {
+		case "echo", "printf", "cat":
+			return word, offset, true
+		default:
+			return "", 0, false
+		}
+	}
+}
+
+func isShellVarAssignment(word string) bool {
+	eq := strings.IndexByte(word, '=')
+	if eq <= 0 {
+		return false
+	}
+	for i, c := range word[:eq] {
+		if c != '_' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') && (i == 0 || !('0' <= c && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package actionlint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRuleSecretPrintFindSecretPrints(t *testing.T) {
+	tests := []struct {
+		what string
+		run  string
+		want []secretPrint
+	}{
+		{
+			what: "echo",
+			run:  "echo ${{ secrets.TOKEN }}",
+			want: []secretPrint{{"${{ secrets.TOKEN }}", "echo"}},
+		},
+		{
+			what: "printf",
+			run:  "printf '%s' \"${{ secrets.TOKEN }}\"",
+			want: []secretPrint{{"${{ secrets.TOKEN }}", "printf"}},
+		},
+		{
+			what: "cat",
+			run:  "cat ${{ secrets.TOKEN }}",
+			want: []secretPrint{{"${{ secrets.TOKEN }}", "cat"}},
+		},
+		{
+			what: "index access",
+			run:  "echo ${{ secrets['TOKEN'] }}",
+			want: []secretPrint{{"${{ secrets['TOKEN'] }}", "echo"}},
+		},
+		{
+			what: "secret in complex expression",
+			run:  "echo ${{ secrets.TOKEN || 'default' }}",
+			want: []secretPrint{{"${{ secrets.TOKEN || 'default' }}", "echo"}},
+		},
+		{
+			what: "multiple secrets",
+			run:  "echo ${{ secrets.A }} ${{ secrets.B }}",
+			want: []secretPrint{{"${{ secrets.A }}", "echo"}, {"${{ secrets.B }}", "echo"}},
+		},
+		{
+			what: "multiple commands",
+			run:  "echo ${{ secrets.A }}\ncat ${{ secrets.B }}",
+			want: []secretPrint{{"${{ secrets.A }}", "echo"}, {"${{ secrets.B }}", "cat"}},
+		},
+		{
+			what: "after separator",
+			run:  "cd foo && echo ${{ secrets.A }}; true || printf ${{ secrets.B }}",
+			want: []secretPrint{{"${{ secrets.A }}", "echo"}, {"${{ secrets.B }}", "printf"}},
+		},
+		{
+			what: "leading variable assignment",
+			run:  "FOO=bar echo ${{ secrets.A }}",
+			want: []secretPrint{{"${{ secrets.A }}", "echo"}},
+		},
+		{
+			what: "line continuation",
+			run:  "echo \\\n  ${{ secrets.A }}",
+			want: []secretPrint{{"${{ secrets.A }}", "echo"}},
+		},
+		{
+			what: "separator in quotes",
+			run:  "echo 'a;b|c>d' ${{ secrets.A }}",
+			want: []secretPrint{{"${{ secrets.A }}", "echo"}},
+		},
+		{
+			what: "no secret",
+			run:  "echo ${{ github.sha }}",
+		},
+		{
+			what: "piped",
+			run:  "echo ${{ secrets.A }} | docker login --password-stdin",
+		},
+		{
+			what: "redirected",
+			run:  "echo ${{ secrets.A }} > file.txt",
+		},
+		{
+			what: "appended",
+			run:  "echo \"TOKEN=${{ secrets.A }}\" >> \"$GITHUB_ENV\"",
+		},
+		{
+			what: "other command",
+			run:  "curl -H \"Authorization: ${{ secrets.A }}\" https://example.com",
+		},
+		{
+			what: "secret in variable assignment",
+			run:  "TOKEN=${{ secrets.A }} echo hello",
+		},
+		{
+			what: "secret in command substitution",
+			run:  "TOKEN=$(echo ${{ secrets.A }})",
+		},
+		{
+			what: "different commands",
+			run:  "echo hello\n./login.sh ${{ secrets.A }}",
+		},
+		{
+			what: "add-mask workflow command",
+			run:  "echo \"::add-mask::${{ secrets.A }}\"",
+		},
+		{
+			what: "secret in comment",
+			run:  "echo hello # ${{ secrets.A }}",
+		},
+		{
+			what: "command name in quotes",
+			run:  "./run.sh 'echo' ${{ secrets.A }}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			have := findSecretPrints(tc.run)
+			if len(tc.want) == 0 {
+				if len(have) > 0 {
+					t.Fatalf("wanted no secret print but got %v", have)
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.want, have, cmp.AllowUnexported(secretPrint{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestRuleSecretPrintIgnoreComment(t *testing.T) {
+	s := &Step{
+		Exec: &ExecRun{
+			Run:        &String{Value: "echo ${{ secrets.A }}", Pos: &Pos{}},
+			RunComment: "# " + ignoreComment("secret-print"),
+		},
+	}
+	r := NewRuleSecretPrint()
+	r.SetConfig(&Config{CheckSecretPrint: true})
+	if err := r.VisitStep(s); err != nil {
+		t.Fatal(err)
+	}
+	if errs := r.Errs(); len(errs) > 0 {
+		t.Fatalf("wanted no error but got %v", errs)
+	}
+}
+
+func TestRuleSecretPrintDisabledByDefault(t *testing.T) {
+	s := &Step{
+		Exec: &ExecRun{
+			Run: &String{Value: "echo ${{ secrets.A }}", Pos: &Pos{}},
+		},
+	}
+	r := NewRuleSecretPrint()
+	if err := r.VisitStep(s); err != nil {
+		t.Fatal(err)
+	}
+	if errs := r.Errs(); len(errs) > 0 {
+		t.Fatalf("wanted no error without \"check-secret-print\" but got %v", errs)
+	}
+}
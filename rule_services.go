@@ -0,0 +1,186 @@
+package actionlint
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reContainerImage is a regular expression for container image references like
+// "ghcr.io/owner/image:tag" or "postgres@sha256:...". This is a simplified version of the grammar
+// defined in https://github.com/distribution/reference
+var reContainerImage = regexp.MustCompile(
+	`^` +
+		// Optional registry domain with port
+		`(?:(?:localhost|[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)+|[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?:[0-9]+)(?::[0-9]+)?/)?` +
+		// Path components
+		`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		// Optional tag
+		`(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?` +
+		// Optional digest
+		`(?:@[a-zA-Z][a-zA-Z0-9]*(?:[-_+.][a-zA-Z][a-zA-Z0-9]*)*:[0-9a-fA-F]{32,})?` +
+		`$`,
+)
+
+// unsupportedServiceOptions is a list of options of `docker run` which are not supported at
+// "options:" of containers.
+// https://docs.github.com/en/actions/using-jobs/running-jobs-in-a-container#jobsjob_idcontaineroptions
+var unsupportedServiceOptions = []string{"--network", "--entrypoint"}
+
+// RuleServices is a rule to check service containers at 'services' section of jobs. It checks image
+// references at "image:", port mappings at "ports:", and docker run options at "options:".
+// https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions#jobsjob_idservices
+type RuleServices struct {
+	RuleBase
+}
+
+// NewRuleServices creates new RuleServices instance.
+func NewRuleServices() *RuleServices {
+	return &RuleServices{
+		RuleBase: RuleBase{
+			name: "services",
+			desc: "Checks for image references, port mappings, and options of service containers at \"services:\"",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleServices) VisitJobPre(n *Job) error {
+	if n.Services == nil {
+		return nil
+	}
+	for _, s := range n.Services.Value {
+		c := s.Container
+		if c == nil {
+			continue
+		}
+		if c.Image == nil {
+			rule.Errorf(s.Name.Pos, "\"image\" is not set at service %q. a service container must have an image to run", s.Name.Value)
+		} else {
+			rule.checkImage(c.Image, s.Name.Value)
+		}
+		for _, p := range c.Ports {
+			rule.checkPort(p, s.Name.Value)
+		}
+		rule.checkOptions(c.Options, s.Name.Value)
+	}
+	return nil
+}
+
+func (rule *RuleServices) checkImage(image *String, service string) {
+	// Note: Empty image is reported by parser
+	if image.Value == "" || image.ContainsExpression() {
+		return
+	}
+	ref := strings.TrimPrefix(image.Value, "docker://")
+	if !reContainerImage.MatchString(ref) {
+		rule.Errorf(
+			image.Pos,
+			"image %q at service %q is not a valid container image reference. it should be like \"name:tag\" or \"registry.example.com/owner/name:tag\" where the name consists of lower case characters",
+			image.Value,
+			service,
+		)
+	}
+}
+
+// checkPort checks the port mapping follows the grammar "[[ip:]host:]container[/protocol]". Each
+// port can be a range like "8000-8010".
+// https://docs.docker.com/reference/cli/docker/container/run/#publish
+func (rule *RuleServices) checkPort(port *String, service string) {
+	if port.Value == "" || port.ContainsExpression() {
+		return
+	}
+
+	s := port.Value
+	if i := strings.LastIndexByte(s, '/'); i >= 0 {
+		switch p := s[i+1:]; p {
+		case "tcp", "udp", "sctp":
+			// OK
+		default:
+			rule.Errorf(port.Pos, "protocol %q in port mapping %q at service %q is invalid. available protocols are \"tcp\", \"udp\", and \"sctp\"", p, port.Value, service)
+			return
+		}
+		s = s[:i]
+	}
+
+	// IP address may contain ':' (IPv6 address like "[::1]:8080:80")
+	if strings.HasPrefix(s, "[") {
+		i := strings.Index(s, "]:")
+		if i < 0 {
+			rule.Errorf(port.Pos, "IPv6 address in port mapping %q at service %q is not closed with \"]\"", port.Value, service)
+			return
+		}
+		s = s[i+2:]
+	}
+
+	ps := strings.Split(s, ":")
+	switch len(ps) {
+	case 1, 2:
+		// "container" or "host:container"
+	case 3:
+		ps = ps[1:] // Remove IP address
+	default:
+		rule.Errorf(port.Pos, "port mapping %q at service %q is invalid. it must be in the form of \"[[ip:]host:]container[/protocol]\" such as \"8080:80/tcp\"", port.Value, service)
+		return
+	}
+
+	for i, p := range ps {
+		if p == "" && i == 0 && len(ps) == 2 {
+			continue // Host port can be omitted like "127.0.0.1::80"
+		}
+		if !rule.checkPortRange(p, port, service) {
+			return
+		}
+	}
+}
+
+func (rule *RuleServices) checkPortRange(r string, port *String, service string) bool {
+	ns := strings.SplitN(r, "-", 2)
+	for _, n := range ns {
+		i, err := strconv.Atoi(n)
+		if err != nil {
+			rule.Errorf(port.Pos, "port %q in port mapping %q at service %q is not a number. it must be in the form of \"[[ip:]host:]container[/protocol]\" such as \"8080:80/tcp\"", n, port.Value, service)
+			return false
+		}
+		if i < 1 || 65535 < i {
+			rule.Errorf(port.Pos, "port number %d in port mapping %q at service %q is out of range. it must be in 1..65535", i, port.Value, service)
+			return false
+		}
+	}
+	return true
+}
+
+// checkOptions checks "options:" is a plausible string of docker run options like
+// "--health-cmd pg_isready --health-interval 10s".
+func (rule *RuleServices) checkOptions(opts *String, service string) {
+	if opts == nil || opts.ContainsExpression() {
+		return
+	}
+
+	ws := strings.Fields(opts.Value)
+	if len(ws) == 0 {
+		return
+	}
+	if !strings.HasPrefix(ws[0], "-") {
+		rule.Errorf(
+			opts.Pos,
+			"options %q at service %q must start with an option of \"docker run\" such as \"--health-cmd\" but got %q. the image should be set at \"image:\"",
+			opts.Value,
+			service,
+			ws[0],
+		)
+		return
+	}
+
+	for _, w := range ws {
+		name := w
+		if i := strings.IndexByte(name, '='); i >= 0 {
+			name = name[:i]
+		}
+		for _, u := range unsupportedServiceOptions {
+			if name == u {
+				rule.Errorf(opts.Pos, "option %q at service %q is not supported by GitHub Actions", u, service)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package actionlint
+
+import (
+	"testing"
+)
+
+func TestRuleServicesContainerImageReference(t *testing.T) {
+	valid := []string{
+		"redis",
+		"redis:7",
+		"postgres:16.2-alpine",
+		"library/node",
+		"ghcr.io/owner/image:latest",
+		"my-registry.example.com:5000/team/app_v2:1.0.0",
+		"localhost/app",
+		"localhost:5000/app:dev",
+		"registry:5000/app",
+		"mysql@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		"mysql:8@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		"docker://redis:7",
+	}
+	for _, image := range valid {
+		t.Run("valid "+image, func(t *testing.T) {
+			rule := NewRuleServices()
+			rule.checkImage(&String{Value: image, Pos: &Pos{}}, "test")
+			if errs := rule.Errs(); len(errs) > 0 {
+				t.Fatalf("unexpected errors for %q: %v", image, errs)
+			}
+		})
+	}
+
+	invalid := []string{
+		"Redis",
+		"ghcr.io/Owner/image",
+		"redis:",
+		"redis:7:8",
+		"-redis",
+		"redis/",
+		"my image",
+		"redis@sha256:xyz",
+	}
+	for _, image := range invalid {
+		t.Run("invalid "+image, func(t *testing.T) {
+			rule := NewRuleServices()
+			rule.checkImage(&String{Value: image, Pos: &Pos{}}, "test")
+			if errs := rule.Errs(); len(errs) != 1 {
+				t.Fatalf("one error should be reported for %q but got %v", image, errs)
+			}
+		})
+	}
+}
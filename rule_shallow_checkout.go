@@ -0,0 +1,78 @@
+package actionlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+var reGitHistoryCommand = regexp.MustCompile(`\bgit\s+(describe|log|tag|merge-base)\b`)
+
+// RuleShallowCheckout is a rule checker to detect git commands which depend on the history of the
+// repository after checking out the repository with actions/checkout. actions/checkout fetches only
+// one commit by default so commands like `git describe` don't work as expected. This rule is a
+// heuristic and may cause false positives.
+type RuleShallowCheckout struct {
+	RuleBase
+	checkout *ExecAction
+}
+
+// NewRuleShallowCheckout creates a new RuleShallowCheckout instance.
+func NewRuleShallowCheckout() *RuleShallowCheckout {
+	return &RuleShallowCheckout{
+		RuleBase: RuleBase{
+			name: "shallow-checkout",
+			desc: "Checks for git commands depending on the history after shallow checkout by actions/checkout",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleShallowCheckout) VisitJobPre(n *Job) error {
+	rule.checkout = nil
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleShallowCheckout) VisitStep(n *Step) error {
+	switch e := n.Exec.(type) {
+	case *ExecAction:
+		if e.Uses == nil || !strings.HasPrefix(strings.ToLower(e.Uses.Value), "actions/checkout@") {
+			return nil
+		}
+		if isShallowCheckout(e) && !rule.isIgnoredByComment(e.UsesComment) {
+			rule.checkout = e
+		} else {
+			rule.checkout = nil
+		}
+	case *ExecRun:
+		if rule.checkout == nil || e.Run == nil || rule.isIgnoredByComment(e.RunComment) {
+			return nil
+		}
+		m := reGitHistoryCommand.FindString(e.Run.Value)
+		if m == "" {
+			return nil
+		}
+		rule.Errorf(
+			e.Run.Pos,
+			"%q in the script may not work as expected since %q at %s does not fetch all history and tags (\"fetch-depth\" is 1 by default). set \"fetch-depth: 0\" at \"with:\" of the checkout step to fetch all history and tags, or put comment \"# %s\" at the line of \"run:\" if this is intended",
+			m,
+			rule.checkout.Uses.Value,
+			rule.checkout.Uses.Pos,
+			ignoreComment(rule.name),
+		)
+		rule.checkout = nil // Report only once per checkout
+	}
+	return nil
+}
+
+// isShallowCheckout returns true when the actions/checkout step fetches only some commits.
+// `fetch-depth: 0` means fetching all history. When the value is given by ${{ }}, the depth is
+// unknown so it is not regarded as shallow.
+func isShallowCheckout(e *ExecAction) bool {
+	i, ok := e.Inputs["fetch-depth"]
+	if !ok || i.Value == nil {
+		return true
+	}
+	v := strings.TrimSpace(i.Value.Value)
+	return v != "0" && !i.Value.ContainsExpression()
+}
@@ -1,6 +1,7 @@
 package actionlint
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -12,11 +13,32 @@ const (
 	platformKindWindows
 )
 
-// RuleShellName is a rule to check 'shell' field. For more details, see
+// bashism is a bash-specific construct which does not work with POSIX sh such as dash.
+type bashism struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// bashisms is a list of obvious bash-specific constructs detected in scripts run by sh. This is a
+// lightweight heuristic. shellcheck checks scripts more precisely with `--shell sh`.
+var bashisms = []bashism{
+	{"[[ ]]", regexp.MustCompile(`(?:^|[\s;&|(!])\[\[\s`)},
+	{"declare", regexp.MustCompile(`(?:^|[\s;&|(])declare\s`)},
+	{"typeset", regexp.MustCompile(`(?:^|[\s;&|(])typeset\s`)},
+	{"<<<", regexp.MustCompile(`<<<`)},
+	{"function", regexp.MustCompile(`(?:^|[\s;&|])function\s+[a-zA-Z_][\w-]*`)},
+	{"source", regexp.MustCompile(`(?:^|[\s;&|])source\s`)},
+}
+
+// RuleShellName is a rule to check 'shell' field. It also reports obvious bash-specific constructs
+// in scripts at 'run:' whose shell is POSIX sh. For more details, see
 // https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#using-a-specific-shell
 type RuleShellName struct {
 	RuleBase
-	platform platformKind
+	platform      platformKind
+	workflowShell *String
+	jobShell      *String
+	action        bool
 }
 
 // NewRuleShellName creates new RuleShellName instance.
@@ -24,7 +46,7 @@ func NewRuleShellName() *RuleShellName {
 	return &RuleShellName{
 		RuleBase: RuleBase{
 			name: "shell-name",
-			desc: "Checks for shell names used for scripts in \"run:\"",
+			desc: "Checks for shell names used for scripts in \"run:\" and bash-specific syntax in scripts run by \"sh\"",
 		},
 		platform: platformKindAny,
 	}
@@ -33,11 +55,32 @@ func NewRuleShellName() *RuleShellName {
 // VisitStep is callback when visiting Step node.
 func (rule *RuleShellName) VisitStep(n *Step) error {
 	if run, ok := n.Exec.(*ExecRun); ok {
+		// "shell" is required in steps of composite action since defaults are not available
+		// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runsstepsshell
+		if rule.action && run.Shell == nil && run.RunPos != nil {
+			rule.Error(run.RunPos, "\"shell\" is required for \"run\" step in composite action. set \"shell: bash\" for example")
+		}
 		rule.checkShellName(run.Shell)
+		rule.checkBashisms(run)
 	}
 	return nil
 }
 
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleShellName) VisitActionPre(n *Action) error {
+	rule.action = true
+	rule.platform = platformKindAny // Runner of the action is unknown
+	rule.workflowShell = nil
+	rule.jobShell = nil
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleShellName) VisitActionPost(n *Action) error {
+	rule.action = false
+	return nil
+}
+
 // VisitJobPre is callback when visiting Job node before visiting its children.
 func (rule *RuleShellName) VisitJobPre(n *Job) error {
 	if n.RunsOn == nil {
@@ -46,24 +89,132 @@ func (rule *RuleShellName) VisitJobPre(n *Job) error {
 	rule.platform = rule.getPlatformFromRunner(n.RunsOn)
 	if n.Defaults != nil && n.Defaults.Run != nil {
 		rule.checkShellName(n.Defaults.Run.Shell)
+		rule.jobShell = n.Defaults.Run.Shell
+	}
+	if rule.jobShell == nil {
+		rule.checkWorkflowShellOnJob(n)
 	}
 	return nil
 }
 
+// checkWorkflowShellOnJob checks the shell at workflow-level "defaults.run.shell" is available on
+// the platform of the job which inherits it. The shell name itself was already checked at
+// VisitWorkflowPre without knowing the platform.
+func (rule *RuleShellName) checkWorkflowShellOnJob(n *Job) {
+	s := rule.workflowShell
+	if s == nil || rule.platform == platformKindAny || n.ID == nil {
+		return
+	}
+	if strings.Contains(s.Value, "{0}") || s.ContainsExpression() {
+		return
+	}
+
+	inherited := false
+	for _, step := range n.Steps {
+		if r, ok := step.Exec.(*ExecRun); ok && r.Shell == nil {
+			inherited = true
+			break
+		}
+	}
+	if !inherited {
+		return // All steps in the job specify their own shells
+	}
+
+	name := strings.ToLower(s.Value)
+	for _, a := range getAvailableShellNames(rule.platform) {
+		if name == a {
+			return
+		}
+	}
+	known := false
+	for _, a := range getAvailableShellNames(platformKindAny) {
+		if name == a {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return // Invalid shell name was already reported
+	}
+
+	platform := "macOS or Linux"
+	if rule.platform == platformKindWindows {
+		platform = "Windows"
+	}
+	rule.Errorf(
+		s.Pos,
+		"shell name %q at \"defaults.run.shell\" of the workflow is not available on %s where job %q runs. set \"defaults.run.shell\" of the job to one of %s",
+		s.Value,
+		platform,
+		n.ID.Value,
+		sortedQuotes(getAvailableShellNames(rule.platform)),
+	)
+}
+
 // VisitJobPost is callback when visiting Job node after visiting its children.
 func (rule *RuleShellName) VisitJobPost(n *Job) error {
 	rule.platform = platformKindAny // Clear
+	rule.jobShell = nil
 	return nil
 }
 
 // VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
 func (rule *RuleShellName) VisitWorkflowPre(n *Workflow) error {
+	rule.workflowShell = nil
 	if n.Defaults != nil && n.Defaults.Run != nil {
 		rule.checkShellName(n.Defaults.Run.Shell)
+		rule.workflowShell = n.Defaults.Run.Shell
 	}
 	return nil
 }
 
+// checkBashisms reports bash-specific constructs in the script when the effective shell of the
+// step is sh. The default shell on macOS and Linux is bash so only explicit `shell: sh` is checked.
+func (rule *RuleShellName) checkBashisms(run *ExecRun) {
+	if run.Run == nil {
+		return
+	}
+
+	shell := run.Shell
+	if shell == nil {
+		shell = rule.jobShell
+	}
+	if shell == nil {
+		shell = rule.workflowShell
+	}
+	if shell == nil || shell.ContainsExpression() {
+		return
+	}
+	if name := strings.ToLower(shell.Value); name != "sh" && !strings.HasPrefix(name, "sh ") {
+		return
+	}
+
+	lines := strings.Split(run.Run.Value, "\n")
+	var found []string
+	for _, b := range bashisms {
+		for _, l := range lines {
+			if strings.HasPrefix(strings.TrimSpace(l), "#") {
+				continue // Skip comment lines
+			}
+			if b.re.MatchString(l) {
+				found = append(found, b.name)
+				break
+			}
+		}
+	}
+	if len(found) == 0 {
+		return
+	}
+
+	rule.Errorf(
+		run.Run.Pos,
+		"bash-specific syntax %s is used in the script though the shell is %q at %s. it does not work with POSIX sh such as dash. set \"shell: bash\" or rewrite the script in POSIX sh",
+		quotes(found),
+		shell.Value,
+		shell.Pos,
+	)
+}
+
 func (rule *RuleShellName) checkShellName(node *String) {
 	if node == nil {
 		return
@@ -24,6 +24,8 @@ type RuleShellcheck struct {
 	jobShell      string
 	runnerShell   string
 	mu            sync.Mutex
+	// cache is an on-disk cache of shellcheck results. Nil means the cache is disabled.
+	cache *shellcheckCache
 }
 
 func newRuleShellcheck(cmd *externalCommand) *RuleShellcheck {
@@ -103,6 +105,19 @@ func (rule *RuleShellcheck) VisitWorkflowPost(n *Workflow) error {
 	return rule.cmd.wait() // Wait until all processes running for this rule
 }
 
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (rule *RuleShellcheck) VisitActionPre(n *Action) error {
+	rule.workflowShell = ""
+	rule.jobShell = ""
+	rule.runnerShell = ""
+	return nil
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (rule *RuleShellcheck) VisitActionPost(n *Action) error {
+	return rule.cmd.wait() // Wait until all processes running for this rule
+}
+
 func (rule *RuleShellcheck) getShellName(exec *ExecRun) string {
 	if exec.Shell != nil {
 		return exec.Shell.Value
@@ -200,6 +215,16 @@ func (rule *RuleShellcheck) runShellcheck(src, shell string, pos *Pos) {
 	}
 	script := fmt.Sprintf("%s\n%s\n", setup, src)
 
+	var key string
+	if rule.cache != nil && rule.cache.available(rule.cmd) {
+		key = rule.cache.key(args, script)
+		if errs, ok := rule.cache.get(key); ok {
+			rule.Debug("%s: Use cached shellcheck result %s", pos, key)
+			rule.reportErrors(errs, pos)
+			return
+		}
+	}
+
 	rule.cmd.run(args, script, func(stdout []byte, err error) error {
 		if err != nil {
 			rule.Debug("Command %s %s failed: %v", rule.cmd.exe, args, err)
@@ -210,26 +235,35 @@ func (rule *RuleShellcheck) runShellcheck(src, shell string, pos *Pos) {
 		if err := json.Unmarshal(stdout, &errs); err != nil {
 			return fmt.Errorf("could not parse JSON output from shellcheck: %w: stdout=%q", err, stdout)
 		}
-		if len(errs) == 0 {
-			return nil
-		}
-
-		// Synchronize rule.Errorf calls
-		rule.mu.Lock()
-		defer rule.mu.Unlock()
-		// It's better to show source location in the script as position of error, but it's not
-		// possible easily. YAML has multiple block styles with '|', '>', '|+', '>+', '|-', '>-'. Some
-		// of them remove indentation and/or blank lines. So restoring source position in block string
-		// is not possible. Sourcemap is necessary to do it.
-		// Instead, actionlint shows position of 'run:' as position of error. And separately show
-		// location in script which is reported by shellcheck in error message.
-		for _, err := range errs {
-			// Consider the first line is setup for running shell which was implicitly added for better check
-			line := err.Line - 1
-			msg := strings.TrimSuffix(err.Message, ".") // Trim period aligning style of error message
-			rule.Errorf(pos, "shellcheck reported issue in this script: SC%d:%s:%d:%d: %s", err.Code, err.Level, line, err.Column, msg)
+		if key != "" {
+			if err := rule.cache.put(key, errs); err != nil {
+				rule.Debug("Could not cache shellcheck result: %v", err)
+			}
 		}
 
+		rule.reportErrors(errs, pos)
 		return nil
 	})
 }
+
+func (rule *RuleShellcheck) reportErrors(errs []shellcheckError, pos *Pos) {
+	if len(errs) == 0 {
+		return
+	}
+
+	// Synchronize rule.Errorf calls
+	rule.mu.Lock()
+	defer rule.mu.Unlock()
+	// It's better to show source location in the script as position of error, but it's not
+	// possible easily. YAML has multiple block styles with '|', '>', '|+', '>+', '|-', '>-'. Some
+	// of them remove indentation and/or blank lines. So restoring source position in block string
+	// is not possible. Sourcemap is necessary to do it.
+	// Instead, actionlint shows position of 'run:' as position of error. And separately show
+	// location in script which is reported by shellcheck in error message.
+	for _, err := range errs {
+		// Consider the first line is setup for running shell which was implicitly added for better check
+		line := err.Line - 1
+		msg := strings.TrimSuffix(err.Message, ".") // Trim period aligning style of error message
+		rule.Errorf(pos, "shellcheck reported issue in this script: SC%d:%s:%d:%d: %s", err.Code, err.Level, line, err.Column, msg)
+	}
+}
@@ -36,12 +36,14 @@ func TestRuleBaseErrorfAndErrs(t *testing.T) {
 			Message: "this is test 1",
 			Line:    1,
 			Column:  2,
+			Offset:  -1,
 			Kind:    "dummy name",
 		},
 		{
 			Message: "this is test 2",
 			Line:    3,
 			Column:  4,
+			Offset:  -1,
 			Kind:    "dummy name",
 		},
 	}
@@ -74,3 +76,26 @@ func TestRuleBaseNameAndDescription(t *testing.T) {
 		t.Errorf("description is unexpected: %q", r.Description())
 	}
 }
+
+func TestRuleBaseIsIgnoredByComment(t *testing.T) {
+	testCases := []struct {
+		comment string
+		want    bool
+	}{
+		{"# actionlint-ignore-action", true},
+		{"#actionlint-ignore-action", true},
+		{"# v1.2.3 actionlint-ignore-action", true},
+		{"# actionlint-ignore-action, see #123", true},
+		{"# actionlint-ignore-action-permissions", false},
+		{"# actionlint-ignore-action-permissions actionlint-ignore-action", true},
+		{"# actionlint-ignore-actions", false},
+		{"# actionlint-ignore-shellcheck", false},
+		{"", false},
+	}
+	r := NewRuleBase("action", "")
+	for _, tc := range testCases {
+		if have := r.isIgnoredByComment(tc.comment); have != tc.want {
+			t.Errorf("wanted %v for comment %q but got %v", tc.want, tc.comment, have)
+		}
+	}
+}
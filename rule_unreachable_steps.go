@@ -0,0 +1,148 @@
+package actionlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// reTrailingExit matches a line which only runs `exit` command with non-zero literal exit status.
+var reTrailingExit = regexp.MustCompile(`^exit\s+([1-9][0-9]*)\s*;?$`)
+
+// RuleUnreachableSteps is a rule to detect steps which never run because the previous step always
+// fails with `exit` command at the end of its script. This is a best-effort check. Only the
+// unconditional `exit` at top level of the script is detected.
+type RuleUnreachableSteps struct {
+	RuleBase
+	workflowShell *String
+	jobShell      *String
+	// exit is the `run:` of the step which always exits with non-zero status. Steps following the
+	// step are unreachable.
+	exit *ExecRun
+	// status is the exit status of the step at `exit` field.
+	status string
+}
+
+// NewRuleUnreachableSteps creates new RuleUnreachableSteps instance.
+func NewRuleUnreachableSteps() *RuleUnreachableSteps {
+	return &RuleUnreachableSteps{
+		RuleBase: RuleBase{
+			name: "unreachable-steps",
+			desc: "Checks for steps which never run because the previous step always exits with non-zero status",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleUnreachableSteps) VisitWorkflowPre(n *Workflow) error {
+	rule.workflowShell = nil
+	if n.Defaults != nil && n.Defaults.Run != nil {
+		rule.workflowShell = n.Defaults.Run.Shell
+	}
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleUnreachableSteps) VisitJobPre(n *Job) error {
+	rule.jobShell = nil
+	if n.Defaults != nil && n.Defaults.Run != nil {
+		rule.jobShell = n.Defaults.Run.Shell
+	}
+	rule.exit = nil
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleUnreachableSteps) VisitJobPost(n *Job) error {
+	rule.jobShell = nil
+	rule.exit = nil
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleUnreachableSteps) VisitStep(n *Step) error {
+	// Steps with `if:` may run after the failure. For example, `if: failure()` or `if: always()`.
+	if n.If != nil {
+		return nil
+	}
+
+	if rule.exit != nil {
+		rule.Errorf(
+			n.Pos,
+			"this step is unreachable because the previous step at %s always fails with \"exit %s\" at the end of its script. set \"continue-on-error: true\" at the previous step or add \"if:\" condition such as \"if: failure()\" to this step if this is intended",
+			rule.exit.RunPos,
+			rule.status,
+		)
+		rule.exit = nil // Report only the first unreachable step
+		return nil
+	}
+
+	run, ok := n.Exec.(*ExecRun)
+	if !ok || run.Run == nil || n.ContinueOnError != nil || !rule.isPOSIXLikeShell(run) {
+		return nil
+	}
+
+	if s, ok := trailingExitStatus(run.Run.Value); ok {
+		rule.exit = run
+		rule.status = s
+	}
+	return nil
+}
+
+// isPOSIXLikeShell returns true when `exit N` in the script fails the step. The shell is bash, sh,
+// pwsh, or powershell.
+func (rule *RuleUnreachableSteps) isPOSIXLikeShell(run *ExecRun) bool {
+	shell := run.Shell
+	if shell == nil {
+		shell = rule.jobShell
+	}
+	if shell == nil {
+		shell = rule.workflowShell
+	}
+	if shell == nil {
+		return true // Default shell is bash or pwsh
+	}
+	if shell.ContainsExpression() {
+		return false
+	}
+	name := shell.Value
+	if i := strings.IndexByte(name, ' '); i >= 0 {
+		name = name[:i]
+	}
+	switch strings.ToLower(name) {
+	case "bash", "sh", "pwsh", "powershell":
+		return true
+	default:
+		return false
+	}
+}
+
+// trailingExitStatus returns the exit status when the last command of the script is `exit N` at
+// top level with non-zero literal N. To avoid false positives, it returns false when the script
+// contains heredoc or the `exit` command is continued from the previous line such as `cmd || exit 1`.
+func trailingExitStatus(script string) (string, bool) {
+	if strings.Contains(script, "<<") {
+		return "", false // `exit` may be in heredoc
+	}
+
+	var last, prev string
+	for _, l := range strings.Split(script, "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		prev, last = last, l
+	}
+
+	m := reTrailingExit.FindStringSubmatch(last)
+	if m == nil {
+		return "", false
+	}
+
+	for _, s := range []string{"\\", "&&", "||", "|"} {
+		if strings.HasSuffix(prev, s) {
+			return "", false // The `exit` is a part of the previous command
+		}
+	}
+
+	return m[1], true
+}
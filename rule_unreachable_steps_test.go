@@ -0,0 +1,46 @@
+package actionlint
+
+import "testing"
+
+func TestRuleUnreachableStepsTrailingExitStatus(t *testing.T) {
+	tests := []struct {
+		what   string
+		script string
+		want   string
+	}{
+		{"exit 1", "exit 1", "1"},
+		{"exit with other status", "echo error\nexit 42", "42"},
+		{"semicolon", "exit 1;", "1"},
+		{"trailing comment and spaces", "echo error\n  exit 1  \n# done\n\n", "1"},
+		{"exit 0", "exit 0", ""},
+		{"exit without status", "echo hello\nexit", ""},
+		{"exit with variable", "exit $code", ""},
+		{"exit not at the end", "exit 1\necho hello", ""},
+		{"exit in if", "if [ -f foo ]; then exit 1; fi", ""},
+		{"exit in if block", "if [ -f foo ]; then\n  exit 1\nfi", ""},
+		{"exit in function", "f() {\n  exit 1\n}", ""},
+		{"exit with or", "make || exit 1", ""},
+		{"exit continued from or", "make ||\n  exit 1", ""},
+		{"exit continued from and", "test -f foo &&\n  exit 1", ""},
+		{"exit continued from backslash", "echo \\\n  exit 1", ""},
+		{"heredoc", "cat <<EOS\nexit 1", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			have, ok := trailingExitStatus(tc.script)
+			if tc.want == "" {
+				if ok {
+					t.Fatalf("exit status should not be detected in %q but got %q", tc.script, have)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("exit status %q was not detected in %q", tc.want, tc.script)
+			}
+			if have != tc.want {
+				t.Fatalf("wanted exit status %q but got %q in %q", tc.want, have, tc.script)
+			}
+		})
+	}
+}
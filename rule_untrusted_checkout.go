@@ -0,0 +1,118 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// RuleUntrustedCheckout is a rule checker to detect actions/checkout which checks out the head of
+// the pull request in workflows triggered by pull_request_target event. The workflows run with
+// write permissions and secrets of the base repository so running the untrusted code of the pull
+// request in them can leak the secrets.
+// https://securitylab.github.com/research/github-actions-preventing-pwn-requests/
+type RuleUntrustedCheckout struct {
+	RuleBase
+	enabled bool
+	exprs   exprCollector
+	// value is the input value of actions/checkout being checked.
+	value string
+	// found is the untrusted property referenced in the input value. Empty when not found.
+	found string
+}
+
+// NewRuleUntrustedCheckout creates a new RuleUntrustedCheckout instance.
+func NewRuleUntrustedCheckout() *RuleUntrustedCheckout {
+	r := &RuleUntrustedCheckout{
+		RuleBase: RuleBase{
+			name: "untrusted-checkout",
+			desc: "Checks for checking out the head of pull request in workflows triggered by \"pull_request_target\" event",
+		},
+	}
+	r.exprs.visit = r.collectExpr
+	return r
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleUntrustedCheckout) VisitWorkflowPre(n *Workflow) error {
+	rule.enabled = false
+	for _, e := range n.On {
+		if w, ok := e.(*WebhookEvent); ok && w.Hook != nil && w.Hook.Value == "pull_request_target" {
+			rule.enabled = true
+			break
+		}
+	}
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleUntrustedCheckout) VisitStep(n *Step) error {
+	if !rule.enabled {
+		return nil
+	}
+	e, ok := n.Exec.(*ExecAction)
+	if !ok || e.Uses == nil || !strings.HasPrefix(strings.ToLower(e.Uses.Value), "actions/checkout@") {
+		return nil
+	}
+	if rule.isIgnoredByComment(e.UsesComment) {
+		return nil
+	}
+
+	for _, name := range []string{"ref", "repository"} {
+		i, ok := e.Inputs[name]
+		if !ok || i.Value == nil {
+			continue
+		}
+		rule.value = i.Value.Value
+		rule.found = ""
+		rule.exprs.collectString(i.Value)
+		if rule.found == "" {
+			continue
+		}
+		rule.Errorf(
+			i.Value.Pos,
+			"checking out the head of pull request with %q at %q input of %q is dangerous in the workflow triggered by \"pull_request_target\" event. the workflow runs with write permissions and secrets of the base repository so the untrusted code of the pull request can steal them. use \"pull_request\" event instead or put comment \"# %s\" at the line of \"uses:\" if the checked out code is never executed",
+			rule.found,
+			name,
+			e.Uses.Value,
+			ignoreComment(rule.name),
+		)
+		return nil // Report only once per step
+	}
+	return nil
+}
+
+func (rule *RuleUntrustedCheckout) collectExpr(expr ExprNode, pos *Pos) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if !entering || rule.found != "" {
+			return
+		}
+		path := propertyPathOf(n)
+		if isUntrustedPullRequestRef(path, rule.value) {
+			rule.found = strings.Join(path, ".")
+		}
+	})
+}
+
+// isUntrustedPullRequestRef returns true when the property path points to the head of the pull
+// request like github.event.pull_request.head.sha. The pull request number is also untrusted when
+// it is used for the ref of the pull request like refs/pull/${{ github.event.number }}/merge.
+func isUntrustedPullRequestRef(path []string, value string) bool {
+	if len(path) < 2 || path[0] != "github" {
+		return false
+	}
+	if len(path) == 2 {
+		return path[1] == "head_ref"
+	}
+	if path[1] != "event" {
+		return false
+	}
+	if len(path) >= 4 && path[2] == "pull_request" && path[3] == "head" {
+		return true // e.g. github.event.pull_request.head.sha, github.event.pull_request.head.repo.full_name
+	}
+	switch strings.Join(path[2:], ".") {
+	case "pull_request.merge_commit_sha":
+		return true
+	case "number", "pull_request.number":
+		return strings.Contains(value, "refs/pull/")
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+// RuleUnusedInputs is a rule to check inputs of workflow_dispatch event which are never referenced
+// in the workflow. This rule is enabled by "check-unused-inputs" in the config file.
+type RuleUnusedInputs struct {
+	RuleBase
+	exprs  exprCollector
+	inputs map[string]*DispatchInput
+	// used is a set of input names referenced in the workflow. Keys are in lower case.
+	used map[string]struct{}
+	// all is set to true when the entire inputs object is referenced like toJSON(inputs).
+	all bool
+}
+
+// NewRuleUnusedInputs creates new RuleUnusedInputs instance.
+func NewRuleUnusedInputs() *RuleUnusedInputs {
+	r := &RuleUnusedInputs{
+		RuleBase: RuleBase{
+			name: "unused-inputs",
+			desc: "Checks for inputs of workflow_dispatch event which are never used in the workflow when \"check-unused-inputs\" is enabled",
+		},
+	}
+	r.exprs.visit = func(expr ExprNode, pos *Pos) { r.collectExpr(expr) }
+	return r
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleUnusedInputs) VisitWorkflowPre(n *Workflow) error {
+	if cfg := rule.Config(); cfg == nil || !cfg.CheckUnusedInputs {
+		return nil
+	}
+
+	for _, e := range n.On {
+		if e, ok := e.(*WorkflowDispatchEvent); ok && len(e.Inputs) > 0 {
+			rule.inputs = e.Inputs
+		}
+	}
+	if rule.inputs == nil {
+		return nil
+	}
+
+	rule.used = map[string]struct{}{}
+	rule.all = false
+
+	rule.exprs.collectString(n.Name)
+	rule.exprs.collectString(n.RunName)
+	rule.exprs.collectEnv(n.Env)
+	rule.exprs.collectDefaults(n.Defaults)
+	rule.exprs.collectConcurrency(n.Concurrency)
+
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleUnusedInputs) VisitJobPre(n *Job) error {
+	if rule.inputs == nil {
+		return nil
+	}
+
+	rule.exprs.collectJob(n)
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleUnusedInputs) VisitStep(n *Step) error {
+	if rule.inputs == nil {
+		return nil
+	}
+
+	rule.exprs.collectStep(n)
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleUnusedInputs) VisitWorkflowPost(n *Workflow) error {
+	if rule.inputs == nil {
+		return nil
+	}
+
+	if !rule.all {
+		for id, i := range rule.inputs {
+			if _, ok := rule.used[id]; ok {
+				continue
+			}
+			// "unused-input" is accepted as an alias of the rule name in the comment
+			if rule.isIgnoredByComment(i.Comment) || hasIgnoreComment(i.Comment, "unused-input") {
+				continue
+			}
+			rule.Errorf(
+				i.Name.Pos,
+				"input %q of \"workflow_dispatch\" event is defined but never used in this workflow. remove the input or put comment \"# %s\" at the line of the input if it is used outside the workflow",
+				i.Name.Value,
+				ignoreComment(rule.name),
+			)
+		}
+	}
+
+	rule.inputs = nil
+	rule.used = nil
+	return nil
+}
+
+func (rule *RuleUnusedInputs) collectExpr(expr ExprNode) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if !entering || rule.all {
+			return
+		}
+
+		// `inputs` context and `github.event.inputs` object are both available for workflow_dispatch
+		// inputs. `github` context and `github.event` object contain the inputs as well.
+		switch strings.Join(propertyPathOf(n), ".") {
+		case "inputs", "github.event.inputs":
+			if name, ok := staticPropertyAccess(n, p); ok {
+				rule.used[name] = struct{}{}
+			} else {
+				rule.all = true // e.g. toJSON(inputs), inputs[matrix.name]
+			}
+		case "github", "github.event":
+			if _, ok := staticPropertyAccess(n, p); !ok {
+				rule.all = true // e.g. toJSON(github.event)
+			}
+		}
+	})
+}
+
+// propertyPathOf returns a path of property accesses in lower case like ["github", "event"] for
+// `github.event`. It returns nil when the node is not a chain of static property accesses.
+func propertyPathOf(n ExprNode) []string {
+	switch n := n.(type) {
+	case *VariableNode:
+		return []string{strings.ToLower(n.Name)}
+	case *ObjectDerefNode:
+		if p := propertyPathOf(n.Receiver); p != nil {
+			return append(p, strings.ToLower(n.Property))
+		}
+	case *IndexAccessNode:
+		if s, ok := n.Index.(*StringNode); ok {
+			if p := propertyPathOf(n.Operand); p != nil {
+				return append(p, strings.ToLower(s.Value))
+			}
+		}
+	}
+	return nil
+}
+
+// staticPropertyAccess returns the property name in lower case when the parent node accesses the
+// property of the node statically like `n.foo` or `n['foo']`.
+func staticPropertyAccess(n, p ExprNode) (string, bool) {
+	switch p := p.(type) {
+	case *ObjectDerefNode:
+		if p.Receiver == n {
+			return strings.ToLower(p.Property), true
+		}
+	case *IndexAccessNode:
+		if s, ok := p.Index.(*StringNode); ok && p.Operand == n {
+			return strings.ToLower(s.Value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,238 @@
+package actionlint
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// reStepOutputWrite matches a line which writes an output to $GITHUB_OUTPUT with `echo` or `printf`
+// like `echo "name=value" >> "$GITHUB_OUTPUT"` or `echo "name<<EOF" >> $GITHUB_OUTPUT`.
+var reStepOutputWrite = regexp.MustCompile(`\b(?:echo|printf)\s+(?:-[a-zA-Z]+\s+)*["']?([a-zA-Z_][a-zA-Z0-9_-]*)(?:=|<<).*>>?\s*["']?\$\{?GITHUB_OUTPUT\b`)
+
+// stepOutputs is outputs set by the step with ID.
+type stepOutputs struct {
+	id    *String
+	run   *ExecRun
+	names []string
+}
+
+// RuleUnusedStepOutputs is a rule to detect outputs of steps which are set in the scripts at `run:`
+// but never referenced in the job. Outputs of steps are only available in the same job so they
+// are referenced by the following steps or the job outputs at `outputs:`. This is a heuristic
+// rule. Only outputs written by `echo` or `printf` at one line are detected.
+type RuleUnusedStepOutputs struct {
+	RuleBase
+	steps []*stepOutputs
+	// used is a mapping from step IDs to the set of output names referenced in the job. When the set
+	// is nil, all outputs of the step are referenced. Keys are in lower case.
+	used map[string]map[string]struct{}
+	// all is set to true when the entire steps context is referenced like toJSON(steps).
+	all bool
+}
+
+// NewRuleUnusedStepOutputs creates new RuleUnusedStepOutputs instance.
+func NewRuleUnusedStepOutputs() *RuleUnusedStepOutputs {
+	return &RuleUnusedStepOutputs{
+		RuleBase: RuleBase{
+			name: "unused-step-outputs",
+			desc: "Checks for outputs of steps which are set in scripts but never used in the job",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleUnusedStepOutputs) VisitJobPre(n *Job) error {
+	rule.steps = nil
+	rule.used = map[string]map[string]struct{}{}
+	rule.all = false
+
+	for _, o := range n.Outputs {
+		rule.collectString(o.Value)
+	}
+	if n.Environment != nil {
+		rule.collectString(n.Environment.URL) // URL is evaluated after all steps were run
+	}
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleUnusedStepOutputs) VisitStep(n *Step) error {
+	rule.collectString(n.Name)
+	rule.collectIfCondition(n.If)
+	switch e := n.Exec.(type) {
+	case *ExecRun:
+		rule.collectString(e.Run)
+		rule.collectString(e.Shell)
+		rule.collectString(e.WorkingDirectory)
+		rule.collectOutputs(n.ID, e)
+	case *ExecAction:
+		for _, i := range e.Inputs {
+			rule.collectString(i.Value)
+		}
+		rule.collectString(e.Entrypoint)
+		rule.collectString(e.Args)
+	}
+	if n.Env != nil {
+		rule.collectString(n.Env.Expression)
+		for _, v := range n.Env.Vars {
+			rule.collectString(v.Value)
+		}
+	}
+	if n.ContinueOnError != nil {
+		rule.collectString(n.ContinueOnError.Expression)
+	}
+	if n.TimeoutMinutes != nil {
+		rule.collectString(n.TimeoutMinutes.Expression)
+	}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleUnusedStepOutputs) VisitJobPost(n *Job) error {
+	if !rule.all {
+		for _, s := range rule.steps {
+			used, ok := rule.used[strings.ToLower(s.id.Value)]
+			if ok && used == nil {
+				continue // All outputs are used like toJSON(steps.foo.outputs)
+			}
+			for _, name := range s.names {
+				if _, ok := used[strings.ToLower(name)]; ok {
+					continue
+				}
+				rule.Errorf(
+					s.run.Run.Pos,
+					"output %q of step %q is set in the script but never used in job %q. outputs of steps are only available in the same job. remove the output or put comment \"# %s\" at the line of \"run:\" if this is intended",
+					name,
+					s.id.Value,
+					n.ID.Value,
+					ignoreComment(rule.name),
+				)
+			}
+		}
+	}
+
+	rule.steps = nil
+	rule.used = nil
+	return nil
+}
+
+func (rule *RuleUnusedStepOutputs) collectOutputs(id *String, e *ExecRun) {
+	if id == nil || id.ContainsExpression() || e.Run == nil || rule.isIgnoredByComment(e.RunComment) {
+		return
+	}
+
+	names, _ := scriptStepOutputs(e.Run.Value)
+	if len(names) == 0 {
+		return
+	}
+	rule.steps = append(rule.steps, &stepOutputs{id, e, names})
+}
+
+// scriptStepOutputs returns the sorted names of outputs written to $GITHUB_OUTPUT in the script. The
+// second return value is false when some line in the script writes outputs in the way this
+// heuristic cannot parse, such as `cat <<EOF >> "$GITHUB_OUTPUT"` or the deprecated set-output
+// command. In the case, the script may set outputs not in the returned names.
+func scriptStepOutputs(script string) ([]string, bool) {
+	complete := true
+	seen := map[string]struct{}{}
+	for _, l := range strings.Split(script, "\n") {
+		if strings.Contains(l, "::set-output ") {
+			complete = false
+			continue
+		}
+		if !strings.Contains(l, "GITHUB_OUTPUT") {
+			continue
+		}
+		if m := reStepOutputWrite.FindStringSubmatch(l); m != nil {
+			seen[m[1]] = struct{}{}
+		} else {
+			complete = false
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names, complete
+}
+
+func (rule *RuleUnusedStepOutputs) collectString(s *String) {
+	if s == nil {
+		return
+	}
+	for v := s.Value; ; {
+		idx := strings.Index(v, "${{")
+		if idx == -1 {
+			return
+		}
+		v = v[idx+3:] // 3 means removing "${{"
+
+		l := NewExprLexer(v)
+		expr, err := NewExprParser().Parse(l)
+		if err != nil {
+			return // Parse errors are reported by "expression" rule
+		}
+		rule.collectExpr(expr)
+		v = v[l.Offset():]
+	}
+}
+
+// collectIfCondition collects outputs referenced in the if: condition. The condition may not be
+// enclosed with ${{ }}.
+func (rule *RuleUnusedStepOutputs) collectIfCondition(s *String) {
+	if s == nil {
+		return
+	}
+	if s.ContainsExpression() {
+		rule.collectString(s)
+		return
+	}
+	if expr, err := NewExprParser().Parse(NewExprLexer(s.Value + "}}")); err == nil {
+		rule.collectExpr(expr)
+	}
+}
+
+func (rule *RuleUnusedStepOutputs) collectExpr(expr ExprNode) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if !entering || rule.all {
+			return
+		}
+
+		path := propertyPathOf(n)
+		if len(path) == 0 || path[0] != "steps" {
+			return
+		}
+
+		switch len(path) {
+		case 1:
+			if _, ok := staticPropertyAccess(n, p); !ok {
+				rule.all = true // e.g. toJSON(steps), steps[matrix.id]
+			}
+		case 2:
+			if _, ok := staticPropertyAccess(n, p); !ok {
+				rule.used[path[1]] = nil // e.g. toJSON(steps.foo)
+			}
+		case 3:
+			if path[2] != "outputs" {
+				return
+			}
+			name, ok := staticPropertyAccess(n, p)
+			if !ok {
+				rule.used[path[1]] = nil // e.g. toJSON(steps.foo.outputs)
+				return
+			}
+			used, ok := rule.used[path[1]]
+			if ok && used == nil {
+				return
+			}
+			if !ok {
+				used = map[string]struct{}{}
+				rule.used[path[1]] = used
+			}
+			used[name] = struct{}{}
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package actionlint
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// RuleWorkingDirectory is a rule to check paths at `working-directory:` exist in the repository.
+// The paths are resolved from the path where the repository is checked out by actions/checkout.
+// This rule is enabled by "check-working-directory" in the config file and skipped when the
+// workflow is not in a repository.
+type RuleWorkingDirectory struct {
+	RuleBase
+	// project is the repository which the workflow belongs to. Nil when the workflow is not in a
+	// repository.
+	project         *Project
+	workflowDefault *String
+	jobDefault      *String
+	// checkouts is a mapping from the paths where repositories are checked out by actions/checkout
+	// in the current job to whether the repository is the repository of the workflow.
+	checkouts map[string]bool
+	// unknown is true when some repository is checked out at an unknown path in the current job.
+	unknown bool
+	// checked is a set of `working-directory:` values already checked. It prevents reporting the
+	// same default value at `defaults.run` repeatedly.
+	checked map[*String]struct{}
+}
+
+// NewRuleWorkingDirectory creates new RuleWorkingDirectory instance.
+func NewRuleWorkingDirectory() *RuleWorkingDirectory {
+	return &RuleWorkingDirectory{
+		RuleBase: RuleBase{
+			name: "working-directory",
+			desc: "Checks for paths at \"working-directory:\" not existing in the repository when \"check-working-directory\" is enabled",
+		},
+	}
+}
+
+func (rule *RuleWorkingDirectory) enabled() bool {
+	if rule.project == nil {
+		return false
+	}
+	cfg := rule.Config()
+	return cfg != nil && cfg.CheckWorkingDirectory
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleWorkingDirectory) VisitWorkflowPre(n *Workflow) error {
+	rule.workflowDefault = nil
+	if n.Defaults != nil && n.Defaults.Run != nil {
+		rule.workflowDefault = n.Defaults.Run.WorkingDirectory
+	}
+	rule.checked = map[*String]struct{}{}
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleWorkingDirectory) VisitJobPre(n *Job) error {
+	rule.jobDefault = nil
+	if n.Defaults != nil && n.Defaults.Run != nil {
+		rule.jobDefault = n.Defaults.Run.WorkingDirectory
+	}
+	rule.checkouts = map[string]bool{}
+	rule.unknown = false
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleWorkingDirectory) VisitStep(n *Step) error {
+	if !rule.enabled() {
+		return nil
+	}
+
+	switch e := n.Exec.(type) {
+	case *ExecAction:
+		rule.collectCheckout(e)
+	case *ExecRun:
+		dir, sec := e.WorkingDirectory, "working-directory"
+		if dir == nil {
+			dir, sec = rule.jobDefault, "defaults.run.working-directory"
+		}
+		if dir == nil {
+			dir = rule.workflowDefault
+		}
+		if dir != nil {
+			rule.checkDir(dir, sec)
+		}
+	}
+
+	return nil
+}
+
+func (rule *RuleWorkingDirectory) collectCheckout(e *ExecAction) {
+	if e.Uses == nil || !strings.HasPrefix(strings.ToLower(e.Uses.Value), "actions/checkout@") {
+		return
+	}
+
+	self := true
+	if i, ok := e.Inputs["repository"]; ok && i.Value != nil {
+		v := strings.TrimSpace(i.Value.Value)
+		self = v == "${{ github.repository }}"
+	}
+
+	p := "."
+	if i, ok := e.Inputs["path"]; ok && i.Value != nil {
+		if i.Value.ContainsExpression() {
+			rule.Debug("Skip checking \"working-directory\" after %s since the checkout path %q is unknown", e.Uses.Pos, i.Value.Value)
+			rule.unknown = true
+			return
+		}
+		p = path.Clean(filepath.ToSlash(strings.TrimSpace(i.Value.Value)))
+	}
+
+	rule.checkouts[p] = self
+}
+
+// checkDir checks the directory exists in the repository. sec is the name of the section where the
+// directory is specified.
+func (rule *RuleWorkingDirectory) checkDir(dir *String, sec string) {
+	if rule.unknown || dir.ContainsExpression() {
+		return
+	}
+	if _, ok := rule.checked[dir]; ok {
+		return
+	}
+
+	d := filepath.ToSlash(strings.TrimSpace(dir.Value))
+	if d == "" || strings.HasPrefix(d, "/") || strings.HasPrefix(d, "~") || strings.HasPrefix(d, "$") || filepath.IsAbs(d) {
+		return // Absolute paths or paths using variables are outside of the repository or unknown
+	}
+	d = path.Clean(d)
+	if d == ".." || strings.HasPrefix(d, "../") {
+		return
+	}
+
+	// Find the innermost checkout containing the directory
+	root, self, found := "", false, false
+	for p, s := range rule.checkouts {
+		if p != "." && d != p && !strings.HasPrefix(d, p+"/") {
+			continue
+		}
+		if !found || len(p) > len(root) || root == "." {
+			root, self, found = p, s, true
+		}
+	}
+	if !found || !self {
+		return // No repository is checked out yet or the directory is in other repository
+	}
+
+	rule.checked[dir] = struct{}{}
+
+	rel := d
+	if root != "." {
+		rel = strings.TrimPrefix(strings.TrimPrefix(d, root), "/")
+		if rel == "" {
+			rel = "."
+		}
+	}
+
+	where := ""
+	if root != "." {
+		where = fmt.Sprintf(" checked out at %q", root)
+	}
+
+	s, err := os.Stat(filepath.Join(rule.project.RootDir(), filepath.FromSlash(rel)))
+	if err != nil {
+		rule.Debug("Could not stat working directory %q: %s", rel, err)
+		rule.Errorf(
+			dir.Pos,
+			"directory %q at %q does not exist in the repository%s. the step will fail unless the directory is created by some previous step",
+			dir.Value,
+			sec,
+			where,
+		)
+		return
+	}
+	if !s.IsDir() {
+		rule.Errorf(dir.Pos, "%q at %q is not a directory in the repository%s", dir.Value, sec, where)
+	}
+}
@@ -0,0 +1,166 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Types for SARIF 2.1.0 log file. Only the properties used by actionlint are defined.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifReportingConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifReportingDescriptor struct {
+	ID                   string                      `json:"id"`
+	Name                 string                      `json:"name"`
+	ShortDescription     sarifMessage                `json:"shortDescription"`
+	FullDescription      sarifMessage                `json:"fullDescription"`
+	HelpURI              string                      `json:"helpUri"`
+	DefaultConfiguration sarifReportingConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifToolComponent struct {
+	Name           string                      `json:"name"`
+	Version        string                      `json:"version"`
+	InformationURI string                      `json:"informationUri"`
+	Rules          []*sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifTool struct {
+	Driver sarifToolComponent `json:"driver"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifArtifactContent struct {
+	Text string `json:"text"`
+}
+
+type sarifRegion struct {
+	StartLine   int                   `json:"startLine"`
+	StartColumn int                   `json:"startColumn"`
+	EndColumn   int                   `json:"endColumn,omitempty"`
+	Snippet     *sarifArtifactContent `json:"snippet,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+const checksDocumentURL = "https://github.com/rhysd/actionlint/blob/main/docs/checks.md"
+
+// printErrorsInSARIF prints the errors as a SARIF 2.1.0 log file with one run.
+func printErrorsInSARIF(out io.Writer, errs []*ErrorTemplateFields, rules []*ruleTemplateFields, files []string) error {
+	descs := make([]*sarifReportingDescriptor, 0, len(rules))
+	indices := make(map[string]int, len(rules))
+	for i, r := range rules {
+		descs = append(descs, &sarifReportingDescriptor{
+			ID:                   r.Name,
+			Name:                 toPascalCase(r.Name),
+			ShortDescription:     sarifMessage{r.Description},
+			FullDescription:      sarifMessage{r.Description},
+			HelpURI:              checksDocumentURL,
+			DefaultConfiguration: sarifReportingConfiguration{"error"},
+		})
+		indices[r.Name] = i
+	}
+
+	results := make([]*sarifResult, 0, len(errs))
+	for _, e := range errs {
+		idx, ok := indices[e.Kind]
+		if !ok {
+			idx = -1
+		}
+
+		region := sarifRegion{
+			StartLine:   e.Line,
+			StartColumn: e.Column,
+		}
+		// Snippet is empty when the position of the error could not be shown with indicator. In the
+		// case, the end of the region is unknown
+		if e.Snippet != "" {
+			region.EndColumn = e.EndColumn + 1 // endColumn is exclusive in SARIF
+			line, _, _ := strings.Cut(e.Snippet, "\n")
+			region.Snippet = &sarifArtifactContent{line}
+		}
+
+		results = append(results, &sarifResult{
+			RuleID:    e.Kind,
+			RuleIndex: idx,
+			Level:     severityOrDefault(e.Severity),
+			Message:   sarifMessage{e.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							URI:       filepath.ToSlash(e.Filepath),
+							URIBaseID: "%SRCROOT%",
+						},
+						Region: region,
+					},
+				},
+			},
+		})
+	}
+
+	log := &sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []*sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifToolComponent{
+						Name:           "actionlint",
+						Version:        getCommandVersion(),
+						InformationURI: "https://github.com/rhysd/actionlint",
+						Rules:          descs,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("could not encode errors into SARIF: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package actionlint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/execabs"
+)
+
+const (
+	// shellcheckCacheMaxAge is the duration after which a cache entry not used is evicted.
+	shellcheckCacheMaxAge = 30 * 24 * time.Hour
+	// shellcheckCacheMaxEntries is the maximum number of cache entries. The least recently used
+	// entries are evicted when the number of entries exceeds this value.
+	shellcheckCacheMaxEntries = 10000
+)
+
+// shellcheckCache is an on-disk cache of shellcheck results. The results are keyed by the SHA-256
+// hash of the script, the arguments, $SHELLCHECK_OPTS, and the version of shellcheck. One cache is shared by all
+// rules created by one Linter instance and it is safe to use the cache concurrently, including
+// the usage from multiple actionlint processes. The modification time of a cache file is updated
+// when the entry is used so that old entries can be evicted in least recently used order.
+type shellcheckCache struct {
+	dir        string
+	once       sync.Once
+	version    string
+	opts       string
+	evictOnce  sync.Once
+	maxAge     time.Duration
+	maxEntries int
+}
+
+// newShellcheckCache creates a new shellcheckCache instance. When dir is empty, the default
+// directory in the user cache directory (e.g. $XDG_CACHE_HOME/actionlint/shellcheck) is used. Nil
+// is returned when the cache directory cannot be determined.
+func newShellcheckCache(dir string) *shellcheckCache {
+	if dir == "" {
+		d, err := os.UserCacheDir()
+		if err != nil {
+			return nil
+		}
+		dir = filepath.Join(d, "actionlint", "shellcheck")
+	}
+	return &shellcheckCache{
+		dir:        dir,
+		opts:       os.Getenv("SHELLCHECK_OPTS"),
+		maxAge:     shellcheckCacheMaxAge,
+		maxEntries: shellcheckCacheMaxEntries,
+	}
+}
+
+// available returns true when the cache is available for the shellcheck command. The version of
+// shellcheck is retrieved only once at the first call. When the version cannot be retrieved,
+// the cache is not available.
+func (c *shellcheckCache) available(cmd *externalCommand) bool {
+	c.once.Do(func() {
+		args := append(append([]string{}, cmd.args...), "--version")
+		out, err := execabs.Command(cmd.exe, args...).Output()
+		if err != nil {
+			return
+		}
+		// The output of `shellcheck --version` contains "version: 0.9.0" line
+		for _, l := range strings.Split(string(out), "\n") {
+			if l = strings.TrimSpace(l); strings.HasPrefix(l, "version:") {
+				c.version = strings.TrimSpace(strings.TrimPrefix(l, "version:"))
+				return
+			}
+		}
+	})
+	return c.version != ""
+}
+
+func (c *shellcheckCache) key(args []string, script string) string {
+	h := sha256.New()
+	h.Write([]byte(c.version))
+	// shellcheck reads options from $SHELLCHECK_OPTS even if --norc is given
+	h.Write([]byte{0})
+	h.Write([]byte(c.opts))
+	for _, a := range args {
+		h.Write([]byte{0})
+		h.Write([]byte(a))
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(script))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *shellcheckCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// get returns the cached shellcheck errors for the key. The second return value is false when
+// the cache is missing or broken.
+func (c *shellcheckCache) get(key string) ([]shellcheckError, bool) {
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	errs := []shellcheckError{}
+	if err := json.Unmarshal(b, &errs); err != nil {
+		return nil, false
+	}
+	// Mark the entry as recently used. Failing to update the time only makes the entry evicted earlier
+	now := time.Now()
+	os.Chtimes(c.path(key), now, now)
+	return errs, true
+}
+
+// put stores the shellcheck errors for the key. The cache file is written atomically by renaming
+// a temporary file so that other goroutines and processes never read a partially written file.
+func (c *shellcheckCache) put(key string, errs []shellcheckError) error {
+	b, err := json.Marshal(errs)
+	if err != nil {
+		return fmt.Errorf("could not encode shellcheck result into JSON: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("could not create shellcheck cache directory %q: %w", c.dir, err)
+	}
+	// Evict old entries only once per Linter instance since listing the directory is not cheap
+	c.evictOnce.Do(func() { c.evict(time.Now()) })
+	f, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temporary file for shellcheck cache: %w", err)
+	}
+	tmp := f.Name()
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write shellcheck cache: %w", err)
+	}
+	return nil
+}
+
+// evict removes cache entries which were not used for longer than maxAge. When the number of the
+// remaining entries still exceeds maxEntries, the least recently used entries are removed. Temporary
+// files left by interrupted processes are also removed after maxAge. Errors are ignored since the
+// entries may be removed by other processes concurrently.
+func (c *shellcheckCache) evict(now time.Time) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	type cacheFile struct {
+		path  string
+		mtime time.Time
+	}
+	files := make([]cacheFile, 0, len(entries))
+	for _, e := range entries {
+		n := e.Name()
+		if e.IsDir() || !strings.HasSuffix(n, ".json") && !strings.HasSuffix(n, ".tmp") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		p := filepath.Join(c.dir, n)
+		if now.Sub(info.ModTime()) > c.maxAge {
+			os.Remove(p)
+			continue
+		}
+		if strings.HasSuffix(n, ".json") {
+			files = append(files, cacheFile{p, info.ModTime()})
+		}
+	}
+
+	if len(files) <= c.maxEntries {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].mtime.Before(files[j].mtime)
+	})
+	for _, f := range files[:len(files)-c.maxEntries] {
+		os.Remove(f.path)
+	}
+}
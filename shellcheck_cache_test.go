@@ -0,0 +1,228 @@
+package actionlint
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestShellcheckCacheGetPut(t *testing.T) {
+	c := newShellcheckCache(filepath.Join(t.TempDir(), "cache"))
+	c.version = "0.9.0"
+
+	k := c.key([]string{"-f", "json"}, "echo $FOO")
+	if _, ok := c.get(k); ok {
+		t.Fatal("cache should be missing before putting the result")
+	}
+
+	want := []shellcheckError{
+		{Line: 1, Column: 6, Level: "info", Code: 2086, Message: "Double quote to prevent globbing and word splitting."},
+	}
+	if err := c.put(k, want); err != nil {
+		t.Fatal(err)
+	}
+	have, ok := c.get(k)
+	if !ok {
+		t.Fatal("cache was not found after putting the result")
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Empty result is also cached
+	k = c.key([]string{"-f", "json"}, "echo hello")
+	if err := c.put(k, []shellcheckError{}); err != nil {
+		t.Fatal(err)
+	}
+	have, ok = c.get(k)
+	if !ok {
+		t.Fatal("empty result was not cached")
+	}
+	if len(have) != 0 {
+		t.Fatalf("empty result was expected but got %v", have)
+	}
+}
+
+func TestShellcheckCacheKey(t *testing.T) {
+	c := &shellcheckCache{version: "0.9.0"}
+	k := c.key([]string{"--shell", "bash"}, "echo hi")
+
+	if k != c.key([]string{"--shell", "bash"}, "echo hi") {
+		t.Fatal("key is not stable for the same inputs")
+	}
+	if k == c.key([]string{"--shell", "sh"}, "echo hi") {
+		t.Fatal("key should be different when the arguments are different")
+	}
+	if k == c.key([]string{"--shell", "bash"}, "echo bye") {
+		t.Fatal("key should be different when the script is different")
+	}
+	if k == c.key([]string{"--shell", "bashecho hi"}, "") {
+		t.Fatal("key should be different when the boundary of the arguments and the script is different")
+	}
+	c2 := &shellcheckCache{version: "0.10.0"}
+	if k == c2.key([]string{"--shell", "bash"}, "echo hi") {
+		t.Fatal("key should be different when the version of shellcheck is different")
+	}
+	c3 := &shellcheckCache{version: "0.9.0", opts: "--exclude=SC2086"}
+	if k == c3.key([]string{"--shell", "bash"}, "echo hi") {
+		t.Fatal("key should be different when $SHELLCHECK_OPTS is different")
+	}
+}
+
+func TestShellcheckCacheBrokenFile(t *testing.T) {
+	c := newShellcheckCache(t.TempDir())
+	c.version = "0.9.0"
+	k := c.key(nil, "echo hi")
+	if err := os.WriteFile(c.path(k), []byte("{broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if errs, ok := c.get(k); ok {
+		t.Fatalf("broken cache should be ignored but got %v", errs)
+	}
+}
+
+func TestShellcheckCacheConcurrentAccess(t *testing.T) {
+	c := newShellcheckCache(t.TempDir())
+	c.version = "0.9.0"
+	k := c.key(nil, "echo $FOO")
+	want := []shellcheckError{{Line: 1, Column: 6, Level: "info", Code: 2086, Message: "msg"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.put(k, want); err != nil {
+				t.Error(err)
+			}
+			if have, ok := c.get(k); ok {
+				if diff := cmp.Diff(want, have); diff != "" {
+					t.Error(diff)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("only one cache file should remain but got %d entries", len(entries))
+	}
+}
+
+func TestShellcheckCacheUnavailableWithoutVersion(t *testing.T) {
+	c := newShellcheckCache(t.TempDir())
+	cmd := &externalCommand{exe: filepath.Join(t.TempDir(), "no-such-shellcheck")}
+	if c.available(cmd) {
+		t.Fatal("cache should not be available when version of shellcheck cannot be retrieved")
+	}
+}
+
+func TestShellcheckCacheEvictOldEntries(t *testing.T) {
+	c := newShellcheckCache(t.TempDir())
+	c.version = "0.9.0"
+
+	old := c.key(nil, "echo old")
+	recent := c.key(nil, "echo recent")
+	for _, k := range []string{old, recent} {
+		if err := os.WriteFile(c.path(k), []byte("[]"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tmp := filepath.Join(c.dir, old+".123.tmp")
+	if err := os.WriteFile(tmp, []byte("["), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	past := now.Add(-c.maxAge - time.Hour)
+	for _, p := range []string{c.path(old), tmp} {
+		if err := os.Chtimes(p, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c.evict(now)
+
+	if _, ok := c.get(old); ok {
+		t.Error("old entry should be evicted")
+	}
+	if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+		t.Error("stale temporary file should be removed", err)
+	}
+	if _, ok := c.get(recent); !ok {
+		t.Error("recent entry should not be evicted")
+	}
+}
+
+func TestShellcheckCacheEvictLeastRecentlyUsedEntries(t *testing.T) {
+	c := newShellcheckCache(t.TempDir())
+	c.version = "0.9.0"
+	c.maxEntries = 2
+
+	now := time.Now()
+	keys := []string{}
+	for i, s := range []string{"echo 1", "echo 2", "echo 3", "echo 4"} {
+		k := c.key(nil, s)
+		if err := os.WriteFile(c.path(k), []byte("[]"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(time.Duration(i-4) * time.Minute)
+		if err := os.Chtimes(c.path(k), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, k)
+	}
+
+	c.evict(now)
+
+	for i, k := range keys {
+		_, err := os.Stat(c.path(k))
+		if i < 2 && !os.IsNotExist(err) {
+			t.Errorf("entry %d should be evicted: %v", i, err)
+		}
+		if i >= 2 && err != nil {
+			t.Errorf("entry %d should remain: %v", i, err)
+		}
+	}
+}
+
+func TestShellcheckCacheGetUpdatesModTime(t *testing.T) {
+	c := newShellcheckCache(t.TempDir())
+	c.version = "0.9.0"
+	k := c.key(nil, "echo hi")
+	if err := c.put(k, []shellcheckError{}); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(c.path(k), past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.get(k); !ok {
+		t.Fatal("cache was not found")
+	}
+
+	info, err := os.Stat(c.path(k))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().After(past) {
+		t.Fatalf("modification time should be updated on cache hit but it is %s", info.ModTime())
+	}
+}
+
+func TestShellcheckCacheReadShellcheckOpts(t *testing.T) {
+	t.Setenv("SHELLCHECK_OPTS", "--exclude=SC2086")
+	c := newShellcheckCache(t.TempDir())
+	if c.opts != "--exclude=SC2086" {
+		t.Fatalf("$SHELLCHECK_OPTS was not read: %q", c.opts)
+	}
+}
@@ -0,0 +1,73 @@
+package actionlint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// editDistance returns Levenshtein distance between the two strings. The distance is calculated
+// for bytes since the strings compared in this package are ASCII.
+func editDistance(a, b string) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cur := row[j]
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := prev + cost // Substitution
+			if row[j]+1 < d {
+				d = row[j] + 1 // Deletion
+			}
+			if row[j-1]+1 < d {
+				d = row[j-1] + 1 // Insertion
+			}
+			row[j] = d
+			prev = cur
+		}
+	}
+	return row[len(b)]
+}
+
+// closestString returns the candidate which is the most similar to the given string. Letter cases
+// are ignored on comparing strings. When no candidate is similar enough, this function returns
+// false as the second return value. When multiple candidates have the same distance, the first one
+// is returned.
+func closestString(s string, candidates []string) (string, bool) {
+	s = strings.ToLower(s)
+	best, dist := "", -1
+	for _, c := range candidates {
+		d := editDistance(s, strings.ToLower(c))
+		// The threshold is a half of the longer string's length
+		l := len(s)
+		if len(c) > l {
+			l = len(c)
+		}
+		if d > l/2 {
+			continue
+		}
+		if dist < 0 || d < dist {
+			best, dist = c, d
+		}
+	}
+	return best, dist >= 0
+}
+
+// didYouMean returns the suggestion message for the mistyped value like ` did you mean "foo"?`.
+// An empty string is returned when no candidate is similar to the value.
+func didYouMean(v string, candidates []string) string {
+	c, ok := closestString(v, candidates)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" did you mean %q?", c)
+}
@@ -0,0 +1,60 @@
+package actionlint
+
+import (
+	"testing"
+)
+
+func TestSimilarEditDistance(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"content", "contents", 1},
+		{"contents", "content", 1},
+		{"kitten", "sitting", 3},
+		{"readonly", "read", 4},
+		{"pull-request", "pull-requests", 1},
+	}
+
+	for _, tc := range tests {
+		if have := editDistance(tc.a, tc.b); have != tc.want {
+			t.Errorf("edit distance between %q and %q should be %d but got %d", tc.a, tc.b, tc.want, have)
+		}
+	}
+}
+
+func TestSimilarClosestString(t *testing.T) {
+	cands := []string{"read", "write", "none"}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"readonly", "read"},
+		{"readable", "read"},
+		{"writable", "write"},
+		{"WRITE", "write"},
+		{"non", "none"},
+		{"xyz", ""},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		have, ok := closestString(tc.input, cands)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("no candidate should be found for %q but got %q", tc.input, have)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("%q should be found for %q but not found", tc.want, tc.input)
+		} else if have != tc.want {
+			t.Errorf("%q should be found for %q but got %q", tc.want, tc.input, have)
+		}
+	}
+}
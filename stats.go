@@ -0,0 +1,47 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
+
+// printLintStats prints the summary of the errors found in the linted files to the writer. It is
+// enabled by -stats option. The first line is a one-line summary such as "3 errors in 2 files (5
+// files checked)" and the following lines are the numbers of errors per rule in descending order.
+func printLintStats(out io.Writer, errs []*Error, checked int) {
+	files := map[string]struct{}{}
+	rules := map[string]int{}
+	width := 0
+	for _, err := range errs {
+		files[err.Filepath] = struct{}{}
+		rules[err.Kind]++
+		if len(err.Kind) > width {
+			width = len(err.Kind)
+		}
+	}
+
+	fmt.Fprintf(out, "%s in %s (%s checked)\n", plural(len(errs), "error"), plural(len(files), "file"), plural(checked, "file"))
+
+	names := make([]string, 0, len(rules))
+	for n := range rules {
+		names = append(names, n)
+	}
+	// Most frequent first. Sort by name for the same count to make the output deterministic
+	sort.Slice(names, func(i, j int) bool {
+		if rules[names[i]] != rules[names[j]] {
+			return rules[names[i]] > rules[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, n := range names {
+		fmt.Fprintf(out, "  %-*s  %d\n", width, n, rules[n])
+	}
+}
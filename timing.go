@@ -0,0 +1,178 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// lintTimings collects cumulative wall time spent in each rule and each external command such as
+// shellcheck while linting files. It is enabled by -timing option for profiling slow lints. Time
+// of rules is accumulated per file and merged once after visiting the file so that the overhead
+// is small.
+type lintTimings struct {
+	mu     sync.Mutex
+	rules  map[string]time.Duration
+	cmds   map[string]time.Duration
+	runs   map[string]int
+	files  int
+	errors int
+}
+
+func newLintTimings() *lintTimings {
+	return &lintTimings{
+		rules: map[string]time.Duration{},
+		cmds:  map[string]time.Duration{},
+		runs:  map[string]int{},
+	}
+}
+
+func (t *lintTimings) addPasses(passes []*timedPass) {
+	t.mu.Lock()
+	for _, p := range passes {
+		t.rules[p.name] += p.elapsed
+	}
+	t.mu.Unlock()
+}
+
+func (t *lintTimings) addCommand(exe string, d time.Duration) {
+	name := filepath.Base(exe)
+	t.mu.Lock()
+	t.cmds[name] += d
+	t.runs[name]++
+	t.mu.Unlock()
+}
+
+func (t *lintTimings) addFile(errs int) {
+	t.mu.Lock()
+	t.files++
+	t.errors += errs
+	t.mu.Unlock()
+}
+
+func sortedDurations(m map[string]time.Duration) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	// Slowest first. Sort by name for the same duration to make the output deterministic
+	sort.Slice(ks, func(i, j int) bool {
+		if m[ks[i]] != m[ks[j]] {
+			return m[ks[i]] > m[ks[j]]
+		}
+		return ks[i] < ks[j]
+	})
+	return ks
+}
+
+// print prints the collected timings to the writer. Rules and external commands are sorted in
+// descending order of the time spent in them.
+func (t *lintTimings) print(out io.Writer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	width := 0
+	for n := range t.rules {
+		if len(n) > width {
+			width = len(n)
+		}
+	}
+	for n := range t.cmds {
+		if len(n) > width {
+			width = len(n)
+		}
+	}
+
+	fmt.Fprintf(out, "Timing: %d files, %d errors\n", t.files, t.errors)
+	fmt.Fprintln(out, "  Rules:")
+	var total time.Duration
+	for _, n := range sortedDurations(t.rules) {
+		d := t.rules[n]
+		total += d
+		fmt.Fprintf(out, "    %-*s  %s\n", width, n, formatDuration(d))
+	}
+	fmt.Fprintf(out, "    %-*s  %s\n", width, "(total)", formatDuration(total))
+	if len(t.cmds) > 0 {
+		fmt.Fprintln(out, "  External commands:")
+		for _, n := range sortedDurations(t.cmds) {
+			fmt.Fprintf(out, "    %-*s  %s (%d runs)\n", width, n, formatDuration(t.cmds[n]), t.runs[n])
+		}
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.3fms", float64(d.Microseconds())/1000)
+}
+
+// timedPass is a pass which wraps the pass of the rule to measure the time spent in its callbacks.
+// The instance is created per file so it is not accessed concurrently.
+type timedPass struct {
+	pass    Pass
+	name    string
+	elapsed time.Duration
+}
+
+// newTimedPass wraps the rule to measure its time. The returned pass implements ActionPass when
+// the rule implements it.
+func newTimedPass(r Rule) (*timedPass, Pass) {
+	p := &timedPass{pass: r, name: r.Name()}
+	if a, ok := r.(ActionPass); ok {
+		return p, &timedActionPass{p, a}
+	}
+	return p, p
+}
+
+func (p *timedPass) measure(start time.Time) {
+	p.elapsed += time.Since(start)
+}
+
+// VisitStep is callback when visiting Step node.
+func (p *timedPass) VisitStep(n *Step) error {
+	defer p.measure(time.Now())
+	return p.pass.VisitStep(n)
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (p *timedPass) VisitJobPre(n *Job) error {
+	defer p.measure(time.Now())
+	return p.pass.VisitJobPre(n)
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (p *timedPass) VisitJobPost(n *Job) error {
+	defer p.measure(time.Now())
+	return p.pass.VisitJobPost(n)
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (p *timedPass) VisitWorkflowPre(n *Workflow) error {
+	defer p.measure(time.Now())
+	return p.pass.VisitWorkflowPre(n)
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (p *timedPass) VisitWorkflowPost(n *Workflow) error {
+	defer p.measure(time.Now())
+	return p.pass.VisitWorkflowPost(n)
+}
+
+// timedActionPass is timedPass for the rule which also checks action metadata.
+type timedActionPass struct {
+	*timedPass
+	action ActionPass
+}
+
+// VisitActionPre is callback when visiting Action node before visiting its children.
+func (p *timedActionPass) VisitActionPre(n *Action) error {
+	defer p.measure(time.Now())
+	return p.action.VisitActionPre(n)
+}
+
+// VisitActionPost is callback when visiting Action node after visiting its children.
+func (p *timedActionPass) VisitActionPost(n *Action) error {
+	defer p.measure(time.Now())
+	return p.action.VisitActionPost(n)
+}